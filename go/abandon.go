@@ -0,0 +1,52 @@
+/**
+ * Guardial Go SDK Client Abandonment
+ * Cancels the analysis of requests whose client disconnected
+ */
+
+package guardial
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ActionAbandoned is reported for requests whose client disconnected (the request
+// context was canceled) before the verdict arrived. The analysis call is canceled, so
+// it doesn't use up quota or a connection, and the request doesn't reach the handler.
+const ActionAbandoned = "client_abandoned"
+
+// statusClientClosedRequest is the de facto status of requests the client gave up on
+const statusClientClosedRequest = 499
+
+// abandoned reports whether err is the analysis of a request giving up because its
+// client went away, rather than the API failing. AnalysisTimeout expiring doesn't count.
+func abandoned(ctx context.Context, err error) bool {
+	return err != nil && errors.Is(ctx.Err(), context.Canceled)
+}
+
+// abandon records that the client of event went away during its analysis, and returns
+// the verdict and rejection that keep the request from reaching the handler. Session
+// analytics count the request as abandoned rather than blocked, and OnAbandoned hears
+// of it.
+func (m *middleware) abandon(ctx context.Context, event *SecurityEventRequest, start time.Time) (*SecurityEventResponse, *Rejection) {
+	m.client.log("🔌 Client abandoned request, analysis canceled:", event.Method, event.Path, event.EventID)
+	trace := traceFromContext(ctx)
+	trace.record("analysis", start, ActionAbandoned)
+	trace.abandon()
+	if state := requestStateFromContext(ctx); state != nil {
+		state.abandoned = true
+	}
+	if m.options.OnAbandoned != nil {
+		m.options.OnAbandoned(context.WithoutCancel(ctx), event)
+	}
+
+	analysis := &SecurityEventResponse{EventID: event.EventID, Action: ActionAbandoned}
+	return analysis, &Rejection{Status: statusClientClosedRequest, Message: "Client closed request", Analysis: analysis}
+}
+
+// abandoned reports whether the rejection is for a request its client gave up on; such
+// requests are dropped without a response, even in monitor mode
+func (r *Rejection) abandoned() bool {
+	return r != nil && r.Analysis != nil && r.Analysis.Action == ActionAbandoned
+}
@@ -0,0 +1,63 @@
+package guardial
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAbandonedRequests(t *testing.T) {
+	tests := []struct {
+		name          string
+		disconnect    bool // The client goes away while the API is deciding
+		wantBlocked   int
+		wantAbandoned int
+	}{
+		{"blocked", false, 1, 0},
+		{"abandoned", true, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, disconnect := context.WithCancel(context.Background())
+			defer disconnect()
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if tt.disconnect {
+					io.Copy(io.Discard, r.Body) // Lets the server notice the canceled call
+					disconnect()
+					<-r.Context().Done()
+					return
+				}
+				w.Write([]byte(blockedVerdict))
+			})
+			var reported []*SecurityEventRequest
+			options := DefaultMiddlewareOptions()
+			options.SessionAnalytics = &SessionAnalyticsOptions{Interval: time.Hour}
+			options.OnAbandoned = func(ctx context.Context, event *SecurityEventRequest) {
+				if ctx.Err() != nil {
+					t.Errorf("OnAbandoned ctx already done: %v", ctx.Err())
+				}
+				reported = append(reported, event)
+			}
+			m := newMiddleware(client, options)
+
+			req := httptest.NewRequest("GET", "/api/orders", nil).WithContext(ctx)
+			m.serve(httptest.NewRecorder(), req, func(http.ResponseWriter, *http.Request) {
+				t.Error("request reached the handler")
+			})
+
+			rollups := m.sessions.rollups(time.Now())
+			if len(rollups) != 1 {
+				t.Fatalf("%d rollups, want 1", len(rollups))
+			}
+			if rollups[0].Blocked != tt.wantBlocked || rollups[0].Abandoned != tt.wantAbandoned {
+				t.Errorf("rollup blocked %d, abandoned %d; want %d, %d", rollups[0].Blocked, rollups[0].Abandoned, tt.wantBlocked, tt.wantAbandoned)
+			}
+			if len(reported) != tt.wantAbandoned {
+				t.Errorf("OnAbandoned called %d times, want %d", len(reported), tt.wantAbandoned)
+			}
+		})
+	}
+}
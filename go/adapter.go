@@ -0,0 +1,113 @@
+/**
+ * Guardial Go SDK Framework Adapters
+ * The middleware pipeline exposed for framework-specific adapters
+ */
+
+package guardial
+
+import (
+	"context"
+	"net/http"
+)
+
+// Guard runs the middleware pipeline (blocklists, canaries, fingerprints, analysis,
+// crash telemetry, degradation) for framework adapters such as guardialgin that can't
+// use a plain net/http middleware.
+type Guard struct {
+	m *middleware
+}
+
+// NewGuard creates a Guard with the given middleware options
+func NewGuard(client *Client, options *MiddlewareOptions) *Guard {
+	return &Guard{m: newMiddleware(client, options)}
+}
+
+// OnReject replaces the default JSON error response for refused requests, so adapters
+// can answer through their framework (e.g. gin's AbortWithStatusJSON).
+// MiddlewareOptions.BlockHandler still takes precedence for blocked requests.
+func (g *Guard) OnReject(fn func(w http.ResponseWriter, r *http.Request, rejection Rejection)) {
+	g.m.rejecter = fn
+}
+
+// Serve analyzes r and, if it may proceed, calls next with the request carrying the
+// verdict (see FromContext) and the writer the response must go through
+func (g *Guard) Serve(w http.ResponseWriter, r *http.Request, next func(http.ResponseWriter, *http.Request)) {
+	g.m.serve(w, r, next)
+}
+
+// Check runs the steps of the pipeline that don't need net/http types (exclusions,
+// blocklist, GraphQL operations, sampling, analysis) for adapters that build events
+// with Client.NewEvent, such as guardialfiber. It returns the verdict, nil if the
+// request proceeds unanalyzed, and the rejection to answer with if it must not
+// proceed; set its Headers on the response.
+func (g *Guard) Check(ctx context.Context, event *SecurityEventRequest) (*SecurityEventResponse, *Rejection) {
+	m := g.m
+	if m.excluded(event.Method, event.Path) {
+		return nil, nil
+	}
+	if len(m.webhooks) > 0 {
+		header := make(http.Header, len(event.Headers))
+		for name, value := range event.Headers {
+			header.Set(name, value)
+		}
+		if rejection := m.checkWebhook(ctx, event, header, []byte(event.RequestBody), event.BodyTruncated); rejection != nil && !m.monitor(ctx, event, rejection) {
+			m.decisionHeaders(rejection)
+			return nil, rejection
+		}
+	}
+	m.expandMultipart(event)
+	if decision, blocked := m.client.IsBlocked(event.PeerIP); blocked && m.overridden(ctx, event) == nil {
+		m.client.log("🚫 Request from blocked IP:", decision.IP, decision.Reason)
+		rejection := &Rejection{Status: http.StatusForbidden, Message: blockedMessage, Category: ReasonCategoryBlocklist}
+		if !m.monitor(ctx, event, rejection) {
+			m.decisionHeaders(rejection)
+			return nil, rejection
+		}
+	}
+	if rejection := m.watchGraphQL(ctx, event); rejection != nil && !m.monitor(ctx, event, rejection) {
+		m.decisionHeaders(rejection)
+		return nil, rejection
+	}
+	analysis, rejection := m.analyze(ctx, event)
+	if rejection.abandoned() {
+		return analysis, rejection
+	}
+	if rejection != nil {
+		if m.monitor(ctx, event, rejection) {
+			return analysis, nil
+		}
+		m.decisionHeaders(rejection)
+	}
+	return analysis, rejection
+}
+
+// RequestParts describes a request for frameworks that don't use net/http (e.g.
+// fasthttp). Header names must be in canonical form ("X-Forwarded-For").
+type RequestParts struct {
+	Method     string
+	Path       string
+	RawQuery   string
+	RemoteAddr string // Peer address, host:port
+	Headers    map[string]string
+	Body       []byte
+}
+
+// NewEvent builds the security event for a request described by parts
+func (c *Client) NewEvent(parts RequestParts) *SecurityEventRequest {
+	header := func(name string) string { return parts.Headers[name] }
+	sourceIP, peerIP := c.addresses(header, parts.Headers["X-Forwarded-For"], parts.RemoteAddr, false)
+	return &SecurityEventRequest{
+		EventID:     c.newEventID(),
+		Method:      parts.Method,
+		Path:        parts.Path,
+		SourceIP:    sourceIP,
+		PeerIP:      peerIP,
+		UserAgent:   parts.Headers["User-Agent"],
+		Headers:     parts.Headers,
+		QueryParams: parts.RawQuery,
+		RequestBody: string(parts.Body),
+		CustomerID:  c.config.CustomerID,
+		HasAuth:     hasAuthHeader(header),
+		SessionID:   c.sessionID,
+	}
+}
@@ -0,0 +1,278 @@
+/**
+ * Guardial Go SDK Anonymization
+ * Per-jurisdiction IP truncation and user pseudonymization of outgoing events
+ */
+
+package guardial
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"net"
+	"strings"
+	"time"
+)
+
+// defaultSaltRotation is how long a pseudonymization salt is used
+const defaultSaltRotation = 24 * time.Hour
+
+// ipHeaders carry client addresses and are truncated along with SourceIP
+var ipHeaders = []string{"X-Forwarded-For", "X-Real-Ip", "X-Client-Ip", "Cf-Connecting-Ip", "True-Client-Ip"}
+
+// credentialHeaders carry secrets, which are masked in events that leave the process
+// for anything but inline analysis, e.g. review queues, spool files, and published or
+// audit-logged events
+var credentialHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key", "X-Auth-Token"}
+
+// redactedValue replaces the value of masked headers
+const redactedValue = "[REDACTED]"
+
+// AnonymizationRule describes how events are anonymized before they leave the process
+type AnonymizationRule struct {
+	Countries []string `json:"countries"` // ISO 3166-1 alpha-2 origins the rule applies to
+
+	// Leading bits of source addresses kept, the rest zeroed, e.g. 24 for IPv4 and 48
+	// for IPv6; 0 sends addresses unchanged. Forwarding headers are truncated too.
+	IPv4PrefixBits int `json:"ipv4_prefix_bits"`
+	IPv6PrefixBits int `json:"ipv6_prefix_bits"`
+
+	// UserHeaders carry user identifiers (e.g. "X-User-Id") and are replaced with
+	// pseudonyms: keyed hashes that are stable while a salt is in use
+	UserHeaders []string `json:"user_headers"`
+
+	DropHeaders []string `json:"drop_headers"` // Removed outright, e.g. "Cookie"
+}
+
+// AnonymizationConfig anonymizes events per origin jurisdiction before they are sent to
+// the API, a Transport, or response analysis, so one deployment can meet different
+// data-protection regimes. Local enforcement (blocklists, overrides, trust) still sees
+// the original values. Origins come from CountryCode, so configure a GeoResolver.
+type AnonymizationConfig struct {
+	// Rules apply by origin; the first rule listing an event's country applies
+	Rules []AnonymizationRule `json:"rules"`
+
+	// Default applies to events no rule matches, including those of unknown origin;
+	// nil sends them unchanged
+	Default *AnonymizationRule `json:"default,omitempty"`
+
+	// Secret keys pseudonyms. Set the same value on every instance so they agree on
+	// pseudonyms; if empty, each process uses a random one.
+	Secret string `json:"-"`
+
+	// SaltRotation is how long pseudonyms stay stable; a user's pseudonyms from
+	// different periods can't be linked (default: 24h)
+	SaltRotation time.Duration `json:"salt_rotation"`
+}
+
+// anonymizer applies an AnonymizationConfig
+type anonymizer struct {
+	config AnonymizationConfig
+	rules  map[string]*AnonymizationRule // By upper-case country code
+}
+
+func newAnonymizer(config *AnonymizationConfig) *anonymizer {
+	cfg := *config
+	if cfg.Secret == "" {
+		secret := make([]byte, 32)
+		_, _ = rand.Read(secret)
+		cfg.Secret = string(secret)
+	}
+	if cfg.SaltRotation <= 0 {
+		cfg.SaltRotation = defaultSaltRotation
+	}
+	a := &anonymizer{config: cfg, rules: make(map[string]*AnonymizationRule)}
+	for i := range cfg.Rules {
+		for _, country := range cfg.Rules[i].Countries {
+			country = strings.ToUpper(country)
+			if _, exists := a.rules[country]; !exists {
+				a.rules[country] = &cfg.Rules[i]
+			}
+		}
+	}
+	return a
+}
+
+// rule returns the rule for an origin, or nil
+func (a *anonymizer) rule(country string) *AnonymizationRule {
+	if rule, ok := a.rules[strings.ToUpper(country)]; ok {
+		return rule
+	}
+	return a.config.Default
+}
+
+// anonymize returns event as it may leave the process: event itself if no rule applies,
+// otherwise an anonymized copy
+func (c *Client) anonymize(event *SecurityEventRequest) *SecurityEventRequest {
+	if c.anonymizer == nil || event == nil {
+		return event
+	}
+	rule := c.anonymizer.rule(event.CountryCode)
+	if rule == nil {
+		return event
+	}
+
+	anonymized := *event
+	anonymized.SourceIP = truncateIP(event.SourceIP, rule)
+	anonymized.Headers = c.anonymizeHeaders(event.Headers, rule)
+	return &anonymized
+}
+
+// anonymizeHeaders returns a copy of headers with rule applied. Dropping "Cookie" also
+// drops "Set-Cookie", so captured responses don't hand back the cookies requests lost.
+func (c *Client) anonymizeHeaders(headers map[string]string, rule *AnonymizationRule) map[string]string {
+	anonymized := make(map[string]string, len(headers))
+	for name, value := range headers {
+		switch {
+		case containsFold(rule.DropHeaders, name),
+			strings.EqualFold(name, "Set-Cookie") && containsFold(rule.DropHeaders, "Cookie"):
+			continue
+		case containsFold(rule.UserHeaders, name):
+			value = c.anonymizer.pseudonym(value, time.Now())
+		case containsFold(ipHeaders, name):
+			addresses := strings.Split(value, ",")
+			for i, address := range addresses {
+				addresses[i] = truncateIP(strings.TrimSpace(address), rule)
+			}
+			value = strings.Join(addresses, ", ")
+		}
+		anonymized[name] = value
+	}
+	return anonymized
+}
+
+// anonymizeResponseHeaders returns the headers of a response to event as they may
+// leave the process, under the rule for event's origin
+func (c *Client) anonymizeResponseHeaders(event *SecurityEventRequest, headers map[string]string) map[string]string {
+	if c.anonymizer == nil {
+		return headers
+	}
+	rule := c.anonymizer.rule(event.CountryCode)
+	if rule == nil {
+		return headers
+	}
+	return c.anonymizeHeaders(headers, rule)
+}
+
+// anonymizeFinding returns finding as it may leave the process. Findings carry no
+// origin, so it is resolved from SourceIP with the GeoResolver, if any. Addresses are
+// truncated wherever they appear: SourceIP, metadata values, and the evidence text.
+func (c *Client) anonymizeFinding(finding *Finding) *Finding {
+	if c.anonymizer == nil || finding.SourceIP == "" {
+		return finding
+	}
+	country := ""
+	if c.config.GeoResolver != nil {
+		country, _, _ = c.config.GeoResolver.Resolve(finding.SourceIP)
+	}
+	rule := c.anonymizer.rule(country)
+	if rule == nil {
+		return finding
+	}
+
+	anonymized := *finding
+	anonymized.SourceIP = truncateIP(finding.SourceIP, rule)
+	if anonymized.SourceIP != finding.SourceIP {
+		anonymized.Evidence = strings.ReplaceAll(anonymized.Evidence, finding.SourceIP, anonymized.SourceIP)
+	}
+	if finding.Metadata != nil {
+		anonymized.Metadata = make(map[string]string, len(finding.Metadata))
+		for name, value := range finding.Metadata {
+			if truncated := truncateIP(value, rule); truncated != value {
+				anonymized.Evidence = strings.ReplaceAll(anonymized.Evidence, value, truncated)
+				value = truncated
+			}
+			anonymized.Metadata[name] = value
+		}
+	}
+	return &anonymized
+}
+
+// sessionKey returns the key a session rollup is shipped under: a keyed pseudonym of
+// key when anonymization is configured, otherwise its plain hash
+func (c *Client) sessionKey(key string) string {
+	if c.anonymizer != nil {
+		return c.anonymizer.pseudonym(key, time.Now())
+	}
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:16])
+}
+
+// anonymizedIP returns the source IP of event as it may leave the process
+func (c *Client) anonymizedIP(event *SecurityEventRequest) string {
+	if c.anonymizer == nil {
+		return event.SourceIP
+	}
+	if rule := c.anonymizer.rule(event.CountryCode); rule != nil {
+		return truncateIP(event.SourceIP, rule)
+	}
+	return event.SourceIP
+}
+
+// redactCredentials returns event with its credential headers masked: event itself if
+// it has none, otherwise a copy
+func redactCredentials(event *SecurityEventRequest) *SecurityEventRequest {
+	if event == nil {
+		return nil
+	}
+	redacted := event
+	for name := range event.Headers {
+		if !containsFold(credentialHeaders, name) {
+			continue
+		}
+		if redacted == event {
+			copied := *event
+			copied.Headers = make(map[string]string, len(event.Headers))
+			for name, value := range event.Headers {
+				copied.Headers[name] = value
+			}
+			redacted = &copied
+		}
+		redacted.Headers[name] = redactedValue
+	}
+	return redacted
+}
+
+// pseudonym returns the keyed hash of value under the salt in use at now
+func (a *anonymizer) pseudonym(value string, now time.Time) string {
+	if value == "" {
+		return ""
+	}
+	var period [8]byte
+	binary.BigEndian.PutUint64(period[:], uint64(now.UnixNano()/int64(a.config.SaltRotation)))
+	salt := hmac.New(sha256.New, []byte(a.config.Secret))
+	salt.Write(period[:])
+	mac := hmac.New(sha256.New, salt.Sum(nil))
+	mac.Write([]byte(value))
+	return "anon_" + hex.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// truncateIP zeroes the bits of ip beyond the rule's prefix; values that aren't IP
+// addresses are returned unchanged
+func truncateIP(ip string, rule *AnonymizationRule) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return ip
+	}
+	if v4 := parsed.To4(); v4 != nil {
+		if rule.IPv4PrefixBits <= 0 || rule.IPv4PrefixBits >= 32 {
+			return ip
+		}
+		return v4.Mask(net.CIDRMask(rule.IPv4PrefixBits, 32)).String()
+	}
+	if rule.IPv6PrefixBits <= 0 || rule.IPv6PrefixBits >= 128 {
+		return ip
+	}
+	return parsed.Mask(net.CIDRMask(rule.IPv6PrefixBits, 128)).String()
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package guardial
+
+import (
+	"strings"
+	"testing"
+)
+
+func newAnonymizingClient() *Client {
+	return NewClient(&Config{APIKey: "test", Anonymization: &AnonymizationConfig{
+		Default: &AnonymizationRule{IPv4PrefixBits: 24, DropHeaders: []string{"Cookie"}},
+		Secret:  "secret",
+	}})
+}
+
+func TestAnonymizeFinding(t *testing.T) {
+	client := newAnonymizingClient()
+	finding := &Finding{
+		Type:     "canary_triggered",
+		Evidence: "canary issued to 198.51.100.23 was used by 192.0.2.77",
+		SourceIP: "192.0.2.77",
+		Metadata: map[string]string{"issued_to": "198.51.100.23", "method": "GET"},
+	}
+
+	got := client.anonymizeFinding(finding)
+	if got.SourceIP != "192.0.2.0" {
+		t.Errorf("SourceIP = %q, want 192.0.2.0", got.SourceIP)
+	}
+	if got.Metadata["issued_to"] != "198.51.100.0" || got.Metadata["method"] != "GET" {
+		t.Errorf("Metadata = %v", got.Metadata)
+	}
+	for _, ip := range []string{"192.0.2.77", "198.51.100.23"} {
+		if strings.Contains(got.Evidence, ip) {
+			t.Errorf("Evidence %q still holds %s", got.Evidence, ip)
+		}
+	}
+	if finding.SourceIP != "192.0.2.77" {
+		t.Error("anonymizeFinding() changed the caller's finding")
+	}
+}
+
+func TestAnonymizeResponseHeaders(t *testing.T) {
+	tests := []struct {
+		name   string
+		client *Client
+		want   map[string]string
+	}{
+		{"anonymized", newAnonymizingClient(), map[string]string{"Content-Type": "text/html"}},
+		{"no anonymization", NewClient(&Config{APIKey: "test"}), map[string]string{"Content-Type": "text/html", "Set-Cookie": "session=secret"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := map[string]string{"Content-Type": "text/html", "Set-Cookie": "session=secret"}
+			got := tt.client.anonymizeResponseHeaders(&SecurityEventRequest{}, headers)
+			if len(got) != len(tt.want) {
+				t.Fatalf("headers = %v, want %v", got, tt.want)
+			}
+			for name, want := range tt.want {
+				if got[name] != want {
+					t.Errorf("header %s = %q, want %q", name, got[name], want)
+				}
+			}
+		})
+	}
+}
+
+func TestSessionKey(t *testing.T) {
+	key := "192.0.2.77\x00Mozilla/5.0"
+	plain := NewClient(&Config{APIKey: "test"}).sessionKey(key)
+	anonymized := newAnonymizingClient().sessionKey(key)
+	if anonymized == plain || !strings.HasPrefix(anonymized, "anon_") {
+		t.Errorf("sessionKey() = %q with anonymization, want a keyed pseudonym", anonymized)
+	}
+	if again := newAnonymizingClient().sessionKey(key); again != anonymized {
+		t.Errorf("sessionKey() = %q, then %q; want stable pseudonyms under one secret", anonymized, again)
+	}
+}
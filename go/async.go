@@ -0,0 +1,108 @@
+/**
+ * Guardial Go SDK Async Mode
+ * Fire-and-forget event shipping for telemetry-only deployments
+ */
+
+package guardial
+
+import (
+	"time"
+)
+
+// Actions reported for events handled in async mode
+const (
+	ActionQueued  = "queued"  // Event accepted into the queue; no verdict available
+	ActionDropped = "dropped" // Queue was full; event discarded
+)
+
+const defaultAsyncQueueSize = 1000
+
+// startAsync creates the bounded queue and the goroutine that ships queued events
+func (c *Client) startAsync() {
+	size := c.config.AsyncQueueSize
+	if size <= 0 {
+		size = defaultAsyncQueueSize
+	}
+	c.queue = make(chan *SecurityEventRequest, size)
+
+	c.goBackground(c.runAsyncWorker)
+}
+
+// runAsyncWorker ships queued events in batches of up to Config.BatchSize, flushing
+// partial batches every Config.BatchFlushInterval
+func (c *Client) runAsyncWorker() {
+	batchSize := c.batchSize()
+	ticker := time.NewTicker(c.batchFlushInterval())
+	defer ticker.Stop()
+
+	batch := make([]*SecurityEventRequest, 0, batchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		c.shipEvents(batch)
+		batch = make([]*SecurityEventRequest, 0, batchSize)
+	}
+
+	for {
+		select {
+		case event := <-c.queue:
+			batch = append(batch, event)
+			if len(batch) >= batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-c.closing:
+			// Drain whatever is still queued, then ship it
+			for {
+				select {
+				case event := <-c.queue:
+					batch = append(batch, event)
+					if len(batch) >= batchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// enqueueEvent hands event to the background shipper without waiting for the API.
+// Async events are always allowed: there is no verdict to enforce.
+func (c *Client) enqueueEvent(event *SecurityEventRequest) *SecurityEventResponse {
+	select {
+	case <-c.closing:
+		c.log("Client closed, dropping event:", event.Method, event.Path, event.EventID)
+		return &SecurityEventResponse{EventID: event.EventID, Allowed: true, Action: ActionDropped}
+	default:
+	}
+
+	select {
+	case c.queue <- event:
+		return &SecurityEventResponse{EventID: event.EventID, Allowed: true, Action: ActionQueued}
+	default:
+		c.log("Async queue full, dropping event:", event.Method, event.Path, event.EventID)
+		return &SecurityEventResponse{EventID: event.EventID, Allowed: true, Action: ActionDropped}
+	}
+}
+
+// shipEvents sends queued events; failures are logged because nobody is waiting on them
+func (c *Client) shipEvents(events []*SecurityEventRequest) {
+	if len(events) == 1 && c.transport == nil {
+		var analysis SecurityEventResponse
+		if err := c.postEvents(c.ctx, "/api/events", events, singleEvent, &analysis); err != nil {
+			c.warn("Async event delivery failed:", err)
+			return
+		}
+		c.log("Async security analysis completed:", analysis)
+		return
+	}
+
+	if _, err := c.AnalyzeEventsContext(c.ctx, events); err != nil {
+		c.warn("Async batch delivery failed:", err)
+	}
+}
@@ -0,0 +1,108 @@
+/**
+ * Guardial Go SDK Batch Submission
+ * Many events per /api/events/batch call for high-traffic services
+ */
+
+package guardial
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultBatchSize          = 100
+	defaultBatchFlushInterval = time.Second
+)
+
+// batchRequest is the payload of /api/events/batch
+type batchRequest struct {
+	Events []*SecurityEventRequest `json:"events"`
+}
+
+// eventBatch is the postEvents payload for /api/events/batch
+func eventBatch(wire []*SecurityEventRequest) interface{} {
+	return batchRequest{Events: wire}
+}
+
+// batchResponse is the response of /api/events/batch, one result per event in order
+type batchResponse struct {
+	Results []*SecurityEventResponse `json:"results"`
+}
+
+// AnalyzeEvents analyzes several security events with as few API calls as possible
+func (c *Client) AnalyzeEvents(events []*SecurityEventRequest) ([]*SecurityEventResponse, error) {
+	return c.AnalyzeEventsContext(context.Background(), events)
+}
+
+// AnalyzeEventsContext analyzes several security events, splitting them into batches of at
+// most Config.BatchSize. Results are returned in the same order as events.
+func (c *Client) AnalyzeEventsContext(ctx context.Context, events []*SecurityEventRequest) ([]*SecurityEventResponse, error) {
+	batchSize := c.batchSize()
+	results := make([]*SecurityEventResponse, 0, len(events))
+
+	for start := 0; start < len(events); start += batchSize {
+		end := start + batchSize
+		if end > len(events) {
+			end = len(events)
+		}
+		chunk := events[start:end]
+
+		// Set customer ID if not provided
+		for _, event := range chunk {
+			if event.CustomerID == "" {
+				event.CustomerID = c.config.CustomerID
+			}
+			if event.EventID == "" {
+				event.EventID = c.newEventID()
+			}
+			c.enrichGeo(event)
+			c.scoreCost(event)
+		}
+
+		var response batchResponse
+		if c.transport != nil {
+			var err error
+			if response.Results, err = c.sendEvents(ctx, chunk); err != nil {
+				return results, err
+			}
+		} else if err := c.postEvents(ctx, "/api/events/batch", chunk, eventBatch, &response); err != nil {
+			return results, err
+		}
+		if len(response.Results) != len(chunk) {
+			return results, fmt.Errorf("batch response has %d results for %d events", len(response.Results), len(chunk))
+		}
+		for i, result := range response.Results {
+			if result == nil {
+				return results, fmt.Errorf("malformed batch response: no result for event %d", start+i)
+			}
+			if result.EventID == "" {
+				result.EventID = chunk[i].EventID
+			}
+			enrichTaxonomy(result)
+			structureReasons(result)
+			c.recalibrateSeverity(chunk[i].Path, result)
+			c.enforceCost(chunk[i], result)
+			c.applyPolicy(chunk[i], result)
+		}
+		results = append(results, response.Results...)
+	}
+
+	c.log(fmt.Sprintf("Batch analysis completed: %d events", len(results)))
+	return results, nil
+}
+
+func (c *Client) batchSize() int {
+	if c.config.BatchSize > 0 {
+		return c.config.BatchSize
+	}
+	return defaultBatchSize
+}
+
+func (c *Client) batchFlushInterval() time.Duration {
+	if c.config.BatchFlushInterval > 0 {
+		return c.config.BatchFlushInterval
+	}
+	return defaultBatchFlushInterval
+}
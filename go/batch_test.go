@@ -0,0 +1,41 @@
+package guardial
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAnalyzeEventsResponses(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr bool
+	}{
+		{"one result per event", `{"results":[{"allowed":true},{"allowed":false}]}`, false},
+		{"null result", `{"results":[{"allowed":true},null]}`, true},
+		{"missing result", `{"results":[{"allowed":true}]}`, true},
+		{"null results", `{"results":null}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, verdictAPI(tt.body))
+			events := []*SecurityEventRequest{{Method: "GET", Path: "/a"}, {Method: "GET", Path: "/b"}}
+
+			results, err := client.AnalyzeEventsContext(context.Background(), events)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("AnalyzeEventsContext() = %d results, want an error", len(results))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AnalyzeEventsContext() = %v", err)
+			}
+			for i, result := range results {
+				if result.EventID != events[i].EventID {
+					t.Errorf("result %d EventID = %q, want %q", i, result.EventID, events[i].EventID)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+/**
+ * Guardial Go SDK IP Blocks
+ * Local IP blocks shared with sibling instances so attackers can't rotate across pods
+ */
+
+package guardial
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BlockDecision is an IP block raised by one instance and shared with its siblings
+type BlockDecision struct {
+	IP        string    `json:"ip"`
+	Reason    string    `json:"reason"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Origin    string    `json:"origin"` // Session ID of the instance that raised the block
+}
+
+// BlockPropagator shares block decisions between instances, through a shared store or a
+// gossip channel. Publish should return quickly; Subscribe delivers decisions raised by
+// other instances until ctx is done.
+type BlockPropagator interface {
+	Publish(ctx context.Context, decision BlockDecision) error
+	Subscribe(ctx context.Context, deliver func(BlockDecision)) error
+}
+
+// blockList holds active IP blocks
+type blockList struct {
+	mu     sync.RWMutex
+	blocks map[string]BlockDecision
+}
+
+func newBlockList() *blockList {
+	return &blockList{blocks: make(map[string]BlockDecision)}
+}
+
+// add records decision, keeping whichever block on the IP lasts longer
+func (l *blockList) add(decision BlockDecision) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if existing, ok := l.blocks[decision.IP]; ok && existing.ExpiresAt.After(decision.ExpiresAt) {
+		return
+	}
+	l.blocks[decision.IP] = decision
+}
+
+func (l *blockList) get(ip string) (BlockDecision, bool) {
+	l.mu.RLock()
+	decision, ok := l.blocks[ip]
+	l.mu.RUnlock()
+	if !ok {
+		return BlockDecision{}, false
+	}
+	if time.Now().After(decision.ExpiresAt) {
+		l.mu.Lock()
+		if current, ok := l.blocks[ip]; ok && time.Now().After(current.ExpiresAt) {
+			delete(l.blocks, ip)
+		}
+		l.mu.Unlock()
+		return BlockDecision{}, false
+	}
+	return decision, true
+}
+
+func (l *blockList) remove(ip string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.blocks, ip)
+}
+
+// BlockIP blocks ip on this instance for ttl and propagates the decision to sibling
+// instances when a BlockPropagator is configured. The middleware rejects blocked IPs
+// before analysis.
+func (c *Client) BlockIP(ctx context.Context, ip string, ttl time.Duration, reason string) error {
+	decision := BlockDecision{
+		IP:        ip,
+		Reason:    reason,
+		ExpiresAt: time.Now().Add(ttl),
+		Origin:    c.sessionID,
+	}
+	c.blocks.add(decision)
+	c.log("🚫 Blocked IP:", ip, reason)
+
+	if c.config.BlockPropagator == nil {
+		return nil
+	}
+	if err := c.config.BlockPropagator.Publish(ctx, decision); err != nil {
+		return fmt.Errorf("failed to propagate block: %w", err)
+	}
+	return nil
+}
+
+// UnblockIP lifts a block on this instance only
+func (c *Client) UnblockIP(ip string) {
+	c.blocks.remove(ip)
+}
+
+// IsBlocked reports whether ip is blocked, locally or by a sibling instance
+func (c *Client) IsBlocked(ip string) (BlockDecision, bool) {
+	return c.blocks.get(ip)
+}
+
+// runBlockSubscription subscribes to sibling decisions until the client starts closing,
+// so Close doesn't wait on a subscription that only ends with the client
+func (c *Client) runBlockSubscription() {
+	ctx, cancel := context.WithCancel(c.ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-c.closing:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	c.subscribeBlocks(ctx)
+}
+
+// subscribeBlocks applies decisions from sibling instances until ctx is done
+func (c *Client) subscribeBlocks(ctx context.Context) {
+	err := c.config.BlockPropagator.Subscribe(ctx, func(decision BlockDecision) {
+		if decision.Origin == c.sessionID || decision.IP == "" || time.Now().After(decision.ExpiresAt) {
+			return
+		}
+		c.blocks.add(decision)
+		c.log("🚫 Blocked IP from sibling instance:", decision.IP, decision.Reason)
+	})
+	if err != nil && ctx.Err() == nil {
+		c.log("⚠️ Block subscription stopped:", err)
+	}
+}
@@ -0,0 +1,76 @@
+package guardial
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBlockedPeerIP(t *testing.T) {
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor string
+		wantStatus   int
+	}{
+		{"blocked peer", "192.0.2.1:4321", "", http.StatusForbidden},
+		{"blocked peer, spoofed forwarded IP", "192.0.2.1:4321", "203.0.113.7", http.StatusForbidden},
+		{"other peer claiming the blocked IP", "198.51.100.9:4321", "192.0.2.1", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, verdictAPI(allowedVerdict))
+			client.BlockIP(context.Background(), "192.0.2.1", time.Hour, "test")
+			handler := StandardMiddleware(client, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+			req := httptest.NewRequest("POST", "/api/orders", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestGossipVerify(t *testing.T) {
+	gossip, err := NewGossipPropagator("127.0.0.1:0", nil, "secret")
+	if err != nil {
+		t.Fatalf("NewGossipPropagator() = %v", err)
+	}
+	defer gossip.Close()
+
+	message := func(secret string, signedAt time.Time) []byte {
+		decision, _ := json.Marshal(BlockDecision{IP: "192.0.2.1", Reason: "test", ExpiresAt: signedAt.Add(time.Hour)})
+		data, _ := json.Marshal(gossipMessage{Timestamp: signedAt.Unix(), Signature: Sign(secret, signedAt.Unix(), decision), Decision: decision})
+		return data
+	}
+	now := time.Now()
+	replayed := message("secret", now.Add(-time.Second))
+
+	tests := []struct {
+		name   string
+		data   []byte
+		wantOK bool
+	}{
+		{"signed", message("secret", now), true},
+		{"first delivery", replayed, true},
+		{"replayed", replayed, false},
+		{"forged", message("wrong", now), false},
+		{"stale", message("secret", now.Add(-time.Hour)), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := gossip.verify(tt.data); ok != tt.wantOK {
+				t.Errorf("verify() ok = %t, want %t", ok, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+/**
+ * Guardial Go SDK Body Capture
+ * Bounded buffering of request bodies into security events
+ */
+
+package guardial
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// defaultMaxBodyBytes is the body capture limit of DefaultMiddlewareOptions
+const defaultMaxBodyBytes = 1024 * 1024
+
+// captureBody reads the part of r's body that goes into the event, leaving r.Body
+// readable from the start. It reports whether the body was cut short or skipped.
+func (m *middleware) captureBody(r *http.Request) ([]byte, bool) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, false
+	}
+	if m.skipContentType(r.Header.Get("Content-Type")) {
+		return nil, r.ContentLength != 0
+	}
+
+	limit := m.options.MaxBodyBytes
+	if limit <= 0 {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewBuffer(body))
+		return body, false
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(r.Body, limit+1))
+	if int64(len(body)) <= limit {
+		r.Body = io.NopCloser(bytes.NewBuffer(body))
+		return body, false
+	}
+	// Hand the handler what was read followed by the unread rest
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), r.Body), r.Body}
+	return body[:limit], true
+}
+
+// skipContentType reports whether bodies of contentType are left out of events
+func (m *middleware) skipContentType(contentType string) bool {
+	return contentType != "" && matchMediaType(contentType, m.options.SkipContentTypes)
+}
+
+// matchMediaType reports whether the media type of contentType matches one of patterns,
+// in path.Match syntax ("video/*", "application/*+json")
+func matchMediaType(contentType string, patterns []string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType, _, _ = strings.Cut(strings.ToLower(contentType), ";")
+		mediaType = strings.TrimSpace(mediaType)
+	}
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(strings.ToLower(pattern), mediaType); matched {
+			return true
+		}
+	}
+	return false
+}
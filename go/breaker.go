@@ -0,0 +1,122 @@
+/**
+ * Guardial Go SDK Circuit Breaker
+ * Stops calling the analysis API while it is failing repeatedly
+ */
+
+package guardial
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// CircuitBreakerConfig configures the circuit breaker around the Guardial API
+type CircuitBreakerConfig struct {
+	FailureThreshold int           `json:"failure_threshold"` // Consecutive failures before opening
+	OpenDuration     time.Duration `json:"open_duration"`     // How long to stay open before probing again
+}
+
+// DefaultCircuitBreakerConfig opens after 5 consecutive failures and probes again after 30s
+func DefaultCircuitBreakerConfig() *CircuitBreakerConfig {
+	return &CircuitBreakerConfig{
+		FailureThreshold: 5,
+		OpenDuration:     30 * time.Second,
+	}
+}
+
+// Circuit breaker states
+const (
+	circuitClosed = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker fails fast with ErrCircuitOpen while the API is unhealthy. In the
+// half-open state a single probe call is let through to test recovery.
+type circuitBreaker struct {
+	mu       sync.Mutex
+	config   CircuitBreakerConfig
+	state    int
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func newCircuitBreaker(config *CircuitBreakerConfig) *circuitBreaker {
+	cfg := *config
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = 5
+	}
+	if cfg.OpenDuration <= 0 {
+		cfg.OpenDuration = 30 * time.Second
+	}
+	return &circuitBreaker{config: cfg}
+}
+
+// allow reports whether a call may be made right now
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		if time.Since(b.openedAt) < b.config.OpenDuration {
+			return false
+		}
+		b.state = circuitHalfOpen
+		b.probing = true
+		return true
+	case circuitHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	}
+	return true
+}
+
+// record feeds the outcome of a call into the breaker and returns true if the state changed
+func (b *circuitBreaker) record(err error) (changed bool, open bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// A canceled call says nothing either way; let the next caller probe instead
+	if errors.Is(err, context.Canceled) {
+		b.probing = false
+		return false, false
+	}
+	if !countsAsFailure(err) {
+		changed = b.state != circuitClosed
+		b.state = circuitClosed
+		b.failures = 0
+		b.probing = false
+		return changed, false
+	}
+
+	b.failures++
+	if b.state == circuitHalfOpen || b.failures >= b.config.FailureThreshold {
+		changed = b.state != circuitOpen
+		b.state = circuitOpen
+		b.openedAt = time.Now()
+		b.probing = false
+		return changed, true
+	}
+	return false, false
+}
+
+// countsAsFailure decides whether an error says anything about the API's health.
+// Client errors (bad key, bad payload) do not; network errors and 5xx do.
+func countsAsFailure(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500
+	}
+	return true
+}
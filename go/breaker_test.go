@@ -0,0 +1,42 @@
+package guardial
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerProbe(t *testing.T) {
+	tests := []struct {
+		name      string
+		probeErr  error
+		wantState int
+		wantAllow bool // a second caller may probe right after
+	}{
+		{"probe succeeds", nil, circuitClosed, true},
+		{"probe fails", &APIError{StatusCode: http.StatusBadGateway}, circuitOpen, false},
+		{"probe canceled", context.Canceled, circuitHalfOpen, true},
+		{"probe canceled during retry", fmt.Errorf("%w (last error: %v)", context.Canceled, errors.New("dial")), circuitHalfOpen, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			breaker := newCircuitBreaker(&CircuitBreakerConfig{FailureThreshold: 1, OpenDuration: time.Hour})
+			breaker.record(errors.New("connection refused"))
+			breaker.openedAt = time.Now().Add(-2 * time.Hour)
+			if !breaker.allow() {
+				t.Fatal("allow() = false after OpenDuration")
+			}
+
+			breaker.record(tt.probeErr)
+			if breaker.state != tt.wantState {
+				t.Errorf("state = %d, want %d", breaker.state, tt.wantState)
+			}
+			if got := breaker.allow(); got != tt.wantAllow {
+				t.Errorf("allow() after probe = %t, want %t", got, tt.wantAllow)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+/**
+ * Guardial Go SDK Concurrency Limiting
+ * A bulkhead bounding in-flight inline analyses, so API latency can't pile up goroutines
+ */
+
+package guardial
+
+import (
+	"context"
+	"time"
+)
+
+// Concurrency limit defaults
+const (
+	defaultMaxInFlight  = 64
+	defaultQueueTimeout = 50 * time.Millisecond
+)
+
+// ConcurrencyOptions bounds the analyses the middleware runs at once. A request that
+// finds every slot taken waits up to QueueTimeout for one; if none frees up, analysis
+// fails with ErrAnalysisSaturated and the request is handled per FailOpen (or its
+// route's FailOpen), like any other analysis failure.
+type ConcurrencyOptions struct {
+	MaxInFlight  int           // Analyses running at once (default: 64)
+	QueueTimeout time.Duration // How long a request waits for a slot (default: 50ms)
+}
+
+// bulkhead is a counting semaphore over analysis calls
+type bulkhead struct {
+	slots        chan struct{}
+	queueTimeout time.Duration
+}
+
+func newBulkhead(options *ConcurrencyOptions) *bulkhead {
+	opts := *options
+	if opts.MaxInFlight <= 0 {
+		opts.MaxInFlight = defaultMaxInFlight
+	}
+	if opts.QueueTimeout <= 0 {
+		opts.QueueTimeout = defaultQueueTimeout
+	}
+	return &bulkhead{slots: make(chan struct{}, opts.MaxInFlight), queueTimeout: opts.QueueTimeout}
+}
+
+// acquire takes a slot, waiting up to the queue timeout; call release when done
+func (b *bulkhead) acquire(ctx context.Context) (release func(), err error) {
+	select {
+	case b.slots <- struct{}{}:
+		return b.release, nil
+	default:
+	}
+
+	timer := time.NewTimer(b.queueTimeout)
+	defer timer.Stop()
+	select {
+	case b.slots <- struct{}{}:
+		return b.release, nil
+	case <-timer.C:
+		return nil, ErrAnalysisSaturated
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (b *bulkhead) release() {
+	<-b.slots
+}
+
+// analyzeEvent runs AnalyzeEventContext within the concurrency limit, if there is one
+func (m *middleware) analyzeEvent(ctx context.Context, event *SecurityEventRequest) (*SecurityEventResponse, error) {
+	if m.bulkhead == nil {
+		return m.client.AnalyzeEventContext(ctx, event)
+	}
+	trace := traceFromContext(ctx)
+	start := time.Now()
+	release, err := m.bulkhead.acquire(ctx)
+	if err != nil {
+		trace.record("concurrency_limit", start, err.Error())
+		return nil, err
+	}
+	defer release()
+	trace.record("concurrency_limit", start, "acquired")
+	return m.client.AnalyzeEventContext(ctx, event)
+}
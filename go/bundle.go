@@ -0,0 +1,75 @@
+/**
+ * Guardial Go SDK Config Bundles
+ * Encrypted client-side configuration distributed as a single file
+ */
+
+package guardial
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// ConfigBundle is everything a service needs to run the SDK: API key, policy, and rule
+// packs, distributed encrypted so it can be rolled out through ordinary config channels
+type ConfigBundle struct {
+	Config    *Config                    `json:"config"`
+	RulePacks map[string]json.RawMessage `json:"rule_packs,omitempty"` // Named rule packs, passed through as-is
+}
+
+// SealConfigBundle encrypts bundle under the key keys returns for name
+func SealConfigBundle(ctx context.Context, keys KeyProvider, name string, bundle *ConfigBundle) ([]byte, error) {
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config bundle: %w", err)
+	}
+	return NewEventCipher(keys).Seal(ctx, name, data)
+}
+
+// OpenConfigBundle decrypts a bundle produced by SealConfigBundle. Fields the bundle
+// leaves out keep their DefaultConfig values, as do a zero Endpoint and Timeout.
+func OpenConfigBundle(ctx context.Context, keys KeyProvider, name string, sealed []byte) (*ConfigBundle, error) {
+	data, err := NewEventCipher(keys).Open(ctx, name, sealed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt config bundle: %w", err)
+	}
+
+	bundle := &ConfigBundle{Config: DefaultConfig()}
+	if err := json.Unmarshal(data, bundle); err != nil {
+		return nil, fmt.Errorf("failed to parse config bundle: %w", err)
+	}
+	if bundle.Config == nil {
+		bundle.Config = DefaultConfig()
+	}
+	defaults := DefaultConfig()
+	if bundle.Config.Endpoint == "" {
+		bundle.Config.Endpoint = defaults.Endpoint
+	}
+	if bundle.Config.Timeout <= 0 {
+		bundle.Config.Timeout = defaults.Timeout
+	}
+	return bundle, nil
+}
+
+// NewClientFromBundle creates a client from the encrypted bundle at path, decrypting it
+// with the key keys (typically KMS-backed) returns for name
+func NewClientFromBundle(ctx context.Context, path string, keys KeyProvider, name string) (*Client, error) {
+	sealed, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config bundle: %w", err)
+	}
+
+	bundle, err := OpenConfigBundle(ctx, keys, name, sealed)
+	if err != nil {
+		return nil, err
+	}
+	if bundle.Config.APIKey == "" {
+		return nil, fmt.Errorf("config bundle %q has no API key", name)
+	}
+	if err := bundle.Config.Validate(); err != nil {
+		return nil, fmt.Errorf("config bundle %q: %w", name, err)
+	}
+	return NewClient(bundle.Config), nil
+}
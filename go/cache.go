@@ -0,0 +1,137 @@
+/**
+ * Guardial Go SDK Verdict Cache
+ * LRU + TTL cache of analysis verdicts for repeated identical requests
+ */
+
+package guardial
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+)
+
+// VerdictCacheConfig configures the local verdict cache
+type VerdictCacheConfig struct {
+	Size         int           `json:"size"`          // Max cached verdicts (default: 10000)
+	TTL          time.Duration `json:"ttl"`           // How long a verdict stays valid (default: 1m)
+	CacheAllowed bool          `json:"cache_allowed"` // Cache allow verdicts
+	CacheBlocked bool          `json:"cache_blocked"` // Cache block verdicts
+}
+
+// DefaultVerdictCacheConfig returns a cache of allow and block verdicts
+func DefaultVerdictCacheConfig() *VerdictCacheConfig {
+	return &VerdictCacheConfig{
+		Size:         10000,
+		TTL:          time.Minute,
+		CacheAllowed: true,
+		CacheBlocked: true,
+	}
+}
+
+type verdictCacheEntry struct {
+	key      string
+	analysis SecurityEventResponse
+	expires  time.Time
+}
+
+// verdictCache is a size-bounded LRU whose entries also expire after a TTL
+type verdictCache struct {
+	config *VerdictCacheConfig
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // Front is most recently used
+}
+
+func newVerdictCache(config *VerdictCacheConfig) *verdictCache {
+	if config.Size <= 0 {
+		config.Size = 10000
+	}
+	if config.TTL <= 0 {
+		config.TTL = time.Minute
+	}
+	return &verdictCache{
+		config:  config,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// RequestSignature returns the normalized signature the verdict cache keys on
+func RequestSignature(event *SecurityEventRequest) string {
+	body := sha256.Sum256([]byte(event.RequestBody))
+
+	h := sha256.New()
+	for _, part := range []string{
+		strings.ToUpper(event.Method),
+		event.Path,
+		event.QueryParams,
+		event.SourceIP,
+		hex.EncodeToString(body[:]),
+	} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// get returns a copy of the cached verdict for key, if present and not expired
+func (vc *verdictCache) get(key string) (*SecurityEventResponse, bool) {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+
+	element, ok := vc.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*verdictCacheEntry)
+	if time.Now().After(entry.expires) {
+		vc.order.Remove(element)
+		delete(vc.entries, key)
+		return nil, false
+	}
+
+	vc.order.MoveToFront(element)
+
+	// Copy slices too, callers may append to them
+	analysis := entry.analysis
+	analysis.RiskReasons = append([]string(nil), analysis.RiskReasons...)
+	analysis.Reasons = append([]RiskReason(nil), analysis.Reasons...)
+	analysis.OwaspDetected = append([]OwaspDetection(nil), analysis.OwaspDetected...)
+	return &analysis, true
+}
+
+// put caches analysis under key if its verdict type is cacheable
+func (vc *verdictCache) put(key string, analysis *SecurityEventResponse) {
+	if analysis.Allowed && !vc.config.CacheAllowed {
+		return
+	}
+	if !analysis.Allowed && !vc.config.CacheBlocked {
+		return
+	}
+
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+
+	expires := time.Now().Add(vc.config.TTL)
+	if element, ok := vc.entries[key]; ok {
+		entry := element.Value.(*verdictCacheEntry)
+		entry.analysis = *analysis
+		entry.expires = expires
+		vc.order.MoveToFront(element)
+		return
+	}
+
+	vc.entries[key] = vc.order.PushFront(&verdictCacheEntry{key: key, analysis: *analysis, expires: expires})
+
+	// Evict least recently used entries
+	for vc.order.Len() > vc.config.Size {
+		oldest := vc.order.Back()
+		vc.order.Remove(oldest)
+		delete(vc.entries, oldest.Value.(*verdictCacheEntry).key)
+	}
+}
@@ -0,0 +1,82 @@
+package guardial
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSharedVerdictsCarryCallerEventID(t *testing.T) {
+	tests := []struct {
+		name      string
+		configure func(config *Config)
+		together  bool // Analyze both events concurrently
+	}{
+		{"verdict cache hit", func(c *Config) { c.VerdictCache = DefaultVerdictCacheConfig() }, false},
+		{"coalesced request", func(c *Config) { c.CoalesceRequests = true }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release := make(chan struct{})
+			client := newConfiguredTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if tt.together {
+					<-release
+				}
+				w.Write([]byte(`{"allowed":true,"action":"allow","risk_score":5}`))
+			}, tt.configure)
+
+			events := []*SecurityEventRequest{
+				{EventID: "evt_first", Method: "GET", Path: "/api/orders", SourceIP: "192.0.2.1"},
+				{EventID: "evt_second", Method: "GET", Path: "/api/orders", SourceIP: "192.0.2.1"},
+			}
+			analyses := make([]*SecurityEventResponse, len(events))
+			var wg sync.WaitGroup
+			for i, event := range events {
+				wg.Add(1)
+				go func(i int, event *SecurityEventRequest) {
+					defer wg.Done()
+					analysis, err := client.AnalyzeEventContext(context.Background(), event)
+					if err != nil {
+						t.Errorf("AnalyzeEventContext(%s) = %v", event.EventID, err)
+						return
+					}
+					analyses[i] = analysis
+				}(i, event)
+				if !tt.together {
+					wg.Wait()
+				}
+			}
+			if tt.together {
+				waitForWaiters(t, client.inflight, 2)
+				close(release)
+			}
+			wg.Wait()
+
+			for i, event := range events {
+				if analyses[i] != nil && analyses[i].EventID != event.EventID {
+					t.Errorf("verdict for %s carries EventID %q", event.EventID, analyses[i].EventID)
+				}
+			}
+		})
+	}
+}
+
+// waitForWaiters waits until n callers share one in-flight call of group
+func waitForWaiters(t *testing.T, group *coalesceGroup, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		group.mu.Lock()
+		for _, call := range group.calls {
+			if call.waiters == n {
+				group.mu.Unlock()
+				return
+			}
+		}
+		group.mu.Unlock()
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("%d callers never shared a call", n)
+}
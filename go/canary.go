@@ -0,0 +1,266 @@
+/**
+ * Guardial Go SDK Canary Tokens
+ * Fake credentials and trap URLs in responses to high-risk sessions; any later use
+ * confirms an attacker
+ */
+
+package guardial
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// canaryKeyPrefix marks canary API keys; it is shaped like a real live key
+const canaryKeyPrefix = "gk_live_"
+
+// canaryPattern finds canary tokens in arbitrary request data
+var canaryPattern = regexp.MustCompile(`gk_live_[0-9a-f]{32}`)
+
+// maxCanaries bounds the number of outstanding canary tokens
+const maxCanaries = 10000
+
+// CanaryOptions configures canary token injection in the middleware
+type CanaryOptions struct {
+	MinRiskScore int           // Inject into allowed responses at or above this risk score (default: 50)
+	TrapPrefix   string        // Path prefix of trap URLs (default: "/internal/admin/")
+	TTL          time.Duration // How long an issued canary stays armed (default: 7 days)
+	BlockTTL     time.Duration // How long IPs that use a canary are blocked (default: 24h)
+}
+
+// CanaryHit describes a canary token seen after it was issued
+type CanaryHit struct {
+	Token     string
+	IssuedTo  string    // Peer IP the canary was served to
+	IssuedAt  time.Time // When it was served
+	IssuedFor string    // Path of the response it was embedded in
+}
+
+type canaryRecord struct {
+	sourceIP string
+	path     string
+	issued   time.Time
+}
+
+// canaryRegistry remembers issued canaries. It lives on the Client so every middleware
+// and CheckCanary share it.
+type canaryRegistry struct {
+	mu     sync.Mutex
+	tokens map[string]canaryRecord
+	ttl    time.Duration
+}
+
+func newCanaryRegistry() *canaryRegistry {
+	return &canaryRegistry{tokens: make(map[string]canaryRecord), ttl: 7 * 24 * time.Hour}
+}
+
+// newCanaryToken returns a random token shaped like a live API key
+func newCanaryToken() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return canaryKeyPrefix + hex.EncodeToString(b)
+}
+
+// armCanary records token as served to sourceIP in the response for path
+func (c *Client) armCanary(token, sourceIP, path string) {
+	r := c.canaries
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.tokens) >= maxCanaries {
+		r.expire(time.Now())
+	}
+	if len(r.tokens) < maxCanaries {
+		r.tokens[token] = canaryRecord{sourceIP: sourceIP, path: path, issued: time.Now()}
+	}
+}
+
+// expire drops canaries past their TTL. Callers hold r.mu.
+func (r *canaryRegistry) expire(now time.Time) {
+	for token, record := range r.tokens {
+		if now.Sub(record.issued) > r.ttl {
+			delete(r.tokens, token)
+		}
+	}
+}
+
+// CheckCanary reports whether value contains an armed canary token. Use it where
+// credentials are validated outside the middleware (API gateways, auth services) so
+// canaries are caught wherever they are replayed.
+func (c *Client) CheckCanary(value string) (CanaryHit, bool) {
+	r := c.canaries
+	for _, token := range canaryPattern.FindAllString(value, -1) {
+		r.mu.Lock()
+		record, ok := r.tokens[token]
+		r.mu.Unlock()
+		if ok && time.Since(record.issued) <= r.ttl {
+			// A token is armed until its TTL even after it fires, so every replay is caught
+			return CanaryHit{Token: token, IssuedTo: record.sourceIP, IssuedAt: record.issued, IssuedFor: record.path}, true
+		}
+	}
+	return CanaryHit{}, false
+}
+
+// confirmAttacker reports a triggered canary and blocks both the peer IP that used it
+// and the one it was issued to
+func (c *Client) confirmAttacker(ctx context.Context, hit CanaryHit, event *SecurityEventRequest, blockTTL time.Duration) {
+	c.log("🚫 Canary token used, confirmed attacker:", event.PeerIP, "issued to", hit.IssuedTo)
+
+	finding := &Finding{
+		Type:     "canary_triggered",
+		Title:    "Canary token used",
+		Severity: "CRITICAL",
+		Evidence: fmt.Sprintf("canary issued to %s at %s on %s was used by %s", hit.IssuedTo,
+			hit.IssuedAt.UTC().Format(time.RFC3339), hit.IssuedFor, event.PeerIP),
+		Path:     event.Path,
+		SourceIP: event.PeerIP,
+		Metadata: map[string]string{
+			"issued_to":  hit.IssuedTo,
+			"issued_for": hit.IssuedFor,
+			"method":     event.Method,
+		},
+	}
+	c.ReportFindingAsync(ctx, finding)
+
+	for _, ip := range []string{event.PeerIP, hit.IssuedTo} {
+		if ip == "" {
+			continue
+		}
+		if err := c.BlockIP(context.WithoutCancel(ctx), ip, blockTTL, "canary token used"); err != nil {
+			c.log("⚠️", err)
+		}
+	}
+}
+
+// canaryGuard is the middleware side of canary tokens
+type canaryGuard struct {
+	client  *Client
+	options CanaryOptions
+}
+
+func newCanaryGuard(client *Client, options *CanaryOptions) *canaryGuard {
+	opts := *options
+	if opts.MinRiskScore <= 0 {
+		opts.MinRiskScore = 50
+	}
+	if opts.TrapPrefix == "" {
+		opts.TrapPrefix = "/internal/admin/"
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = 7 * 24 * time.Hour
+	}
+	if opts.BlockTTL <= 0 {
+		opts.BlockTTL = 24 * time.Hour
+	}
+
+	client.canaries.mu.Lock()
+	if opts.TTL > client.canaries.ttl {
+		client.canaries.ttl = opts.TTL
+	}
+	client.canaries.mu.Unlock()
+	return &canaryGuard{client: client, options: opts}
+}
+
+// tripped looks for armed canaries anywhere in the request and confirms the sender as
+// an attacker when one is found
+func (g *canaryGuard) tripped(r *http.Request, event *SecurityEventRequest) bool {
+	var data strings.Builder
+	data.WriteString(r.URL.Path)
+	data.WriteString(" ")
+	data.WriteString(r.URL.RawQuery)
+	for _, values := range r.Header {
+		for _, value := range values {
+			data.WriteString(" ")
+			data.WriteString(value)
+		}
+	}
+	data.WriteString(" ")
+	data.WriteString(event.RequestBody)
+
+	hit, ok := g.client.CheckCanary(data.String())
+	if !ok {
+		return false
+	}
+	g.client.confirmAttacker(r.Context(), hit, event, g.options.BlockTTL)
+	return true
+}
+
+// respond answers a request that tripped a canary
+func (g *canaryGuard) respond(w http.ResponseWriter, r *http.Request) {
+	// Trap URLs look like a dead end rather than a tripwire
+	if strings.HasPrefix(r.URL.Path, g.options.TrapPrefix) {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnauthorized)
+	w.Write([]byte(`{"error":"invalid API key"}`))
+}
+
+// wrap buffers responses to high-risk requests so a canary can be embedded
+func (g *canaryGuard) wrap(w http.ResponseWriter, r *http.Request) (http.ResponseWriter, func()) {
+	state := requestStateFromContext(r.Context())
+	if state == nil || state.analysis == nil || !state.analysis.Allowed || state.analysis.RiskScore < g.options.MinRiskScore {
+		return w, func() {}
+	}
+
+	rec := &bufferingWriter{ResponseWriter: w, limit: maxRewriteBodySize}
+	return rec, func() {
+		if rec.streaming {
+			return // Already written through
+		}
+		if rec.status == 0 {
+			rec.status = http.StatusOK
+		}
+		body := rec.buf.Bytes()
+		if rec.status == http.StatusOK {
+			token := newCanaryToken()
+			if injected, ok := g.inject(body, rec.Header().Get("Content-Type"), token); ok {
+				g.client.armCanary(token, state.event.PeerIP, r.URL.Path)
+				body = injected
+				g.client.log("Canary token embedded for", state.event.PeerIP, r.URL.Path)
+			}
+		}
+		rec.Header().Del("Content-Length")
+		rec.ResponseWriter.WriteHeader(rec.status)
+		rec.ResponseWriter.Write(body)
+	}
+}
+
+// inject embeds token as a fake API key and a trap URL in HTML or JSON object bodies
+func (g *canaryGuard) inject(body []byte, contentType, token string) ([]byte, bool) {
+	trapURL := g.options.TrapPrefix + token
+
+	switch {
+	case strings.Contains(contentType, "html"):
+		snippet := fmt.Sprintf("\n<!-- staging api_key=%s -->\n<a href=\"%s\" style=\"display:none\" rel=\"nofollow\">admin</a>\n", token, trapURL)
+		if i := bytes.LastIndex(bytes.ToLower(body), []byte("</body>")); i >= 0 {
+			return append(body[:i:i], append([]byte(snippet), body[i:]...)...), true
+		}
+		return append(body, snippet...), true
+	case strings.Contains(contentType, "json"):
+		var object map[string]json.RawMessage
+		if json.Unmarshal(body, &object) != nil {
+			return nil, false
+		}
+		debug, err := json.Marshal(map[string]string{"api_key": token, "admin_url": trapURL})
+		if err != nil {
+			return nil, false
+		}
+		object["_debug"] = debug
+		injected, err := json.Marshal(object)
+		if err != nil {
+			return nil, false
+		}
+		return injected, true
+	}
+	return nil, false
+}
@@ -0,0 +1,44 @@
+package guardial
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCanaryInjection(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantCanary bool
+	}{
+		{"small response", "<html><body>orders</body></html>", true},
+		{"response over the rewrite limit", "<html><body>" + strings.Repeat("x", maxRewriteBodySize) + "</body></html>", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, verdictAPI(`{"allowed":true,"action":"allow","risk_score":80}`))
+			options := DefaultMiddlewareOptions()
+			options.Canary = &CanaryOptions{}
+			handler := StandardMiddleware(client, options)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/html")
+				// Written in chunks without flushing, like a chunked download
+				for body := tt.body; body != ""; {
+					n := min(len(body), 64*1024)
+					w.Write([]byte(body[:n]))
+					body = body[n:]
+				}
+			}))
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest("GET", "/api/orders", nil))
+			if got := strings.Contains(rec.Body.String(), canaryKeyPrefix); got != tt.wantCanary {
+				t.Errorf("canary embedded = %t, want %t", got, tt.wantCanary)
+			}
+			if !tt.wantCanary && rec.Body.String() != tt.body {
+				t.Errorf("response body changed: %d bytes, want %d", rec.Body.Len(), len(tt.body))
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+/**
+ * Guardial Log Tailer Agent
+ * Batching, submission, and checkpointing
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	guardial "github.com/divyankvijayvergiya/guardial-sdk"
+)
+
+// maxPendingBatches bounds how far reading runs ahead of a failing backend
+const maxPendingBatches = 10
+
+// checkpoint is the persisted agent state
+type checkpoint struct {
+	Offsets map[string]int64 `json:"offsets"` // Log path -> byte offset of the next unsubmitted line
+}
+
+func loadCheckpoint(path string) (*checkpoint, error) {
+	state := &checkpoint{Offsets: make(map[string]int64)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint: %w", err)
+	}
+	if state.Offsets == nil {
+		state.Offsets = make(map[string]int64)
+	}
+	return state, nil
+}
+
+// save writes the checkpoint atomically so a crash never leaves a torn state file
+func (s *checkpoint) save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
+
+// agent reads new lines from every tailer and submits them in batches
+type agent struct {
+	client    *guardial.Client
+	format    guardial.LogFormat
+	tailers   []*tailer
+	state     *checkpoint
+	statePath string
+	batchSize int
+
+	pending []*guardial.SecurityEventRequest
+	offsets map[string]int64 // Offsets reached by the pending events
+	skipped int
+}
+
+func (a *agent) run(ctx context.Context, pollInterval, flushInterval time.Duration) {
+	poll := time.NewTicker(pollInterval)
+	defer poll.Stop()
+	flush := time.NewTicker(flushInterval)
+	defer flush.Stop()
+
+	a.poll()
+	for {
+		select {
+		case <-ctx.Done():
+			// Ship what has been read before exiting
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			a.flush(shutdownCtx)
+			cancel()
+			for _, t := range a.tailers {
+				t.close()
+			}
+			return
+		case <-poll.C:
+			a.poll()
+		case <-flush.C:
+			a.flush(ctx)
+		}
+	}
+}
+
+// poll reads new lines from every log, flushing whenever a batch fills up
+func (a *agent) poll() {
+	for _, t := range a.tailers {
+		for len(a.pending) < a.batchSize*maxPendingBatches {
+			line, offset, ok := t.next()
+			if !ok {
+				break
+			}
+			if a.offsets == nil {
+				a.offsets = make(map[string]int64)
+			}
+			a.offsets[t.path] = offset
+
+			event, err := guardial.ParseAccessLogLine(line, a.format)
+			if err != nil {
+				a.skipped++
+				continue
+			}
+			a.pending = append(a.pending, event)
+			if len(a.pending) >= a.batchSize && !a.flush(context.Background()) {
+				// Backend is failing; wait for the next flush tick before reading on
+				return
+			}
+		}
+	}
+}
+
+// flush submits pending events and advances the checkpoint. On failure the events are
+// kept and retried on the next flush. It reports whether the submission succeeded.
+func (a *agent) flush(ctx context.Context) bool {
+	if len(a.pending) > 0 {
+		analyses, err := a.client.AnalyzeEventsContext(ctx, a.pending)
+		if err != nil {
+			log.Printf("guardial-agent: failed to submit %d events, will retry: %v", len(a.pending), err)
+			return false
+		}
+		blocked := 0
+		for _, analysis := range analyses {
+			if !analysis.Allowed {
+				blocked++
+			}
+		}
+		log.Printf("guardial-agent: submitted %d events (%d would be blocked, %d lines skipped)", len(analyses), blocked, a.skipped)
+		a.pending = a.pending[:0]
+		a.skipped = 0
+	}
+
+	if len(a.offsets) == 0 {
+		return true
+	}
+	for path, offset := range a.offsets {
+		a.state.Offsets[path] = offset
+	}
+	a.offsets = nil
+	if err := a.state.save(a.statePath); err != nil {
+		log.Printf("guardial-agent: %v", err)
+	}
+	return true
+}
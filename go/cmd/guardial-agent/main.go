@@ -0,0 +1,73 @@
+/**
+ * Guardial Log Tailer Agent
+ * Tails access logs on hosts that can't be instrumented and ships them for analysis
+ */
+
+// Command guardial-agent follows nginx/Apache/ALB/JSON access logs, converts each line
+// into an event, and submits them in batches through the SDK's enrichment pipeline.
+// Read offsets are checkpointed to a state file so restarts resume where they left off.
+//
+//	GUARDIAL_API_KEY=... guardial-agent -format combined /var/log/nginx/access.log
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	guardial "github.com/divyankvijayvergiya/guardial-sdk"
+)
+
+func main() {
+	format := flag.String("format", string(guardial.LogFormatCombined), "log format: combined, alb, or jsonlines")
+	statePath := flag.String("state", "guardial-agent.state.json", "checkpoint file for read offsets")
+	batchSize := flag.Int("batch", 100, "events per submission")
+	flushInterval := flag.Duration("flush", 5*time.Second, "maximum time an event waits before submission")
+	pollInterval := flag.Duration("poll", time.Second, "how often to check logs for new lines")
+	fromStart := flag.Bool("from-start", false, "read logs without a checkpoint from the beginning instead of the end")
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: guardial-agent [flags] <access log>...")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+	switch guardial.LogFormat(*format) {
+	case guardial.LogFormatCombined, guardial.LogFormatALB, guardial.LogFormatJSONLines:
+	default:
+		log.Fatalf("guardial-agent: unsupported log format %q", *format)
+	}
+
+	client, err := guardial.NewClientFromEnv()
+	if err != nil {
+		log.Fatalf("guardial-agent: %v", err)
+	}
+
+	state, err := loadCheckpoint(*statePath)
+	if err != nil {
+		log.Fatalf("guardial-agent: %v", err)
+	}
+
+	agent := &agent{
+		client:    client,
+		format:    guardial.LogFormat(*format),
+		state:     state,
+		statePath: *statePath,
+		batchSize: *batchSize,
+	}
+	for _, path := range flag.Args() {
+		offset, ok := state.Offsets[path]
+		agent.tailers = append(agent.tailers, newTailer(path, offset, ok, *fromStart))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Printf("guardial-agent: tailing %d log(s) as %s", len(agent.tailers), *format)
+	agent.run(ctx, *pollInterval, *flushInterval)
+}
@@ -0,0 +1,143 @@
+/**
+ * Guardial Log Tailer Agent
+ * Follows a log file across appends, truncation, and rotation
+ */
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"log"
+	"os"
+)
+
+// maxLineSize bounds a single log line; longer lines are dropped
+const maxLineSize = 1024 * 1024
+
+// tailer follows a single log file by path. When the file is rotated, the old file is
+// read to the end before switching to the new one.
+type tailer struct {
+	path      string
+	file      *os.File
+	info      os.FileInfo
+	reader    *bufio.Reader
+	offset    int64  // Read position in the open file
+	partial   []byte // Incomplete trailing line, waiting for its newline
+	start     int64  // Offset to open at; -1 means the end of the file
+	oversized bool   // Discarding the rest of a line over maxLineSize
+}
+
+// newTailer resumes at the checkpointed offset, or starts at the end of the file (or the
+// beginning with fromStart) when there is no checkpoint
+func newTailer(path string, checkpoint int64, hasCheckpoint, fromStart bool) *tailer {
+	start := checkpoint
+	if !hasCheckpoint && !fromStart {
+		start = -1
+	}
+	return &tailer{path: path, start: start}
+}
+
+// next returns the next complete line and the offset just past it
+func (t *tailer) next() (string, int64, bool) {
+	if t.file == nil && !t.open() {
+		return "", 0, false
+	}
+
+	for {
+		chunk, err := t.reader.ReadSlice('\n')
+		t.offset += int64(len(chunk))
+		if err == bufio.ErrBufferFull {
+			t.appendPartial(chunk)
+			continue
+		}
+		if err == io.EOF {
+			t.appendPartial(chunk)
+			if t.rotated() {
+				return t.next()
+			}
+			return "", 0, false
+		}
+		if err != nil {
+			log.Printf("guardial-agent: failed to read %s: %v", t.path, err)
+			t.close()
+			return "", 0, false
+		}
+
+		t.appendPartial(chunk)
+		line, oversized := t.partial, t.oversized
+		t.partial, t.oversized = nil, false
+		if oversized {
+			continue
+		}
+		return string(bytes.TrimRight(line, "\r\n")), t.offset, true
+	}
+}
+
+func (t *tailer) appendPartial(chunk []byte) {
+	if t.oversized {
+		return
+	}
+	if len(t.partial)+len(chunk) > maxLineSize {
+		t.partial, t.oversized = nil, true
+		return
+	}
+	t.partial = append(t.partial, chunk...)
+}
+
+// open opens the log at the start offset, falling back to the beginning if the file is
+// now shorter than the checkpoint (it was truncated or replaced while the agent was down)
+func (t *tailer) open() bool {
+	file, err := os.Open(t.path)
+	if err != nil {
+		return false
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return false
+	}
+
+	offset := t.start
+	if offset < 0 {
+		offset = info.Size()
+	}
+	if offset > info.Size() {
+		log.Printf("guardial-agent: %s is shorter than its checkpoint, reading from the start", t.path)
+		offset = 0
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return false
+	}
+
+	t.file, t.info, t.offset = file, info, offset
+	t.reader = bufio.NewReaderSize(file, 64*1024)
+	t.partial, t.oversized = nil, false
+	return true
+}
+
+// rotated checks, once the open file is exhausted, whether the path now names a new file
+// or the file was truncated. Either way the log is reopened from the beginning.
+func (t *tailer) rotated() bool {
+	current, err := os.Stat(t.path)
+	if err != nil {
+		return false
+	}
+	if os.SameFile(t.info, current) && current.Size() >= t.offset {
+		return false
+	}
+
+	log.Printf("guardial-agent: %s was rotated, reopening", t.path)
+	t.close()
+	t.start = 0
+	return t.open()
+}
+
+func (t *tailer) close() {
+	if t.file != nil {
+		t.file.Close()
+		t.file = nil
+	}
+}
@@ -0,0 +1,98 @@
+/**
+ * Guardial Reverse Proxy
+ * A standalone WAF sidecar in front of services that can't embed the SDK
+ */
+
+// Command guardial-proxy analyzes every request with Guardial and forwards those that
+// may proceed to an upstream service, in any language.
+//
+//	GUARDIAL_API_KEY=... guardial-proxy -listen :8080 -target http://localhost:3000
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	guardial "github.com/divyankvijayvergiya/guardial-sdk"
+)
+
+func main() {
+	listen := flag.String("listen", ":8080", "address to listen on")
+	target := flag.String("target", "", "upstream URL, e.g. http://localhost:3000")
+	monitor := flag.Bool("monitor", false, "record verdicts without refusing requests")
+	failClosed := flag.Bool("fail-closed", false, "refuse requests when analysis fails (blocked requests are always refused)")
+	exclude := flag.String("exclude", "/health,/favicon.ico", "comma-separated paths not analyzed")
+	trustForwarded := flag.Bool("trust-forwarded", false, "take client IPs from X-Forwarded-For (behind a load balancer)")
+	preserveHost := flag.Bool("preserve-host", false, "forward the incoming Host header")
+	shutdownTimeout := flag.Duration("shutdown-timeout", 15*time.Second, "time to drain connections and pending events on exit")
+	flag.Parse()
+
+	if *target == "" {
+		fmt.Fprintln(os.Stderr, "usage: guardial-proxy -target <upstream URL> [flags]")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+	upstream, err := url.Parse(*target)
+	if err != nil || upstream.Scheme == "" || upstream.Host == "" {
+		log.Fatalf("guardial-proxy: invalid target %q", *target)
+	}
+
+	client, err := guardial.NewClientFromEnv()
+	if err != nil {
+		log.Fatalf("guardial-proxy: %v", err)
+	}
+
+	options := guardial.DefaultMiddlewareOptions()
+	options.FailOpen = !*failClosed
+	options.ExcludePaths = nil
+	for _, path := range strings.Split(*exclude, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			options.ExcludePaths = append(options.ExcludePaths, path)
+		}
+	}
+	if *monitor {
+		options.Mode = guardial.ModeMonitor
+	}
+
+	proxy := guardial.NewReverseProxy(upstream, client, &guardial.ProxyOptions{
+		Middleware:            options,
+		TrustForwardedHeaders: *trustForwarded,
+		PreserveHost:          *preserveHost,
+	})
+	server := &http.Server{Addr: *listen, Handler: proxy, ReadHeaderTimeout: 10 * time.Second}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), *shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Printf("guardial-proxy: %v", err)
+		}
+	}()
+
+	log.Printf("guardial-proxy: forwarding %s to %s", *listen, upstream)
+	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("guardial-proxy: %v", err)
+	}
+	<-drained
+
+	closeCtx, cancel := context.WithTimeout(context.Background(), *shutdownTimeout)
+	defer cancel()
+	if err := client.Close(closeCtx); err != nil {
+		log.Printf("guardial-proxy: %v", err)
+	}
+}
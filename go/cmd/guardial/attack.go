@@ -0,0 +1,136 @@
+/**
+ * Guardial CLI
+ * attack: fire generated attack payloads at a deployment and report what was caught
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	guardial "github.com/divyankvijayvergiya/guardial-sdk"
+)
+
+func runAttack(args []string) {
+	flags := flag.NewFlagSet("attack", flag.ExitOnError)
+	target := flags.String("target", "", "URL of an endpoint behind the Guardial middleware; empty sends payloads to the analysis API")
+	categories := flags.String("categories", "", "comma-separated attack categories (default: all of "+strings.Join(guardial.AttackCategories(), ", ")+")")
+	param := flags.String("param", "q", "query parameter carrying web payloads")
+	promptField := flags.String("prompt-field", "prompt", "JSON body field carrying jailbreak prompts")
+	blockStatus := flags.Int("block-status", 403, "response status that means blocked")
+	delay := flags.Duration("delay", 0, "pause between requests")
+	list := flags.Bool("list", false, "print the payloads without sending them")
+	asJSON := flags.Bool("json", false, "print the full report as JSON")
+	showMisses := flags.Bool("misses", false, "list payloads that were not detected")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: guardial attack [flags]")
+		fmt.Fprintln(os.Stderr, "Only attack deployments you are authorized to test.")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	var selected []string
+	if *categories != "" {
+		selected = strings.Split(*categories, ",")
+		for _, category := range selected {
+			if !contains(guardial.AttackCategories(), category) {
+				log.Fatalf("guardial attack: unknown category %q", category)
+			}
+		}
+	}
+	payloads := guardial.GeneratePayloads(selected...)
+
+	if *list {
+		for _, payload := range payloads {
+			fmt.Printf("%s\t%s\t%s\t%q\n", payload.Category, payload.Name, payload.Tamper, payload.Value)
+		}
+		return
+	}
+
+	options := &guardial.RedTeamOptions{
+		Target:      *target,
+		Param:       *param,
+		PromptField: *promptField,
+		BlockStatus: *blockStatus,
+		Delay:       *delay,
+	}
+	if *target == "" {
+		client, err := guardial.NewClientFromEnv()
+		if err != nil {
+			log.Fatalf("guardial attack: %v", err)
+		}
+		defer client.Close(context.Background())
+		options.Client = client
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	started := time.Now()
+	report, err := guardial.RedTeam(ctx, payloads, options)
+	if err != nil && report == nil {
+		log.Fatalf("guardial attack: %v", err)
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(report)
+	} else {
+		printAttackReport(report, time.Since(started), *showMisses)
+	}
+	if err != nil {
+		log.Fatalf("guardial attack: stopped early: %v", err)
+	}
+}
+
+func printAttackReport(report *guardial.RedTeamReport, elapsed time.Duration, showMisses bool) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "CATEGORY\tSENT\tDETECTED\tBLOCKED\tERRORS")
+	for _, category := range guardial.AttackCategories() {
+		if tally := report.Categories[category]; tally != nil {
+			fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\n", category, tally.Sent, tally.Detected, tally.Blocked, tally.Errors)
+		}
+	}
+	total := report.Total
+	fmt.Fprintf(w, "total\t%d\t%d\t%d\t%d\n", total.Sent, total.Detected, total.Blocked, total.Errors)
+	w.Flush()
+	fmt.Printf("\n%d payloads in %s\n", total.Sent, elapsed.Round(time.Millisecond))
+
+	if !showMisses {
+		return
+	}
+	fmt.Println()
+	for _, result := range report.Results {
+		payload := result.Payload
+		label := payload.Category + "/" + payload.Name
+		if payload.Tamper != "" {
+			label += " (" + payload.Tamper + ")"
+		}
+		switch {
+		case result.Error != "":
+			fmt.Printf("error   %s: %s\n", label, result.Error)
+		case !result.Detected:
+			fmt.Printf("missed  %s: %q\n", label, payload.Value)
+		}
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+/**
+ * Guardial CLI
+ * eval: precision and recall per detector over a labeled corpus
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+
+	guardial "github.com/divyankvijayvergiya/guardial-sdk"
+)
+
+func runEval(args []string) {
+	flags := flag.NewFlagSet("eval", flag.ExitOnError)
+	asJSON := flags.Bool("json", false, "print the full report as JSON")
+	showMisses := flags.Bool("misses", false, "list misclassified samples")
+	minPrecision := flags.Float64("min-precision", 0, "exit with status 1 if the precision of blocking falls below this")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: guardial eval [flags] <corpus dir>")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	samples, err := guardial.LoadCorpus(flags.Arg(0))
+	if err != nil {
+		log.Fatalf("guardial eval: %v", err)
+	}
+	if len(samples) == 0 {
+		log.Fatalf("guardial eval: no samples under %s/attack or %s/benign", flags.Arg(0), flags.Arg(0))
+	}
+
+	client, err := guardial.NewClientFromEnv()
+	if err != nil {
+		log.Fatalf("guardial eval: %v", err)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	defer client.Close(context.Background())
+
+	options := guardial.DefaultMiddlewareOptions()
+	options.ExcludePaths = nil
+	report := client.Simulate(ctx, samples, options)
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(report)
+	} else {
+		printReport(report, *showMisses)
+	}
+
+	if report.Overall.Precision < *minPrecision {
+		os.Exit(1)
+	}
+}
+
+func printReport(report *guardial.SimulationReport, showMisses bool) {
+	fmt.Printf("%d samples (%d attack, %d benign), %d errors\n\n", report.Samples, report.Attacks, report.Benign, report.Errors)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "DETECTOR\tTP\tFP\tFN\tPRECISION\tRECALL")
+	for _, metrics := range append([]guardial.DetectorMetrics{report.Overall}, report.Detectors...) {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%s\t%s\n", metrics.Detector,
+			metrics.TruePositives, metrics.FalsePositives, metrics.FalseNegatives,
+			ratio(metrics.Precision, metrics.TruePositives+metrics.FalsePositives),
+			ratio(metrics.Recall, metrics.Expected))
+	}
+	w.Flush()
+
+	if !showMisses {
+		return
+	}
+	fmt.Println()
+	for _, result := range report.Results {
+		switch {
+		case result.Error != "":
+			fmt.Printf("error    %s: %s\n", result.Sample, result.Error)
+		case result.Misclassified() && result.Label == guardial.LabelBenign:
+			fmt.Printf("blocked  %s %v\n", result.Sample, result.Detectors)
+		case result.Misclassified():
+			fmt.Printf("missed   %s (expected %q) %v\n", result.Sample, result.Expected, result.Detectors)
+		}
+	}
+}
+
+// ratio formats a precision or recall, or "-" when it has no samples behind it
+func ratio(value float64, samples int) string {
+	if samples == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.3f", value)
+}
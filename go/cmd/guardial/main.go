@@ -0,0 +1,38 @@
+/**
+ * Guardial CLI
+ * Command-line tools for working with the Guardial SDK
+ */
+
+// Command guardial bundles offline tools for the Guardial SDK.
+//
+//	GUARDIAL_API_KEY=... guardial eval corpus/
+//	guardial attack -target https://staging.example.com/search
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	switch os.Args[1] {
+	case "eval":
+		runEval(os.Args[2:])
+	case "attack":
+		runAttack(os.Args[2:])
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: guardial <command> [arguments]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "commands:")
+	fmt.Fprintln(os.Stderr, "  eval    score detectors against a labeled attack/benign corpus")
+	fmt.Fprintln(os.Stderr, "  attack  fire generated attack payloads at a deployment and report what was caught")
+	os.Exit(2)
+}
@@ -0,0 +1,84 @@
+/**
+ * Guardial Go SDK Request Coalescing
+ * Collapses concurrent identical analyses into a single API call
+ */
+
+package guardial
+
+import (
+	"context"
+	"sync"
+)
+
+// coalesceCall is one in-flight analysis shared by every caller with the same key
+type coalesceCall struct {
+	done     chan struct{}
+	analysis *SecurityEventResponse
+	err      error
+
+	waiters int                // Callers still waiting; guarded by coalesceGroup.mu
+	cancel  context.CancelFunc // Cancels the call once every caller has given up
+}
+
+// coalesceGroup is a minimal singleflight keyed by request signature
+type coalesceGroup struct {
+	mu    sync.Mutex
+	calls map[string]*coalesceCall
+}
+
+func newCoalesceGroup() *coalesceGroup {
+	return &coalesceGroup{calls: make(map[string]*coalesceCall)}
+}
+
+// do runs fn once per key among concurrent callers. fn runs detached from any single
+// caller's cancellation, so one caller giving up doesn't fail the others; each caller
+// still stops waiting when its own ctx is done, and fn is canceled once all of them have.
+func (g *coalesceGroup) do(ctx context.Context, key string, fn func(context.Context) (*SecurityEventResponse, error)) (*SecurityEventResponse, bool, error) {
+	g.mu.Lock()
+	call, shared := g.calls[key]
+	if !shared {
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &coalesceCall{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = call
+
+		go func() {
+			call.analysis, call.err = fn(callCtx)
+			cancel()
+
+			g.mu.Lock()
+			if g.calls[key] == call {
+				delete(g.calls, key)
+			}
+			g.mu.Unlock()
+			close(call.done)
+		}()
+	}
+	call.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+	case <-ctx.Done():
+		g.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			// Nobody wants the verdict anymore; later callers start a fresh call
+			call.cancel()
+			if g.calls[key] == call {
+				delete(g.calls, key)
+			}
+		}
+		g.mu.Unlock()
+		return nil, shared, ctx.Err()
+	}
+	if call.err != nil {
+		return nil, shared, call.err
+	}
+
+	// Every caller gets its own copy of the verdict
+	analysis := *call.analysis
+	analysis.RiskReasons = append([]string(nil), analysis.RiskReasons...)
+	analysis.Reasons = append([]RiskReason(nil), analysis.Reasons...)
+	analysis.OwaspDetected = append([]OwaspDetection(nil), analysis.OwaspDetected...)
+	return &analysis, shared, nil
+}
@@ -0,0 +1,48 @@
+/**
+ * Guardial Go SDK Payload Compression
+ * Gzip for large event payloads, with fallback for backends that don't accept it
+ */
+
+package guardial
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"net/http"
+)
+
+// defaultCompressionThreshold is the payload size above which bodies are gzipped
+const defaultCompressionThreshold = 4 * 1024
+
+// compressPayload gzips payload when it is large enough and the backend accepts gzip.
+// It returns the body to send and whether it is compressed.
+func (c *Client) compressPayload(payload []byte) ([]byte, bool) {
+	threshold := c.config.CompressionThreshold
+	if threshold < 0 || c.gzipRejected.Load() {
+		return payload, false
+	}
+	if threshold == 0 {
+		threshold = defaultCompressionThreshold
+	}
+	if len(payload) < threshold {
+		return payload, false
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(payload); err != nil {
+		return payload, false
+	}
+	if err := zw.Close(); err != nil {
+		return payload, false
+	}
+	return buf.Bytes(), true
+}
+
+// isUnsupportedMediaType reports whether err says the backend can't decode the request
+// body's encoding
+func isUnsupportedMediaType(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnsupportedMediaType
+}
@@ -0,0 +1,53 @@
+package guardial
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestUnsupportedMediaTypeFallback(t *testing.T) {
+	tests := []struct {
+		name          string
+		rejectGzip    bool
+		rejectMsgpack bool
+		wantType      string // Content type of requests after the first
+		wantGzip      bool   // Whether requests after the first are gzipped
+	}{
+		{"msgpack rejected, gzip accepted", false, true, contentTypeJSON, true},
+		{"gzip rejected, msgpack accepted", true, false, contentTypeMsgpack, false},
+		{"both rejected", true, true, contentTypeJSON, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lastType, lastEncoding string
+			client := newConfiguredTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				io.Copy(io.Discard, r.Body)
+				lastType, lastEncoding = r.Header.Get("Content-Type"), r.Header.Get("Content-Encoding")
+				if (tt.rejectGzip && lastEncoding == "gzip") || (tt.rejectMsgpack && lastType == contentTypeMsgpack) {
+					http.Error(w, "unsupported", http.StatusUnsupportedMediaType)
+					return
+				}
+				verdictAPI(allowedVerdict)(w, r)
+			}, func(config *Config) {
+				config.WireEncoding = EncodingMsgpack
+				config.CompressionThreshold = 1
+			})
+
+			event := &SecurityEventRequest{Method: "POST", Path: "/api/orders", RequestBody: strings.Repeat("a", 512)}
+			for i := 0; i < 2; i++ {
+				if err := client.postJSON(context.Background(), "/api/events", event, nil); err != nil {
+					t.Fatalf("postJSON() = %v", err)
+				}
+			}
+			if lastType != tt.wantType || (lastEncoding == "gzip") != tt.wantGzip {
+				t.Errorf("request sent as %q, gzip %t; want %q, gzip %t", lastType, lastEncoding == "gzip", tt.wantType, tt.wantGzip)
+			}
+			if got := client.gzipRejected.Load(); got != tt.rejectGzip {
+				t.Errorf("gzipRejected = %t, want %t", got, tt.rejectGzip)
+			}
+		})
+	}
+}
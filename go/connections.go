@@ -0,0 +1,255 @@
+/**
+ * Guardial Go SDK Connection Collector
+ * Connection-level metadata from the Go listener for volumetric attack findings
+ */
+
+package guardial
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ConnectionCollectorOptions configures connection metadata collection. Zero thresholds
+// disable the corresponding finding.
+type ConnectionCollectorOptions struct {
+	Window              time.Duration // Window for connection rates (default: 10s)
+	MaxConnectionRate   int           // New connections per IP per Window before a connection_flood finding
+	MaxConcurrentPerIP  int           // Open connections per IP before a connection_exhaustion finding
+	MaxTotalConnections int           // New connections across all IPs per Window before a connection_surge finding
+	ReportCooldown      time.Duration // Minimum time between findings for the same IP and type (default: 1m)
+	Reject              bool          // Close new connections from IPs over MaxConcurrentPerIP
+}
+
+// DefaultConnectionCollectorOptions returns conservative thresholds for a public listener
+func DefaultConnectionCollectorOptions() *ConnectionCollectorOptions {
+	return &ConnectionCollectorOptions{
+		Window:             10 * time.Second,
+		MaxConnectionRate:  200,
+		MaxConcurrentPerIP: 100,
+		ReportCooldown:     time.Minute,
+	}
+}
+
+// ConnectionStats is a snapshot of the collector's counters
+type ConnectionStats struct {
+	Active   int                          // Open connections
+	Accepted int                          // Connections accepted in the current window
+	ByIP     map[string]IPConnectionStats // Per-IP counters
+}
+
+// IPConnectionStats holds the counters for one source IP
+type IPConnectionStats struct {
+	Active   int // Open connections
+	Accepted int // Connections accepted in the current window
+}
+
+// ConnectionCollector records accept rates and concurrent connections per source IP from
+// a net.Listener, without kernel-level tooling. Rates count accepted connections, so
+// half-open SYNs that never complete the handshake are not visible.
+type ConnectionCollector struct {
+	client  *Client
+	options ConnectionCollectorOptions
+
+	mu          sync.Mutex
+	ips         map[string]*ipConnections
+	active      int
+	accepted    int
+	windowStart time.Time
+	reported    map[string]time.Time // "type|ip" -> last report
+}
+
+type ipConnections struct {
+	active      int
+	accepted    int
+	windowStart time.Time
+}
+
+// NewConnectionCollector creates a collector that reports volumetric findings through c
+func (c *Client) NewConnectionCollector(options *ConnectionCollectorOptions) *ConnectionCollector {
+	opts := *DefaultConnectionCollectorOptions()
+	if options != nil {
+		opts = *options
+	}
+	if opts.Window <= 0 {
+		opts.Window = 10 * time.Second
+	}
+	if opts.ReportCooldown <= 0 {
+		opts.ReportCooldown = time.Minute
+	}
+	return &ConnectionCollector{
+		client:      c,
+		options:     opts,
+		ips:         make(map[string]*ipConnections),
+		windowStart: time.Now(),
+		reported:    make(map[string]time.Time),
+	}
+}
+
+// Listener wraps ln so accepted connections are counted. It composes with other
+// listener wrappers such as SlowClientGuard.Listener.
+func (cc *ConnectionCollector) Listener(ln net.Listener) net.Listener {
+	return &collectingListener{Listener: ln, collector: cc}
+}
+
+// Stats returns a snapshot of the current counters
+func (cc *ConnectionCollector) Stats() ConnectionStats {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	now := time.Now()
+	stats := ConnectionStats{Active: cc.active, ByIP: make(map[string]IPConnectionStats, len(cc.ips))}
+	if now.Sub(cc.windowStart) < cc.options.Window {
+		stats.Accepted = cc.accepted
+	}
+	for ip, conns := range cc.ips {
+		ipStats := IPConnectionStats{Active: conns.active}
+		if now.Sub(conns.windowStart) < cc.options.Window {
+			ipStats.Accepted = conns.accepted
+		}
+		stats.ByIP[ip] = ipStats
+	}
+	return stats
+}
+
+// opened records a new connection from ip and reports whether it should be accepted
+func (cc *ConnectionCollector) opened(ip string) bool {
+	now := time.Now()
+	var findings []*Finding
+
+	cc.mu.Lock()
+	if now.Sub(cc.windowStart) >= cc.options.Window {
+		cc.windowStart, cc.accepted = now, 0
+		cc.sweep(now)
+	}
+	conns := cc.ips[ip]
+	if conns == nil {
+		conns = &ipConnections{windowStart: now}
+		cc.ips[ip] = conns
+	}
+	if now.Sub(conns.windowStart) >= cc.options.Window {
+		conns.windowStart, conns.accepted = now, 0
+	}
+
+	cc.accepted++
+	conns.accepted++
+	opts := cc.options
+
+	if opts.MaxConnectionRate > 0 && conns.accepted > opts.MaxConnectionRate && cc.shouldReport("connection_flood", ip, now) {
+		findings = append(findings, cc.finding("connection_flood", "Connection flood from a single source", ip,
+			fmt.Sprintf("%d new connections in %s (threshold %d)", conns.accepted, opts.Window, opts.MaxConnectionRate)))
+	}
+	if opts.MaxTotalConnections > 0 && cc.accepted > opts.MaxTotalConnections && cc.shouldReport("connection_surge", "", now) {
+		findings = append(findings, cc.finding("connection_surge", "Connection surge across sources", "",
+			fmt.Sprintf("%d new connections from %d sources in %s (threshold %d)", cc.accepted, len(cc.ips), opts.Window, opts.MaxTotalConnections)))
+	}
+
+	accept := true
+	if opts.MaxConcurrentPerIP > 0 && conns.active >= opts.MaxConcurrentPerIP {
+		if cc.shouldReport("connection_exhaustion", ip, now) {
+			findings = append(findings, cc.finding("connection_exhaustion", "Connection exhaustion from a single source", ip,
+				fmt.Sprintf("%d concurrent connections (threshold %d)", conns.active+1, opts.MaxConcurrentPerIP)))
+		}
+		accept = !opts.Reject
+	}
+	if accept {
+		conns.active++
+		cc.active++
+	}
+	cc.mu.Unlock()
+
+	for _, finding := range findings {
+		cc.client.log("⚡ Volumetric connection pattern:", finding.Type, finding.SourceIP, finding.Evidence)
+		cc.client.ReportFindingAsync(cc.client.ctx, finding)
+	}
+	return accept
+}
+
+// closed records that a connection from ip was closed
+func (cc *ConnectionCollector) closed(ip string) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if conns := cc.ips[ip]; conns != nil && conns.active > 0 {
+		conns.active--
+		cc.active--
+	}
+}
+
+// shouldReport applies the per-IP report cooldown. Callers hold cc.mu.
+func (cc *ConnectionCollector) shouldReport(findingType, ip string, now time.Time) bool {
+	key := findingType + "|" + ip
+	if last, ok := cc.reported[key]; ok && now.Sub(last) < cc.options.ReportCooldown {
+		return false
+	}
+	cc.reported[key] = now
+	return true
+}
+
+// sweep drops idle IPs and expired cooldowns so the maps don't grow without bound.
+// Callers hold cc.mu.
+func (cc *ConnectionCollector) sweep(now time.Time) {
+	for ip, conns := range cc.ips {
+		if conns.active == 0 && now.Sub(conns.windowStart) >= cc.options.Window {
+			delete(cc.ips, ip)
+		}
+	}
+	for key, last := range cc.reported {
+		if now.Sub(last) >= cc.options.ReportCooldown {
+			delete(cc.reported, key)
+		}
+	}
+}
+
+func (cc *ConnectionCollector) finding(findingType, title, ip, evidence string) *Finding {
+	return &Finding{
+		Type:     findingType,
+		Title:    title,
+		Severity: "HIGH",
+		Evidence: evidence,
+		SourceIP: ip,
+		Metadata: map[string]string{
+			"window":   cc.options.Window.String(),
+			"rejected": strconv.FormatBool(findingType == "connection_exhaustion" && cc.options.Reject),
+		},
+	}
+}
+
+type collectingListener struct {
+	net.Listener
+	collector *ConnectionCollector
+}
+
+func (l *collectingListener) Accept() (net.Conn, error) {
+	for {
+		c, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+
+		ip := c.RemoteAddr().String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+		if !l.collector.opened(ip) {
+			c.Close()
+			continue
+		}
+		return &collectedConn{Conn: c, collector: l.collector, ip: ip}, nil
+	}
+}
+
+// collectedConn decrements the open connection count exactly once on Close
+type collectedConn struct {
+	net.Conn
+	collector *ConnectionCollector
+	ip        string
+	once      sync.Once
+}
+
+func (c *collectedConn) Close() error {
+	c.once.Do(func() { c.collector.closed(c.ip) })
+	return c.Conn.Close()
+}
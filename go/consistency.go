@@ -0,0 +1,124 @@
+/**
+ * Guardial Go SDK Enforcement Checks
+ * Polls until blocks and policy changes show up in verdicts
+ */
+
+package guardial
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// AwaitOptions configures how long AwaitVerdict and AwaitBlock poll
+type AwaitOptions struct {
+	Timeout  time.Duration `json:"timeout"`  // Give up after (default: 30s)
+	Interval time.Duration `json:"interval"` // Delay between polls (default: 500ms)
+}
+
+// AwaitVerdict analyzes probe until want accepts the verdict, so incident-response
+// scripts can confirm a policy change took effect. Every poll goes to the API (or
+// sidecar), skipping the verdict cache, the async queue, and the quota governor, and
+// counts toward usage like any analysis. It returns the accepted verdict, or
+// ErrNotObserved with the last verdict once the timeout passes.
+func (c *Client) AwaitVerdict(ctx context.Context, probe *SecurityEventRequest, want func(*SecurityEventResponse) bool, options *AwaitOptions) (*SecurityEventResponse, error) {
+	if probe.CustomerID == "" {
+		probe.CustomerID = c.config.CustomerID
+	}
+	c.enrichGeo(probe)
+	c.scoreCost(probe)
+
+	var last *SecurityEventResponse
+	err := c.await(ctx, options, func(ctx context.Context) (bool, error) {
+		analysis, err := c.probeVerdict(ctx, probe)
+		if err != nil {
+			return false, err
+		}
+		last = analysis
+		return want(analysis), nil
+	})
+	return last, err
+}
+
+// AwaitBlock waits until requests from ip are refused: by a block on this instance,
+// one propagated from a sibling, or the API's verdict for a probe request from ip.
+// Pass the client an incident-response script shares a BlockPropagator with to
+// confirm a block raised elsewhere reached it.
+func (c *Client) AwaitBlock(ctx context.Context, ip string, options *AwaitOptions) error {
+	probe := c.NewEvent(RequestParts{
+		Method:  http.MethodGet,
+		Path:    "/",
+		Headers: map[string]string{"X-Real-Ip": ip},
+	})
+	c.enrichGeo(probe)
+	c.scoreCost(probe)
+
+	return c.await(ctx, options, func(ctx context.Context) (bool, error) {
+		if _, blocked := c.IsBlocked(ip); blocked {
+			return true, nil
+		}
+		analysis, err := c.probeVerdict(ctx, probe)
+		if err != nil {
+			return false, err
+		}
+		return !analysis.Allowed, nil
+	})
+}
+
+// await calls poll every interval until it reports done, ctx ends, or the timeout
+// passes. Poll errors are retried; the last one is wrapped into the timeout error.
+func (c *Client) await(ctx context.Context, options *AwaitOptions, poll func(ctx context.Context) (bool, error)) error {
+	opts := AwaitOptions{Timeout: 30 * time.Second, Interval: 500 * time.Millisecond}
+	if options != nil {
+		if options.Timeout > 0 {
+			opts.Timeout = options.Timeout
+		}
+		if options.Interval > 0 {
+			opts.Interval = options.Interval
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+
+	var lastErr error
+	for {
+		done, err := poll(ctx)
+		if done {
+			return nil
+		}
+		if err != nil && ctx.Err() == nil {
+			c.warn("⚠️ Enforcement check failed:", err)
+			lastErr = err
+		}
+
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return fmt.Errorf("%w: %w", ErrNotObserved, lastErr)
+			}
+			return ErrNotObserved
+		case <-ticker.C:
+		}
+	}
+}
+
+// probeVerdict fetches a fresh verdict for event. It refreshes the verdict cache so
+// live traffic sees the change as soon as the check does.
+func (c *Client) probeVerdict(ctx context.Context, event *SecurityEventRequest) (*SecurityEventResponse, error) {
+	var signature string
+	if c.verdicts != nil {
+		signature = RequestSignature(event)
+	}
+	analysis, err := c.fetchAnalysis(ctx, event, signature)
+	if err != nil {
+		return nil, err
+	}
+	c.enforceCost(event, analysis)
+	c.applyPolicy(event, analysis)
+	return analysis, nil
+}
@@ -0,0 +1,163 @@
+/**
+ * Guardial Go SDK Request Cost Scoring
+ * Resource-consumption (OWASP API4) protection via pluggable cost estimates
+ */
+
+package guardial
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CostEstimator estimates how expensive a request is to serve
+type CostEstimator interface {
+	EstimateCost(event *SecurityEventRequest) int
+}
+
+// CostEstimatorFunc adapts a function to CostEstimator
+type CostEstimatorFunc func(event *SecurityEventRequest) int
+
+// EstimateCost calls f(event)
+func (f CostEstimatorFunc) EstimateCost(event *SecurityEventRequest) int {
+	return f(event)
+}
+
+// DefaultCostEstimator scores body size, query complexity, and known-expensive routes
+type DefaultCostEstimator struct {
+	RouteCosts map[string]int // Extra cost by path prefix; the longest matching prefix wins
+}
+
+// EstimateCost returns 1 plus 1 per KB of body, 1 per query parameter, 1 per level of
+// JSON nesting beyond the first, and the route's extra cost
+func (e DefaultCostEstimator) EstimateCost(event *SecurityEventRequest) int {
+	cost := 1 + len(event.RequestBody)/1024
+
+	// Query complexity
+	if values, err := url.ParseQuery(event.QueryParams); err == nil {
+		for _, v := range values {
+			cost += len(v)
+		}
+	}
+	if depth := nestingDepth(event.RequestBody); depth > 1 {
+		cost += depth - 1
+	}
+
+	// Known-expensive routes
+	matched, routeCost := -1, 0
+	for prefix, extra := range e.RouteCosts {
+		if strings.HasPrefix(event.Path, prefix) && len(prefix) > matched {
+			matched, routeCost = len(prefix), extra
+		}
+	}
+	return cost + routeCost
+}
+
+// nestingDepth returns the deepest bracket nesting in body, ignoring string contents
+func nestingDepth(body string) int {
+	depth, deepest := 0, 0
+	inString, escaped := false, false
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		switch {
+		case escaped:
+			escaped = false
+		case inString && c == '\\':
+			escaped = true
+		case c == '"':
+			inString = !inString
+		case inString:
+		case c == '{' || c == '[':
+			depth++
+			if depth > deepest {
+				deepest = depth
+			}
+		case c == '}' || c == ']':
+			if depth > 0 {
+				depth--
+			}
+		}
+	}
+	return deepest
+}
+
+// CostOptions configures request cost scoring
+type CostOptions struct {
+	Estimator CostEstimator `json:"-"` // default: DefaultCostEstimator{}
+
+	// MaxRequestCost blocks any single request costing more (0 disables)
+	MaxRequestCost int `json:"max_request_cost"`
+
+	// BudgetPerSource blocks a source IP once its total cost within Window exceeds it (0 disables)
+	BudgetPerSource int           `json:"budget_per_source"`
+	Window          time.Duration `json:"window"` // default: 1m
+}
+
+// costBudget tracks the cost spent by each source IP in fixed windows
+type costBudget struct {
+	mu          sync.Mutex
+	windowStart time.Time
+	spent       map[string]int
+}
+
+// charge adds cost to source's spend in the current window and returns the new total
+func (b *costBudget) charge(source string, cost int, window time.Duration) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	if b.spent == nil || now.Sub(b.windowStart) >= window {
+		b.windowStart = now
+		b.spent = make(map[string]int)
+	}
+	b.spent[source] += cost
+	return b.spent[source]
+}
+
+// scoreCost sets event.RequestCost using the configured estimator
+func (c *Client) scoreCost(event *SecurityEventRequest) {
+	if c.config.Cost == nil {
+		return
+	}
+	var estimator CostEstimator = DefaultCostEstimator{}
+	if c.config.Cost.Estimator != nil {
+		estimator = c.config.Cost.Estimator
+	}
+	event.RequestCost = estimator.EstimateCost(event)
+}
+
+// enforceCost blocks requests that are too expensive on their own or that exhaust
+// their source's budget, raising the risk score accordingly
+func (c *Client) enforceCost(event *SecurityEventRequest, analysis *SecurityEventResponse) {
+	options := c.config.Cost
+	if options == nil {
+		return
+	}
+
+	var reason string
+	if options.MaxRequestCost > 0 && event.RequestCost > options.MaxRequestCost {
+		reason = fmt.Sprintf("API4 unrestricted resource consumption: request cost %d exceeds %d", event.RequestCost, options.MaxRequestCost)
+	}
+	if options.BudgetPerSource > 0 && event.SourceIP != "" {
+		window := options.Window
+		if window <= 0 {
+			window = time.Minute
+		}
+		if spent := c.costs.charge(event.SourceIP, event.RequestCost, window); spent > options.BudgetPerSource && reason == "" {
+			reason = fmt.Sprintf("API4 unrestricted resource consumption: source cost %d exceeds budget %d per %s", spent, options.BudgetPerSource, window)
+		}
+	}
+	if reason == "" {
+		return
+	}
+
+	c.log("⚠️ Request cost limit exceeded:", event.SourceIP, event.Path)
+	weight := min(100, analysis.RiskScore+50) - analysis.RiskScore
+	analysis.RiskScore += weight
+	analysis.Allowed = false
+	analysis.Action = "block"
+	analysis.AddReason(ReasonCostLimit, ReasonCategoryCost, weight, reason)
+}
@@ -0,0 +1,26 @@
+package guardial
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNestingDepth(t *testing.T) {
+	tests := []struct {
+		body string
+		want int
+	}{
+		{"", 0},
+		{`{"a":1}`, 1},
+		{`{"a":[{"b":[1,2]}]}`, 4},
+		{`{"a":"[[[[{{{{"}`, 1},
+		{`{"a":"\"[[["}`, 1},
+		{`]]]}}}[`, 1},
+		{strings.Repeat("[", 10000), 10000},
+	}
+	for _, tt := range tests {
+		if got := nestingDepth(tt.body); got != tt.want {
+			t.Errorf("nestingDepth(%.20q) = %d, want %d", tt.body, got, tt.want)
+		}
+	}
+}
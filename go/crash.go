@@ -0,0 +1,171 @@
+/**
+ * Guardial Go SDK Crash Telemetry
+ * Panics and repeated server errors reported as crash-based probing signals
+ */
+
+package guardial
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CrashTelemetryOptions configures the middleware's recovery layer
+type CrashTelemetryOptions struct {
+	ServerErrorThreshold int           // 5xx responses for the same input before reporting (default: 3)
+	Window               time.Duration // Window the 5xx responses are counted in (default: 5m)
+	IncludeStack         bool          // Attach the (truncated) panic stack to the finding
+}
+
+// Fingerprint returns a stable identifier for the input carried by an event, so crashes
+// and errors can be correlated with the request that triggered them
+func Fingerprint(event *SecurityEventRequest) string {
+	h := sha256.New()
+	for _, part := range []string{event.Method, event.Path, event.QueryParams, event.RequestBody} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+// crashTracker counts server errors per input fingerprint
+type crashTracker struct {
+	mu        sync.Mutex
+	options   CrashTelemetryOptions
+	counts    map[string]*crashCount
+	lastSweep time.Time
+}
+
+type crashCount struct {
+	count int
+	first time.Time
+}
+
+func newCrashTracker(options *CrashTelemetryOptions) *crashTracker {
+	opts := *options
+	if opts.ServerErrorThreshold <= 0 {
+		opts.ServerErrorThreshold = 3
+	}
+	if opts.Window <= 0 {
+		opts.Window = 5 * time.Minute
+	}
+	return &crashTracker{options: opts, counts: make(map[string]*crashCount)}
+}
+
+// recordServerError counts a 5xx for fingerprint and reports whether the threshold was just reached
+func (t *crashTracker) recordServerError(fingerprint string) (int, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(t.lastSweep) > t.options.Window {
+		for key, entry := range t.counts {
+			if now.Sub(entry.first) > t.options.Window {
+				delete(t.counts, key)
+			}
+		}
+		t.lastSweep = now
+	}
+
+	entry, ok := t.counts[fingerprint]
+	if !ok || now.Sub(entry.first) > t.options.Window {
+		entry = &crashCount{first: now}
+		t.counts[fingerprint] = entry
+	}
+	entry.count++
+	return entry.count, entry.count == t.options.ServerErrorThreshold
+}
+
+// recoverCrash is deferred around downstream handlers by the recovery layer
+func (m *middleware) recoverCrash(w *statusRecorder, r *http.Request) {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+	if recovered == http.ErrAbortHandler {
+		panic(recovered)
+	}
+
+	finding := m.crashFinding(r, "panic", "Handler panic triggered by request input")
+	finding.Metadata["crash_kind"] = crashKind(recovered)
+	finding.Metadata["panic"] = truncate(fmt.Sprint(recovered), 512)
+	if m.crashes.options.IncludeStack {
+		finding.Metadata["stack"] = truncate(string(debug.Stack()), 4096)
+	}
+
+	m.client.log("💥 Panic recovered:", r.Method, r.URL.Path, recovered)
+	m.client.ReportFindingAsync(r.Context(), finding)
+
+	if !w.wroteHeader {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// observeStatus reports inputs that repeatedly produce server errors
+func (m *middleware) observeStatus(w *statusRecorder, r *http.Request) {
+	if w.status < http.StatusInternalServerError {
+		return
+	}
+
+	finding := m.crashFinding(r, "repeated_server_errors", "Repeated server errors triggered by the same input")
+	count, reached := m.crashes.recordServerError(finding.Metadata["fingerprint"])
+	if !reached {
+		return
+	}
+	finding.Metadata["status"] = strconv.Itoa(w.status)
+	finding.Metadata["count"] = strconv.Itoa(count)
+
+	m.client.log("💥 Repeated server errors:", r.Method, r.URL.Path, count)
+	m.client.ReportFindingAsync(r.Context(), finding)
+}
+
+func (m *middleware) crashFinding(r *http.Request, findingType, title string) *Finding {
+	event := &SecurityEventRequest{Method: r.Method, Path: r.URL.Path, QueryParams: r.URL.RawQuery}
+	eventID := ""
+	if state := requestStateFromContext(r.Context()); state != nil {
+		event = state.event
+		if state.analysis != nil {
+			eventID = state.analysis.EventID
+		}
+	}
+
+	return &Finding{
+		Type:     findingType,
+		Title:    title,
+		Severity: "HIGH",
+		Evidence: truncate(event.Method+" "+event.Path+"?"+event.QueryParams, 512),
+		Path:     event.Path,
+		SourceIP: m.client.getClientIP(r),
+		Metadata: map[string]string{
+			"fingerprint": Fingerprint(event),
+			"event_id":    eventID,
+		},
+	}
+}
+
+// crashKind classifies a recovered panic value
+func crashKind(recovered interface{}) string {
+	runtimeErr, ok := recovered.(runtime.Error)
+	if !ok {
+		return "panic"
+	}
+
+	msg := runtimeErr.Error()
+	switch {
+	case strings.Contains(msg, "nil pointer dereference"), strings.Contains(msg, "nil map"):
+		return "nil_dereference"
+	case strings.Contains(msg, "index out of range"), strings.Contains(msg, "slice bounds out of range"):
+		return "out_of_bounds"
+	case strings.Contains(msg, "divide by zero"):
+		return "divide_by_zero"
+	}
+	return "runtime_error"
+}
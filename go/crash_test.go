@@ -0,0 +1,88 @@
+package guardial
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHandlerMiddlewareRecovery(t *testing.T) {
+	tests := []struct {
+		name       string
+		next       func(w http.ResponseWriter)
+		wantStatus int
+		wantBody   string
+	}{
+		{"panic before writing", func(w http.ResponseWriter) { panic("boom") }, http.StatusInternalServerError, "Internal Server Error\n"},
+		{"panic after writing", func(w http.ResponseWriter) {
+			io.WriteString(w, "partial")
+			panic("boom")
+		}, http.StatusOK, "partial"},
+		{"no panic", func(w http.ResponseWriter) { io.WriteString(w, "ok") }, http.StatusOK, "ok"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, verdictAPI(allowedVerdict))
+			options := DefaultMiddlewareOptions()
+			options.CrashTelemetry = &CrashTelemetryOptions{}
+			handler := HandlerMiddleware(client, options)
+
+			analyzed := false
+			rec := httptest.NewRecorder()
+			handler(rec, httptest.NewRequest("GET", "/api/orders", nil), func(w http.ResponseWriter, r *http.Request) {
+				analyzed = requestStateFromContext(r.Context()) != nil
+				tt.next(w)
+			})
+			if rec.Code != tt.wantStatus || rec.Body.String() != tt.wantBody {
+				t.Errorf("response = %d %q, want %d %q", rec.Code, rec.Body.String(), tt.wantStatus, tt.wantBody)
+			}
+			if !analyzed {
+				t.Error("next did not receive the analyzed request")
+			}
+		})
+	}
+}
+
+func TestCloseWaitsForCrashFindings(t *testing.T) {
+	tests := []struct {
+		name     string
+		requests int
+		next     http.HandlerFunc
+	}{
+		{"panic", 1, func(w http.ResponseWriter, r *http.Request) { panic("boom") }},
+		{"repeated server errors", 3, func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "failed", http.StatusInternalServerError)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reported atomic.Int32
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/api/findings" {
+					time.Sleep(50 * time.Millisecond)
+					reported.Add(1)
+				}
+				verdictAPI(allowedVerdict)(w, r)
+			})
+			options := DefaultMiddlewareOptions()
+			options.CrashTelemetry = &CrashTelemetryOptions{}
+			handler := StandardMiddleware(client, options)(tt.next)
+
+			for i := 0; i < tt.requests; i++ {
+				handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/orders", nil))
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			if err := client.Close(ctx); err != nil {
+				t.Fatalf("Close() = %v, want nil", err)
+			}
+			if got := reported.Load(); got != 1 {
+				t.Errorf("findings reported by Close = %d, want 1", got)
+			}
+		})
+	}
+}
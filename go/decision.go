@@ -0,0 +1,60 @@
+/**
+ * Guardial Go SDK Decision Headers
+ * Machine-readable response headers on blocked responses
+ */
+
+package guardial
+
+import "net/http"
+
+// Decision headers set on blocked responses, so proxies, clients, and support tooling
+// can react to blocks without parsing the body. Their names and meanings are stable.
+const (
+	HeaderEventID  = "X-Guardial-Event-Id" // Event ID of the verdict; absent when the request was refused before analysis
+	HeaderAction   = "X-Guardial-Action"   // The verdict's action, e.g. "block"
+	HeaderCategory = "X-Guardial-Category" // Reason category, e.g. "injection", "policy", or "blocklist"
+)
+
+// decisionHeaders fills rejection.Headers for blocked requests, unless the middleware
+// is configured without decision headers. Canary trips get none, so the decoy response
+// doesn't tell the caller it was caught.
+func (m *middleware) decisionHeaders(rejection *Rejection) {
+	if m.options.DisableDecisionHeaders || rejection.Status >= http.StatusInternalServerError ||
+		rejection.Category == ReasonCategoryCanary {
+		return
+	}
+
+	headers := map[string]string{HeaderAction: "block"}
+	category := rejection.Category
+	if analysis := rejection.Analysis; analysis != nil {
+		if analysis.EventID != "" {
+			headers[HeaderEventID] = analysis.EventID
+		}
+		if analysis.Action != "" {
+			headers[HeaderAction] = analysis.Action
+		}
+		if category == "" {
+			category = primaryCategory(analysis)
+		}
+	}
+	if category != "" {
+		headers[HeaderCategory] = category
+	}
+	rejection.Headers = headers
+}
+
+// primaryCategory returns the category of the reason that contributed most to the
+// verdict, or of the first reason if none carries a weight
+func primaryCategory(analysis *SecurityEventResponse) string {
+	var primary *RiskReason
+	for i := range analysis.Reasons {
+		reason := &analysis.Reasons[i]
+		if primary == nil || reason.Weight > primary.Weight {
+			primary = reason
+		}
+	}
+	if primary == nil {
+		return ""
+	}
+	return primary.Category
+}
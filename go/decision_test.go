@@ -0,0 +1,42 @@
+package guardial
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestDecisionHeaders(t *testing.T) {
+	tests := []struct {
+		name      string
+		rejection Rejection
+		disabled  bool
+		want      map[string]string
+	}{
+		{"blocked verdict", Rejection{Status: http.StatusForbidden, Analysis: &SecurityEventResponse{
+			EventID: "evt_1", Action: "block", Reasons: []RiskReason{{Category: "injection", Weight: 1}},
+		}}, false, map[string]string{HeaderEventID: "evt_1", HeaderAction: "block", HeaderCategory: "injection"}},
+		{"blocklist", Rejection{Status: http.StatusForbidden, Category: ReasonCategoryBlocklist}, false,
+			map[string]string{HeaderAction: "block", HeaderCategory: ReasonCategoryBlocklist}},
+		{"canary decoy", Rejection{Status: http.StatusUnauthorized, Category: ReasonCategoryCanary}, false, nil},
+		{"analysis failure", Rejection{Status: http.StatusInternalServerError}, false, nil},
+		{"disabled", Rejection{Status: http.StatusForbidden, Category: ReasonCategoryBlocklist}, true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultMiddlewareOptions()
+			options.DisableDecisionHeaders = tt.disabled
+			m := newMiddleware(NewClient(&Config{APIKey: "test"}), options)
+
+			rejection := tt.rejection
+			m.decisionHeaders(&rejection)
+			if len(rejection.Headers) != len(tt.want) {
+				t.Fatalf("Headers = %v, want %v", rejection.Headers, tt.want)
+			}
+			for name, want := range tt.want {
+				if got := rejection.Headers[name]; got != want {
+					t.Errorf("header %s = %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,306 @@
+/**
+ * Guardial Go SDK Response Degradation
+ * Soft-blocking for medium-risk clients: stale data, reduced result sets, added latency
+ */
+
+package guardial
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DegradeOptions configures how responses to degraded requests are altered. Scrapers
+// that get a hard 403 rotate IPs and user agents; degraded responses keep them on a
+// slow, low-value path instead.
+type DegradeOptions struct {
+	Delay    time.Duration // Latency added before the handler runs
+	Jitter   time.Duration // Random extra latency up to this much, so the delay isn't a clean signal
+	MaxItems int           // Truncate JSON arrays (top-level, or fields of a top-level object) to this many items
+
+	// ServeStale answers degraded GET requests with the last response served to a
+	// non-degraded client for the same URL, without running the handler. Only
+	// responses to requests without credentials or cookies are kept.
+	ServeStale bool
+	StaleTTL   time.Duration // How long a response stays servable (default: 10m)
+}
+
+// maxStaleEntries and maxStaleBodySize bound the stale response cache
+const (
+	maxStaleEntries  = 1000
+	maxStaleBodySize = 1024 * 1024
+)
+
+// maxRewriteBodySize bounds the responses held in memory to be rewritten; larger ones
+// are passed through unchanged
+const maxRewriteBodySize = 4 * 1024 * 1024
+
+type degrader struct {
+	client  *Client
+	options DegradeOptions
+
+	mu    sync.Mutex
+	stale map[string]staleResponse
+}
+
+type staleResponse struct {
+	contentType string
+	body        []byte
+	stored      time.Time
+}
+
+func newDegrader(client *Client, options *DegradeOptions) *degrader {
+	opts := *options
+	if opts.StaleTTL <= 0 {
+		opts.StaleTTL = 10 * time.Minute
+	}
+	return &degrader{client: client, options: opts, stale: make(map[string]staleResponse)}
+}
+
+// wrap returns the writer for the downstream handler, a function to call once it
+// returns, and whether to run the handler at all
+func (d *degrader) wrap(w http.ResponseWriter, r *http.Request) (http.ResponseWriter, func(), bool) {
+	state := requestStateFromContext(r.Context())
+	degraded := state != nil && state.analysis != nil && state.analysis.Action == ActionDegrade
+	key := r.URL.RequestURI()
+
+	if !degraded {
+		if !d.options.ServeStale || r.Method != http.MethodGet || !shareable(r, state) {
+			return w, func() {}, true
+		}
+		// Remember good responses so degraded clients can be served stale copies
+		rec := &bufferingWriter{ResponseWriter: w, passThrough: true, limit: maxStaleBodySize}
+		return rec, func() { d.store(key, rec) }, true
+	}
+
+	d.client.log("🐌 Degrading response:", r.Method, r.URL.Path, state.analysis.RiskScore)
+	if !d.sleep(r) {
+		return w, func() {}, false
+	}
+
+	if d.options.ServeStale && r.Method == http.MethodGet {
+		if cached, ok := d.lookup(key); ok {
+			if cached.contentType != "" {
+				w.Header().Set("Content-Type", cached.contentType)
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write(cached.body)
+			return w, func() {}, false
+		}
+	}
+
+	if d.options.MaxItems > 0 {
+		rec := &bufferingWriter{ResponseWriter: w, limit: maxRewriteBodySize}
+		return rec, func() { d.truncate(rec) }, true
+	}
+	return w, func() {}, true
+}
+
+// shareable reports whether r's response may be replayed to other clients: stale copies
+// are keyed by URL alone, so personalized responses must never be stored
+func shareable(r *http.Request, state *requestState) bool {
+	if state != nil && state.event != nil && state.event.HasAuth {
+		return false
+	}
+	return r.Header.Get("Authorization") == "" && r.Header.Get("Cookie") == ""
+}
+
+// sleep adds the configured latency; it returns false if the client went away meanwhile
+func (d *degrader) sleep(r *http.Request) bool {
+	delay := d.options.Delay
+	if d.options.Jitter > 0 {
+		delay += time.Duration(rand.Int63n(int64(d.options.Jitter)))
+	}
+	if delay <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-r.Context().Done():
+		return false
+	}
+}
+
+func (d *degrader) store(key string, rec *bufferingWriter) {
+	if rec.status != http.StatusOK || rec.overflow {
+		return
+	}
+	if cacheControl := strings.ToLower(rec.Header().Get("Cache-Control")); strings.Contains(cacheControl, "private") ||
+		strings.Contains(cacheControl, "no-store") {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, exists := d.stale[key]; !exists && len(d.stale) >= maxStaleEntries {
+		// Evict expired entries; if none, drop an arbitrary one
+		now := time.Now()
+		for k, entry := range d.stale {
+			if now.Sub(entry.stored) > d.options.StaleTTL {
+				delete(d.stale, k)
+			}
+		}
+		for k := range d.stale {
+			if len(d.stale) < maxStaleEntries {
+				break
+			}
+			delete(d.stale, k)
+		}
+	}
+	d.stale[key] = staleResponse{
+		contentType: rec.Header().Get("Content-Type"),
+		body:        append([]byte(nil), rec.buf.Bytes()...),
+		stored:      time.Now(),
+	}
+}
+
+func (d *degrader) lookup(key string) (staleResponse, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	entry, ok := d.stale[key]
+	if !ok || time.Since(entry.stored) > d.options.StaleTTL {
+		return staleResponse{}, false
+	}
+	return entry, true
+}
+
+// truncate writes the buffered response with its JSON arrays cut to MaxItems
+func (d *degrader) truncate(rec *bufferingWriter) {
+	if rec.streaming {
+		return // Already written through
+	}
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	body := rec.buf.Bytes()
+	if rec.status == http.StatusOK && strings.Contains(rec.Header().Get("Content-Type"), "json") {
+		if reduced, ok := truncateJSONArrays(body, d.options.MaxItems); ok {
+			body = reduced
+		}
+	}
+	rec.Header().Del("Content-Length")
+	rec.ResponseWriter.WriteHeader(rec.status)
+	rec.ResponseWriter.Write(body)
+}
+
+// truncateJSONArrays cuts a top-level array, or the array fields of a top-level object,
+// to max items
+func truncateJSONArrays(body []byte, max int) ([]byte, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, false
+	}
+
+	switch v := value.(type) {
+	case []interface{}:
+		if len(v) > max {
+			value = v[:max]
+		}
+	case map[string]interface{}:
+		for key, field := range v {
+			if items, ok := field.([]interface{}); ok && len(items) > max {
+				v[key] = items[:max]
+			}
+		}
+	default:
+		return nil, false
+	}
+
+	reduced, err := json.Marshal(value)
+	if err != nil {
+		return nil, false
+	}
+	return reduced, true
+}
+
+// bufferingWriter buffers the response body. With passThrough it also writes through
+// to the client, keeping at most limit bytes. Streaming responses (see
+// isStreamingResponse), responses the handler flushes and, without passThrough,
+// responses larger than a non-zero limit are switched to writing through unbuffered,
+// with streaming set so the buffered result isn't used.
+type bufferingWriter struct {
+	http.ResponseWriter
+	passThrough bool
+	limit       int
+	status      int
+	buf         bytes.Buffer
+	overflow    bool
+	streaming   bool
+}
+
+func (b *bufferingWriter) WriteHeader(code int) {
+	if b.status != 0 {
+		return
+	}
+	b.status = code
+	if b.passThrough {
+		b.ResponseWriter.WriteHeader(code)
+	}
+	if isStreamingResponse(b.Header()) {
+		b.stream()
+	}
+}
+
+// stream writes out what was buffered and passes the rest of the response through
+func (b *bufferingWriter) stream() {
+	if b.streaming {
+		return
+	}
+	b.streaming = true
+	if !b.passThrough {
+		if b.status == 0 {
+			b.status = http.StatusOK
+		}
+		b.ResponseWriter.WriteHeader(b.status)
+		b.ResponseWriter.Write(b.buf.Bytes())
+	}
+	b.passThrough = true
+	b.overflow = true
+	b.buf.Reset()
+}
+
+func (b *bufferingWriter) Write(p []byte) (int, error) {
+	if b.status == 0 {
+		b.WriteHeader(http.StatusOK)
+	}
+	if !b.passThrough {
+		if b.limit <= 0 || b.buf.Len()+len(p) <= b.limit {
+			return b.buf.Write(p)
+		}
+		b.stream()
+	}
+	if !b.overflow {
+		if b.buf.Len()+len(p) > b.limit {
+			b.overflow = true
+			b.buf.Reset()
+		} else {
+			b.buf.Write(p)
+		}
+	}
+	return b.ResponseWriter.Write(p)
+}
+
+// Flush implements http.Flusher. Flushing means the handler is streaming, so the
+// response stops being buffered.
+func (b *bufferingWriter) Flush() {
+	b.stream()
+	if flusher, ok := b.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (b *bufferingWriter) Unwrap() http.ResponseWriter {
+	return b.ResponseWriter
+}
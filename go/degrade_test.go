@@ -0,0 +1,28 @@
+package guardial
+
+import "testing"
+
+func TestTruncateJSONArrays(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		max    int
+		want   string
+		wantOK bool
+	}{
+		{"top-level array", `[1,2,3,4]`, 2, `[1,2]`, true},
+		{"object fields", `{"items":[1,2,3],"total":3}`, 1, `{"items":[1],"total":3}`, true},
+		{"short array", `[1]`, 5, `[1]`, true},
+		{"large numbers kept", `[12345678901234567890,2]`, 1, `[12345678901234567890]`, true},
+		{"not JSON", `<html>`, 1, "", false},
+		{"truncated JSON", `[1,2`, 1, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := truncateJSONArrays([]byte(tt.body), tt.max)
+			if ok != tt.wantOK || (ok && string(got) != tt.want) {
+				t.Errorf("truncateJSONArrays(%s, %d) = %s, %t; want %s, %t", tt.body, tt.max, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
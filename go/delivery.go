@@ -0,0 +1,175 @@
+/**
+ * Guardial Go SDK Event Transports
+ * Pluggable delivery of events for deployments that don't call the API directly
+ */
+
+package guardial
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// ActionDelivered is reported for events sent through a Transport that returns no verdicts
+const ActionDelivered = "delivered"
+
+// Transport delivers events for analysis in place of the API's HTTP JSON endpoints, so
+// the middleware and detectors work in other topologies: a co-located sidecar, a message
+// queue, or an audit file on an air-gapped host. Implement it for anything else, such as
+// an in-house gRPC service.
+type Transport interface {
+	// Send delivers events and returns one verdict per event, in order. Transports that
+	// only record events return nil verdicts; the events are then allowed with Action
+	// "delivered".
+	Send(ctx context.Context, events []*SecurityEventRequest) ([]*SecurityEventResponse, error)
+
+	// Close releases the transport's resources. Client.Close calls it once pending
+	// async events have been delivered.
+	Close() error
+}
+
+// sendEvents delivers events through the configured Transport
+func (c *Client) sendEvents(ctx context.Context, events []*SecurityEventRequest) ([]*SecurityEventResponse, error) {
+	if c.anonymizer != nil {
+		anonymized := make([]*SecurityEventRequest, len(events))
+		for i, event := range events {
+			anonymized[i] = c.anonymize(event)
+		}
+		events = anonymized
+	}
+	results, err := c.transport.Send(ctx, events)
+	if err != nil {
+		return nil, err
+	}
+	if results == nil {
+		results = make([]*SecurityEventResponse, len(events))
+		for i := range results {
+			results[i] = &SecurityEventResponse{Allowed: true, Action: ActionDelivered}
+		}
+	}
+	if len(results) != len(events) {
+		return nil, fmt.Errorf("transport returned %d verdicts for %d events", len(results), len(events))
+	}
+	return results, nil
+}
+
+// fallbackToAPI reports whether events the sidecar failed on are sent to the API instead
+func (c *Client) fallbackToAPI() bool {
+	return c.config.EventTransport == nil && c.config.Sidecar != nil && c.config.Sidecar.FallbackToAPI
+}
+
+// NewSidecarTransport returns a Transport to an analysis sidecar listening on a unix
+// socket (see ServeSidecar). Config.Sidecar sets one up with optional API fallback.
+func NewSidecarTransport(config *SidecarConfig) Transport {
+	return newSidecarClient(config)
+}
+
+// PublishFunc publishes one serialized event to a message queue, keyed by source IP so
+// an address's events stay ordered within a partition
+type PublishFunc func(ctx context.Context, key string, value []byte) error
+
+type publishTransport struct {
+	publish PublishFunc
+}
+
+// NewPublishTransport returns a Transport that publishes each event as JSON through
+// publish, e.g. to a Kafka topic consumed by the analysis pipeline. Credential headers
+// are masked, since topics retain messages. It returns no verdicts, so requests are
+// never blocked inline.
+func NewPublishTransport(publish PublishFunc) Transport {
+	return &publishTransport{publish: publish}
+}
+
+// Send implements Transport
+func (t *publishTransport) Send(ctx context.Context, events []*SecurityEventRequest) ([]*SecurityEventResponse, error) {
+	for _, event := range events {
+		value, err := json.Marshal(redactCredentials(event))
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal event: %w", err)
+		}
+		if err := t.publish(ctx, event.SourceIP, value); err != nil {
+			return nil, fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	return nil, nil
+}
+
+// Close implements Transport
+func (t *publishTransport) Close() error {
+	return nil
+}
+
+// FileTransport appends events as JSON lines to a file, for audit trails on hosts that
+// can't reach the API. Credential headers are masked before they are written. It
+// returns no verdicts.
+type FileTransport struct {
+	mu     sync.Mutex
+	file   *os.File
+	cipher *EventCipher
+}
+
+// NewFileTransport opens path for appending, creating it if needed
+func NewFileTransport(path string) (*FileTransport, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit file: %w", err)
+	}
+	return &FileTransport{file: file}, nil
+}
+
+// NewEncryptedFileTransport is NewFileTransport with each event sealed by cipher under
+// its CustomerID's key. Lines are base64 records; decode one and pass it to
+// EventCipher.OpenEvent to read it back.
+func NewEncryptedFileTransport(path string, cipher *EventCipher) (*FileTransport, error) {
+	transport, err := NewFileTransport(path)
+	if err != nil {
+		return nil, err
+	}
+	transport.cipher = cipher
+	return transport, nil
+}
+
+// Send implements Transport. Events without a Timestamp are stamped with the time
+// they were written.
+func (t *FileTransport) Send(ctx context.Context, events []*SecurityEventRequest) ([]*SecurityEventResponse, error) {
+	var buf []byte
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, event := range events {
+		line := *redactCredentials(event)
+		if line.Timestamp == "" {
+			line.Timestamp = now
+		}
+		if t.cipher != nil {
+			sealed, err := t.cipher.SealEvent(ctx, &line)
+			if err != nil {
+				return nil, fmt.Errorf("failed to seal event: %w", err)
+			}
+			buf = append(append(buf, base64.StdEncoding.EncodeToString(sealed)...), '\n')
+			continue
+		}
+		data, err := json.Marshal(&line)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal event: %w", err)
+		}
+		buf = append(append(buf, data...), '\n')
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, err := t.file.Write(buf); err != nil {
+		return nil, fmt.Errorf("failed to write audit file: %w", err)
+	}
+	return nil, nil
+}
+
+// Close implements Transport
+func (t *FileTransport) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.file.Close()
+}
@@ -0,0 +1,148 @@
+package guardial
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTransportsRedactCredentials(t *testing.T) {
+	event := &SecurityEventRequest{Method: "GET", Path: "/api/orders", Headers: map[string]string{
+		"Authorization": "Bearer secret",
+		"Cookie":        "session=secret",
+		"X-Request-Id":  "abc",
+	}}
+
+	tests := []struct {
+		name string
+		send func(t *testing.T) []byte // Sends event and returns what was stored
+	}{
+		{"publish", func(t *testing.T) []byte {
+			var published []byte
+			transport := NewPublishTransport(func(ctx context.Context, key string, value []byte) error {
+				published = value
+				return nil
+			})
+			if _, err := transport.Send(context.Background(), []*SecurityEventRequest{event}); err != nil {
+				t.Fatalf("Send() = %v", err)
+			}
+			return published
+		}},
+		{"file", func(t *testing.T) []byte {
+			path := filepath.Join(t.TempDir(), "events.jsonl")
+			transport, err := NewFileTransport(path)
+			if err != nil {
+				t.Fatalf("NewFileTransport() = %v", err)
+			}
+			defer transport.Close()
+			if _, err := transport.Send(context.Background(), []*SecurityEventRequest{event}); err != nil {
+				t.Fatalf("Send() = %v", err)
+			}
+			data, _ := os.ReadFile(path)
+			return data
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stored SecurityEventRequest
+			if err := json.Unmarshal(tt.send(t), &stored); err != nil {
+				t.Fatalf("stored event isn't JSON: %v", err)
+			}
+			for name, want := range map[string]string{"Authorization": redactedValue, "Cookie": redactedValue, "X-Request-Id": "abc"} {
+				if got := stored.Headers[name]; got != want {
+					t.Errorf("stored header %s = %q, want %q", name, got, want)
+				}
+			}
+			if event.Headers["Authorization"] != "Bearer secret" {
+				t.Error("Send() changed the caller's event")
+			}
+		})
+	}
+}
+
+func TestEncryptedFileTransport(t *testing.T) {
+	cipher := NewEventCipher(StaticKeys{
+		"acme":   bytes.Repeat([]byte{1}, 32),
+		"globex": bytes.Repeat([]byte{2}, 32),
+	})
+	events := []*SecurityEventRequest{
+		{CustomerID: "acme", Method: "POST", Path: "/login", RequestBody: "password=hunter2", Headers: map[string]string{"Authorization": "Bearer secret"}},
+		{CustomerID: "globex", Method: "GET", Path: "/api/orders", Timestamp: "2024-01-01T00:00:00Z"},
+	}
+
+	path := filepath.Join(t.TempDir(), "events.log")
+	transport, err := NewEncryptedFileTransport(path, cipher)
+	if err != nil {
+		t.Fatalf("NewEncryptedFileTransport() = %v", err)
+	}
+	if _, err := transport.Send(context.Background(), events); err != nil {
+		t.Fatalf("Send() = %v", err)
+	}
+	if err := transport.Close(); err != nil {
+		t.Fatalf("Close() = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() = %v", err)
+	}
+	for _, plaintext := range []string{"hunter2", "/login", "Bearer"} {
+		if bytes.Contains(data, []byte(plaintext)) {
+			t.Errorf("file contains plaintext %q", plaintext)
+		}
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if len(lines) != len(events) {
+		t.Fatalf("file has %d lines, want %d", len(lines), len(events))
+	}
+
+	tests := []struct {
+		name       string
+		line       int
+		tenant     string
+		wantPath   string
+		wantHeader string
+		wantErr    bool
+	}{
+		{"first tenant", 0, "acme", "/login", redactedValue, false},
+		{"second tenant", 1, "globex", "/api/orders", "", false},
+		{"wrong tenant", 0, "globex", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sealed, err := base64.StdEncoding.DecodeString(lines[tt.line])
+			if err != nil {
+				t.Fatalf("line %d isn't base64: %v", tt.line, err)
+			}
+			event, err := cipher.OpenEvent(context.Background(), tt.tenant, sealed)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("OpenEvent() succeeded under the wrong tenant")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("OpenEvent() = %v", err)
+			}
+			if event.Path != tt.wantPath || event.CustomerID != tt.tenant {
+				t.Errorf("OpenEvent() = %s %s, want %s %s", event.CustomerID, event.Path, tt.tenant, tt.wantPath)
+			}
+			if got := event.Headers["Authorization"]; got != tt.wantHeader {
+				t.Errorf("Authorization = %q, want %q", got, tt.wantHeader)
+			}
+			if event.Timestamp == "" {
+				t.Error("event wasn't stamped with a Timestamp")
+			}
+		})
+	}
+}
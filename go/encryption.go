@@ -0,0 +1,148 @@
+/**
+ * Guardial Go SDK At-Rest Encryption
+ * Per-tenant AES-GCM sealing for security events persisted locally
+ */
+
+package guardial
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// KeyProvider supplies per-tenant data-encryption keys, typically backed by a secrets manager.
+// An empty keyID asks for the tenant's current key; the returned ID is stored with the record
+// so older records stay readable after rotation.
+type KeyProvider interface {
+	Key(ctx context.Context, tenant, keyID string) (id string, key []byte, err error)
+}
+
+// StaticKeys is a KeyProvider holding one fixed 16, 24, or 32 byte key per tenant
+type StaticKeys map[string][]byte
+
+// Key implements KeyProvider
+func (s StaticKeys) Key(ctx context.Context, tenant, keyID string) (string, []byte, error) {
+	key, ok := s[tenant]
+	if !ok {
+		return "", nil, fmt.Errorf("no encryption key for tenant %q", tenant)
+	}
+	return "static", key, nil
+}
+
+// ErrInvalidCiphertext is returned when a sealed record is malformed or fails authentication
+var ErrInvalidCiphertext = errors.New("invalid or tampered ciphertext")
+
+const sealedFormatVersion = 1
+
+// EventCipher encrypts locally persisted security data with AES-GCM using per-tenant keys.
+// NewEncryptedFileTransport and ForensicOptions use it so events never reach disk in
+// plaintext.
+type EventCipher struct {
+	keys KeyProvider
+}
+
+// NewEventCipher creates a cipher that fetches keys from keys
+func NewEventCipher(keys KeyProvider) *EventCipher {
+	return &EventCipher{keys: keys}
+}
+
+// Seal encrypts plaintext for tenant. The tenant and key ID are authenticated with the
+// ciphertext so a record can't be replayed under another tenant.
+func (c *EventCipher) Seal(ctx context.Context, tenant string, plaintext []byte) ([]byte, error) {
+	keyID, key, err := c.keys.Key(ctx, tenant, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get encryption key: %w", err)
+	}
+	if len(keyID) > 255 {
+		return nil, fmt.Errorf("key ID too long: %d bytes", len(keyID))
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	// Layout: version | len(keyID) | keyID | nonce | ciphertext
+	out := make([]byte, 0, 2+len(keyID)+len(nonce)+len(plaintext)+aead.Overhead())
+	out = append(out, sealedFormatVersion, byte(len(keyID)))
+	out = append(out, keyID...)
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, plaintext, sealedAAD(tenant, keyID)), nil
+}
+
+// Open decrypts a record produced by Seal for tenant
+func (c *EventCipher) Open(ctx context.Context, tenant string, sealed []byte) ([]byte, error) {
+	if len(sealed) < 2 || sealed[0] != sealedFormatVersion {
+		return nil, ErrInvalidCiphertext
+	}
+	idLen := int(sealed[1])
+	if len(sealed) < 2+idLen {
+		return nil, ErrInvalidCiphertext
+	}
+	keyID := string(sealed[2 : 2+idLen])
+
+	_, key, err := c.keys.Key(ctx, tenant, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get encryption key: %w", err)
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	rest := sealed[2+idLen:]
+	if len(rest) < aead.NonceSize() {
+		return nil, ErrInvalidCiphertext
+	}
+	plaintext, err := aead.Open(nil, rest[:aead.NonceSize()], rest[aead.NonceSize():], sealedAAD(tenant, keyID))
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+	return plaintext, nil
+}
+
+// SealEvent encrypts event under its CustomerID's key
+func (c *EventCipher) SealEvent(ctx context.Context, event *SecurityEventRequest) ([]byte, error) {
+	jsonData, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event: %w", err)
+	}
+	return c.Seal(ctx, event.CustomerID, jsonData)
+}
+
+// OpenEvent decrypts an event sealed by SealEvent
+func (c *EventCipher) OpenEvent(ctx context.Context, tenant string, sealed []byte) (*SecurityEventRequest, error) {
+	plaintext, err := c.Open(ctx, tenant, sealed)
+	if err != nil {
+		return nil, err
+	}
+
+	var event SecurityEventRequest
+	if err := json.Unmarshal(plaintext, &event); err != nil {
+		return nil, fmt.Errorf("failed to parse event: %w", err)
+	}
+	return &event, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+func sealedAAD(tenant, keyID string) []byte {
+	return []byte("guardial:" + tenant + ":" + keyID)
+}
@@ -0,0 +1,95 @@
+/**
+ * Guardial Go SDK Errors
+ * Sentinel and typed errors for API failures
+ */
+
+package guardial
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sentinel errors, matched with errors.Is
+var (
+	ErrBlocked           = errors.New("guardial: request blocked")
+	ErrUnauthorized      = errors.New("guardial: unauthorized")
+	ErrRateLimited       = errors.New("guardial: rate limited")
+	ErrQuotaExceeded     = errors.New("guardial: plan quota exceeded")
+	ErrCircuitOpen       = errors.New("guardial: circuit breaker open, API temporarily skipped")
+	ErrNotObserved       = errors.New("guardial: change not observed in verdicts before timeout")
+	ErrUnsafePattern     = errors.New("guardial: unsafe regular expression")
+	ErrMessageRefused    = errors.New("guardial: websocket message refused, connection closed")
+	ErrAnalysisSaturated = errors.New("guardial: analysis concurrency limit reached")
+)
+
+// APIError is returned when the Guardial API answers with a non-200 status
+type APIError struct {
+	StatusCode int           // HTTP status code
+	RequestID  string        // X-Request-ID echoed by the API, if any
+	Message    string        // Error message extracted from the body, if any
+	Body       string        // Raw response body
+	RetryAfter time.Duration // Retry-After hint, if the API sent one
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error: %d - %s", e.StatusCode, e.Body)
+}
+
+// Is maps status codes onto the sentinel errors
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrQuotaExceeded:
+		return e.StatusCode == http.StatusPaymentRequired
+	}
+	return false
+}
+
+// newAPIError builds an APIError from a non-200 response
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-ID"),
+		Body:       string(body),
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+
+	var payload struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &payload) == nil {
+		apiErr.Message = payload.Error
+		if apiErr.Message == "" {
+			apiErr.Message = payload.Message
+		}
+	}
+	return apiErr
+}
+
+// BlockedError is returned when Guardial's analysis blocks a request
+type BlockedError struct {
+	Analysis *SecurityEventResponse
+}
+
+// Error implements the error interface
+func (e *BlockedError) Error() string {
+	return fmt.Sprintf("request blocked by Guardial: %s", strings.Join(e.Analysis.RiskReasons, ", "))
+}
+
+// Is makes errors.Is(err, ErrBlocked) true for blocked requests
+func (e *BlockedError) Is(target error) bool {
+	return target == ErrBlocked
+}
@@ -7,7 +7,7 @@ import (
 	"os"
 
 	"github.com/gin-gonic/gin"
-	"github.com/guardial/go-sdk"
+	guardial "github.com/divyankvijayvergiya/guardial-sdk"
 )
 
 func main() {
@@ -0,0 +1,112 @@
+/**
+ * Guardial Go SDK Path Exclusions
+ * Prefix, glob, and regex exclusion patterns with per-method filters
+ */
+
+package guardial
+
+import (
+	"regexp"
+	"strings"
+)
+
+// pathExclusion is a compiled ExcludePaths entry
+type pathExclusion struct {
+	methods []string // Empty matches every method
+	except  bool     // methods lists the methods that are analyzed, not skipped
+	prefix  string   // Plain entries match by prefix
+	pattern *SafeRegexp
+}
+
+// compileExclusions parses ExcludePaths. Each entry is an optional method filter and a
+// path pattern separated by a space:
+//
+//	/health                 prefix match, every method
+//	GET,HEAD /static/**     glob; "*" matches within a segment, "**" across segments
+//	!POST,PUT,PATCH /api/** every method except POST, PUT, and PATCH
+//	~^/v[0-9]+/metrics$     regular expression
+//
+// Patterns compile through CompileSafeRegexp; unsafe ones are ignored like invalid ones.
+func (m *middleware) compileExclusions() {
+	for _, entry := range m.options.ExcludePaths {
+		exclusion := pathExclusion{}
+		pattern := strings.TrimSpace(entry)
+		if methods, rest, found := strings.Cut(pattern, " "); found && !strings.HasPrefix(pattern, "/") && !strings.HasPrefix(pattern, "~") {
+			exclusion.except = strings.HasPrefix(methods, "!")
+			for _, method := range strings.Split(strings.TrimPrefix(methods, "!"), ",") {
+				if method = strings.TrimSpace(method); method != "" {
+					exclusion.methods = append(exclusion.methods, strings.ToUpper(method))
+				}
+			}
+			pattern = strings.TrimSpace(rest)
+		}
+
+		switch {
+		case strings.HasPrefix(pattern, "~"):
+			compiled, err := CompileSafeRegexp(pattern[1:])
+			if err != nil {
+				m.client.log("Ignoring invalid exclude pattern:", entry, err)
+				continue
+			}
+			exclusion.pattern = compiled
+		case strings.ContainsAny(pattern, "*?["):
+			compiled, err := CompileSafeRegexp(globPattern(pattern))
+			if err != nil {
+				m.client.log("Ignoring invalid exclude pattern:", entry, err)
+				continue
+			}
+			exclusion.pattern = compiled
+		default:
+			exclusion.prefix = pattern
+		}
+		m.exclusions = append(m.exclusions, exclusion)
+	}
+}
+
+// matches reports whether the exclusion skips a request
+func (e *pathExclusion) matches(method, path string) bool {
+	if len(e.methods) > 0 && containsString(e.methods, strings.ToUpper(method)) == e.except {
+		return false
+	}
+	if e.pattern != nil {
+		return e.pattern.MatchString(path)
+	}
+	return strings.HasPrefix(path, e.prefix)
+}
+
+// globPattern translates a path glob into an anchored regular expression. "*" and "?"
+// don't cross "/", "**" does, and a trailing "/**" also matches the directory itself.
+func globPattern(glob string) string {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; {
+		case glob[i:] == "/**":
+			b.WriteString("(/.*)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(glob[i:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return b.String()
+}
@@ -0,0 +1,33 @@
+package guardial
+
+import "testing"
+
+func TestExcluded(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		want   bool
+	}{
+		{"GET", "/health", true},
+		{"GET", "/healthz", true},
+		{"GET", "/static/app.js", true},
+		{"GET", "//static/app.js", true},
+		{"GET", "/static/../admin/users", false},
+		{"GET", "/health/../admin", false},
+		{"GET", "/assets/img/../../admin", false},
+		{"GET", "/assets/img/logo.png", true},
+		{"POST", "/static/app.js", false},
+		{"GET", "/v1/metrics", true},
+		{"GET", "/v1/metrics/../../admin", false},
+	}
+	options := DefaultMiddlewareOptions()
+	options.ExcludePaths = []string{"/health", "GET,HEAD /static/**", "/assets/*/*.png", "~^/v[0-9]+/metrics$"}
+	m := newMiddleware(NewClient(&Config{APIKey: "test"}), options)
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			if got := m.excluded(tt.method, tt.path); got != tt.want {
+				t.Errorf("excluded(%q, %q) = %t, want %t", tt.method, tt.path, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+/**
+ * Guardial Go SDK Endpoint Failover
+ * Primary + fallback endpoints with automatic failover and recovery probes
+ */
+
+package guardial
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// FailoverConfig configures failover between Config.Endpoint and Config.FallbackEndpoints
+type FailoverConfig struct {
+	FailureThreshold int           `json:"failure_threshold"` // Consecutive failures before an endpoint is skipped (default: 3)
+	ProbeInterval    time.Duration `json:"probe_interval"`    // How often unhealthy endpoints are health-checked (default: 30s)
+}
+
+// DefaultFailoverConfig skips an endpoint after 3 consecutive failures and probes every 30s
+func DefaultFailoverConfig() *FailoverConfig {
+	return &FailoverConfig{
+		FailureThreshold: 3,
+		ProbeInterval:    30 * time.Second,
+	}
+}
+
+type endpointState struct {
+	url       string
+	failures  int
+	unhealthy bool
+}
+
+// endpointPool picks the highest-priority healthy endpoint
+type endpointPool struct {
+	mu        sync.Mutex
+	config    FailoverConfig
+	endpoints []*endpointState // Priority order: primary first
+}
+
+func newEndpointPool(primary string, fallbacks []string, config *FailoverConfig) *endpointPool {
+	cfg := *DefaultFailoverConfig()
+	if config != nil {
+		if config.FailureThreshold > 0 {
+			cfg.FailureThreshold = config.FailureThreshold
+		}
+		if config.ProbeInterval > 0 {
+			cfg.ProbeInterval = config.ProbeInterval
+		}
+	}
+
+	pool := &endpointPool{config: cfg}
+	for _, url := range append([]string{primary}, fallbacks...) {
+		pool.endpoints = append(pool.endpoints, &endpointState{url: url})
+	}
+	return pool
+}
+
+// current returns the first healthy endpoint, or the primary if none are healthy
+func (p *endpointPool) current() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, endpoint := range p.endpoints {
+		if !endpoint.unhealthy {
+			return endpoint.url
+		}
+	}
+	return p.endpoints[0].url
+}
+
+// record feeds the outcome of a call to url into the pool and returns true if url
+// just became unhealthy
+func (p *endpointPool) record(url string, err error) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, endpoint := range p.endpoints {
+		if endpoint.url != url {
+			continue
+		}
+		if !countsAsFailure(err) {
+			endpoint.failures = 0
+			endpoint.unhealthy = false
+			return false
+		}
+		endpoint.failures++
+		if !endpoint.unhealthy && endpoint.failures >= p.config.FailureThreshold {
+			endpoint.unhealthy = true
+			return true
+		}
+	}
+	return false
+}
+
+// unhealthyEndpoints returns the endpoints that need a recovery probe
+func (p *endpointPool) unhealthyEndpoints() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var urls []string
+	for _, endpoint := range p.endpoints {
+		if endpoint.unhealthy {
+			urls = append(urls, endpoint.url)
+		}
+	}
+	return urls
+}
+
+// endpoint returns the API endpoint to call right now
+func (c *Client) endpoint() string {
+	if c.endpoints == nil {
+		return c.config.Endpoint
+	}
+	return c.endpoints.current()
+}
+
+// recordEndpoint tracks the health of endpoint after a call
+func (c *Client) recordEndpoint(endpoint string, err error) {
+	if c.endpoints == nil {
+		return
+	}
+	if c.endpoints.record(endpoint, err) {
+		c.warn("⚠️ Endpoint unhealthy, failing over from", endpoint, "to", c.endpoints.current())
+	}
+}
+
+// runRecoveryProbes periodically health-checks unhealthy endpoints so traffic moves
+// back to the primary once it recovers
+func (c *Client) runRecoveryProbes() {
+	ticker := time.NewTicker(c.endpoints.config.ProbeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-c.closing:
+			return
+		}
+		for _, endpoint := range c.endpoints.unhealthyEndpoints() {
+			ctx, cancel := context.WithTimeout(c.ctx, c.config.Timeout)
+			_, err := c.healthCheck(ctx, endpoint)
+			cancel()
+			if err == nil {
+				c.endpoints.record(endpoint, nil)
+				c.log("Endpoint recovered:", endpoint)
+			}
+		}
+	}
+}
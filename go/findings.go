@@ -0,0 +1,52 @@
+/**
+ * Guardial Go SDK Local Findings
+ * Detections raised inside the application (sink guards, file-system guard, ...)
+ */
+
+package guardial
+
+import (
+	"context"
+
+	"github.com/divyankvijayvergiya/guardial-sdk/types"
+)
+
+// Finding represents a detection raised locally by the SDK rather than by the analysis API
+type Finding = types.Finding
+
+// ReportFinding sends a locally raised finding to Guardial
+func (c *Client) ReportFinding(ctx context.Context, finding *Finding) error {
+	// Set customer and session if not provided
+	if finding.CustomerID == "" {
+		finding.CustomerID = c.config.CustomerID
+	}
+	if finding.SessionID == "" {
+		finding.SessionID = c.sessionID
+	}
+	if taxonomy, ok := LookupTaxonomy(finding.Type); ok {
+		if len(finding.CWEIDs) == 0 {
+			finding.CWEIDs = taxonomy.CWEIDs
+		}
+		if len(finding.AttackTechniques) == 0 {
+			finding.AttackTechniques = taxonomy.AttackTechniques
+		}
+	}
+
+	if err := c.postJSON(ctx, "/api/findings", c.anonymizeFinding(finding), nil); err != nil {
+		return err
+	}
+
+	c.log("Finding reported:", finding.Type, finding.Severity)
+	return nil
+}
+
+// ReportFindingAsync reports finding in the background so the caller isn't held up by
+// the API. Close waits for the report; findings raised once Close has begun are dropped.
+func (c *Client) ReportFindingAsync(ctx context.Context, finding *Finding) {
+	ctx = context.WithoutCancel(ctx)
+	c.goBackground(func() {
+		if err := c.ReportFinding(ctx, finding); err != nil {
+			c.log("Finding report failed:", finding.Type, err)
+		}
+	})
+}
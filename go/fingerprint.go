@@ -0,0 +1,139 @@
+/**
+ * Guardial Go SDK Client Fingerprints
+ * Expected-caller allowlists for machine-to-machine routes
+ */
+
+package guardial
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// ClientFingerprint describes the only callers expected on a machine-to-machine route.
+// Each non-empty field is checked; any deviation is reported as a HIGH finding.
+type ClientFingerprint struct {
+	PathPrefix      string   `json:"path_prefix"`
+	SANs            []string `json:"sans"`             // mTLS client certificate SAN (DNS, URI, or email), any of
+	UserAgents      []string `json:"user_agents"`      // User-Agent prefixes, any of
+	RequiredHeaders []string `json:"required_headers"` // Headers every call must carry
+	SourceCIDRs     []string `json:"source_cidrs"`     // Peer networks, any of; X-Forwarded-For counts only through TrustedProxies
+	Block           bool     `json:"block"`            // Reject deviating requests instead of only reporting
+}
+
+// compiledFingerprint is a ClientFingerprint with its networks parsed
+type compiledFingerprint struct {
+	ClientFingerprint
+	networks []*net.IPNet
+}
+
+func (m *middleware) compileFingerprints() {
+	for _, fingerprint := range m.options.ClientFingerprints {
+		compiled := compiledFingerprint{ClientFingerprint: fingerprint}
+		for _, cidr := range fingerprint.SourceCIDRs {
+			_, network, err := net.ParseCIDR(cidr)
+			if err != nil {
+				m.client.log("Ignoring invalid client fingerprint CIDR:", cidr, err)
+				continue
+			}
+			compiled.networks = append(compiled.networks, network)
+		}
+		m.fingerprints = append(m.fingerprints, compiled)
+	}
+}
+
+// checkFingerprint reports requests to machine-to-machine routes whose caller doesn't
+// match the expected fingerprint and returns false if the request must be rejected
+func (m *middleware) checkFingerprint(r *http.Request, event *SecurityEventRequest) bool {
+	for _, fingerprint := range m.fingerprints {
+		if !strings.HasPrefix(r.URL.Path, fingerprint.PathPrefix) {
+			continue
+		}
+
+		// SourceIP comes from X-Forwarded-For, which the caller can forge
+		deviations := fingerprint.deviations(r, event.PeerIP)
+		if len(deviations) == 0 {
+			return true
+		}
+
+		m.client.log("⚠️ Unexpected client on machine-to-machine route:", r.URL.Path, deviations)
+		finding := &Finding{
+			Type:     "client_fingerprint_mismatch",
+			Title:    "Unexpected client on machine-to-machine route",
+			Severity: "HIGH",
+			Evidence: strings.Join(deviations, "; "),
+			Path:     r.URL.Path,
+			SourceIP: event.PeerIP,
+			Metadata: map[string]string{
+				"method":     r.Method,
+				"user_agent": r.UserAgent(),
+			},
+		}
+		m.client.ReportFindingAsync(r.Context(), finding)
+		return !fingerprint.Block
+	}
+	return true
+}
+
+// deviations lists how r differs from the fingerprint
+func (f compiledFingerprint) deviations(r *http.Request, peerIP string) []string {
+	var deviations []string
+
+	if len(f.SANs) > 0 && !matchesSAN(r, f.SANs) {
+		deviations = append(deviations, "client certificate SAN not allowed")
+	}
+	if len(f.UserAgents) > 0 && !hasAnyPrefix(r.UserAgent(), f.UserAgents) {
+		deviations = append(deviations, "unexpected user agent "+truncate(r.UserAgent(), 128))
+	}
+	for _, header := range f.RequiredHeaders {
+		if r.Header.Get(header) == "" {
+			deviations = append(deviations, "missing header "+header)
+		}
+	}
+	if len(f.networks) > 0 {
+		ip := net.ParseIP(strings.TrimSpace(peerIP))
+		allowed := false
+		for _, network := range f.networks {
+			if ip != nil && network.Contains(ip) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			deviations = append(deviations, "source "+peerIP+" outside allowed networks")
+		}
+	}
+	return deviations
+}
+
+// matchesSAN reports whether the verified client certificate carries one of sans
+func matchesSAN(r *http.Request, sans []string) bool {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return false
+	}
+	cert := r.TLS.PeerCertificates[0]
+
+	names := append([]string{}, cert.DNSNames...)
+	names = append(names, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		names = append(names, uri.String())
+	}
+	for _, name := range names {
+		for _, san := range sans {
+			if strings.EqualFold(name, san) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
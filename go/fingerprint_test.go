@@ -0,0 +1,39 @@
+package guardial
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFingerprintSourceCIDRs(t *testing.T) {
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor string
+		wantStatus   int
+	}{
+		{"peer inside", "10.1.2.3:4321", "", http.StatusOK},
+		{"peer outside", "198.51.100.9:4321", "", http.StatusForbidden},
+		{"spoofed forwarded IP", "198.51.100.9:4321", "10.1.2.3", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, verdictAPI(allowedVerdict))
+			options := DefaultMiddlewareOptions()
+			options.ClientFingerprints = []ClientFingerprint{{PathPrefix: "/internal/", SourceCIDRs: []string{"10.0.0.0/8"}, Block: true}}
+			handler := StandardMiddleware(client, options)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+			req := httptest.NewRequest("POST", "/internal/payouts", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
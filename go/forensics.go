@@ -0,0 +1,211 @@
+/**
+ * Guardial Go SDK Forensic Capture
+ * Sampled, size-capped, encrypted capture of blocked and CRITICAL requests
+ */
+
+package guardial
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ForensicOptions configures full-request capture for blocked or CRITICAL events
+type ForensicOptions struct {
+	Store      ForensicStore // Where sealed captures are written
+	Cipher     *EventCipher  // Encrypts captures with the tenant's key; required
+	MaxBytes   int           // Cap on captured headers + body (default: 64KB)
+	SampleRate float64       // Fraction of qualifying events captured (default: 1.0)
+}
+
+// ForensicCapture is the decrypted content of a forensic record
+type ForensicCapture struct {
+	EventID    string      `json:"event_id"`
+	CapturedAt time.Time   `json:"captured_at"`
+	Method     string      `json:"method"`
+	URL        string      `json:"url"`
+	Proto      string      `json:"proto"`
+	RemoteAddr string      `json:"remote_addr"`
+	Headers    http.Header `json:"headers"`
+	Body       []byte      `json:"body"`
+	Truncated  bool        `json:"truncated"`
+	RiskScore  int         `json:"risk_score"`
+	Action     string      `json:"action"`
+}
+
+// ForensicRecord is an encrypted capture as handed to a ForensicStore
+type ForensicRecord struct {
+	EventID    string
+	Tenant     string
+	CapturedAt time.Time
+	Sealed     []byte
+}
+
+// ForensicStore persists encrypted forensic records
+type ForensicStore interface {
+	Put(ctx context.Context, record *ForensicRecord) error
+}
+
+// OpenForensicRecord decrypts a sealed capture for incident response
+func OpenForensicRecord(ctx context.Context, cipher *EventCipher, tenant string, sealed []byte) (*ForensicCapture, error) {
+	plaintext, err := cipher.Open(ctx, tenant, sealed)
+	if err != nil {
+		return nil, err
+	}
+
+	var capture ForensicCapture
+	if err := json.Unmarshal(plaintext, &capture); err != nil {
+		return nil, fmt.Errorf("failed to parse capture: %w", err)
+	}
+	return &capture, nil
+}
+
+// FileForensicStore writes one file per record and enforces retention limits
+type FileForensicStore struct {
+	Dir        string
+	Retention  time.Duration // Delete records older than this (0 keeps them forever)
+	MaxRecords int           // Keep at most this many records (0 means unlimited)
+}
+
+// NewFileForensicStore creates dir if needed and returns a store writing into it
+func NewFileForensicStore(dir string, retention time.Duration, maxRecords int) (*FileForensicStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create forensic store: %w", err)
+	}
+	return &FileForensicStore{Dir: dir, Retention: retention, MaxRecords: maxRecords}, nil
+}
+
+// Put implements ForensicStore
+func (s *FileForensicStore) Put(ctx context.Context, record *ForensicRecord) error {
+	name := fmt.Sprintf("%d_%s.gfr", record.CapturedAt.UnixNano(), sanitizeFileComponent(record.EventID))
+	if err := os.WriteFile(filepath.Join(s.Dir, name), record.Sealed, 0o600); err != nil {
+		return fmt.Errorf("failed to write forensic record: %w", err)
+	}
+	return s.prune()
+}
+
+// prune removes records beyond the retention window or record limit
+func (s *FileForensicStore) prune() error {
+	entries, err := filepath.Glob(filepath.Join(s.Dir, "*.gfr"))
+	if err != nil {
+		return err
+	}
+	// Names start with the capture time, so lexical order is oldest first
+	sort.Strings(entries)
+
+	cutoff := time.Time{}
+	if s.Retention > 0 {
+		cutoff = time.Now().Add(-s.Retention)
+	}
+	for i, entry := range entries {
+		tooMany := s.MaxRecords > 0 && len(entries)-i > s.MaxRecords
+		tooOld := false
+		if !cutoff.IsZero() {
+			if info, err := os.Stat(entry); err == nil && info.ModTime().Before(cutoff) {
+				tooOld = true
+			}
+		}
+		if tooMany || tooOld {
+			os.Remove(entry)
+		}
+	}
+	return nil
+}
+
+// captureForensics seals and stores the raw request when the verdict warrants it
+func (m *middleware) captureForensics(r *http.Request, body []byte, analysis *SecurityEventResponse) {
+	options := m.options.Forensics
+	if options == nil || options.Store == nil || options.Cipher == nil {
+		return
+	}
+	if analysis.Allowed && !hasCriticalDetection(analysis) {
+		return
+	}
+
+	sampleRate := options.SampleRate
+	if sampleRate <= 0 {
+		sampleRate = 1
+	}
+	if sampleRate < 1 && rand.Float64() >= sampleRate {
+		return
+	}
+
+	maxBytes := options.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = 64 * 1024
+	}
+
+	capture := &ForensicCapture{
+		EventID:    analysis.EventID,
+		CapturedAt: time.Now().UTC(),
+		Method:     r.Method,
+		URL:        r.URL.String(),
+		Proto:      r.Proto,
+		RemoteAddr: r.RemoteAddr,
+		Headers:    r.Header.Clone(),
+		RiskScore:  analysis.RiskScore,
+		Action:     analysis.Action,
+	}
+
+	// Headers count against the cap first; the body gets what is left
+	headerBytes := 0
+	for key, values := range capture.Headers {
+		for _, value := range values {
+			headerBytes += len(key) + len(value)
+		}
+	}
+	remaining := maxBytes - headerBytes
+	if remaining < 0 {
+		remaining = 0
+	}
+	if len(body) > remaining {
+		body = body[:remaining]
+		capture.Truncated = true
+	}
+	capture.Body = body
+
+	tenant := m.client.config.CustomerID
+	ctx := context.WithoutCancel(r.Context())
+	go func() {
+		plaintext, err := json.Marshal(capture)
+		if err != nil {
+			m.client.log("Forensic capture failed:", err)
+			return
+		}
+		sealed, err := options.Cipher.Seal(ctx, tenant, plaintext)
+		if err != nil {
+			m.client.log("Forensic capture failed:", err)
+			return
+		}
+		record := &ForensicRecord{EventID: capture.EventID, Tenant: tenant, CapturedAt: capture.CapturedAt, Sealed: sealed}
+		if err := options.Store.Put(ctx, record); err != nil {
+			m.client.log("Forensic capture failed:", err)
+		}
+	}()
+}
+
+func hasCriticalDetection(analysis *SecurityEventResponse) bool {
+	for _, detection := range analysis.OwaspDetected {
+		if strings.EqualFold(detection.Severity, "CRITICAL") {
+			return true
+		}
+	}
+	return false
+}
+
+func sanitizeFileComponent(s string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, s)
+}
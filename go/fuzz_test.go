@@ -0,0 +1,265 @@
+/**
+ * Guardial Go SDK Fuzz Targets
+ * Parsers and local detectors handle attacker-controlled input and must never panic or hang
+ */
+
+package guardial
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fuzzInputTimeout bounds the work done for one input; anything slower is treated as a hang
+const fuzzInputTimeout = time.Second
+
+// addCorpus seeds f with every line of every file under $GUARDIAL_FUZZ_CORPUS, so the
+// targets can be run against large payload lists (e.g. SecLists) as well as their
+// built-in seeds:
+//
+//	GUARDIAL_FUZZ_CORPUS=~/SecLists/Fuzzing go test -run 'Fuzz' .
+func addCorpus(f *testing.F, seed func(line string)) {
+	root := os.Getenv("GUARDIAL_FUZZ_CORPUS")
+	if root == "" {
+		return
+	}
+	err := filepath.WalkDir(root, func(path string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxLogLineSize)
+		for scanner.Scan() {
+			seed(scanner.Text())
+		}
+		return nil
+	})
+	if err != nil {
+		f.Fatalf("failed to load fuzz corpus: %v", err)
+	}
+}
+
+// withinTimeout fails t if fn doesn't return within fuzzInputTimeout
+func withinTimeout(t *testing.T, fn func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn()
+	}()
+	select {
+	case <-done:
+	case <-time.After(fuzzInputTimeout):
+		t.Fatalf("input took longer than %v", fuzzInputTimeout)
+	}
+}
+
+// attackSeeds are payloads every string target starts from
+var attackSeeds = []string{
+	"",
+	"' OR 1=1 --",
+	`"><script>alert(1)</script>`,
+	"../../../../etc/passwd",
+	"%2e%2e%2f%2e%2e%2fetc%2fpasswd",
+	"${jndi:ldap://x/a}",
+	"{{7*7}}",
+	"\x00\xff\xfe",
+	strings.Repeat("[", 10000),
+	strings.Repeat(`"\`, 5000),
+}
+
+func FuzzParseAccessLogLine(f *testing.F) {
+	for _, seed := range attackSeeds {
+		f.Add(seed)
+	}
+	f.Add(`203.0.113.7 - - [10/Oct/2024:13:55:36 -0700] "GET /search?q=%27%20OR%201=1 HTTP/1.1" 200 512 "-" "sqlmap/1.7"`)
+	f.Add(`https 2024-10-10T13:55:36.123456Z app/my-alb/50dc6c495c0c9188 203.0.113.7:2817 10.0.0.1:80 0.000 0.001 0.000 200 200 34 366 "GET https://example.com:443/login?user=admin HTTP/1.1" "curl/8.0" - -`)
+	f.Add(`{"time":"2024-10-10T13:55:36Z","method":"post","uri":"/api/users?id=1","remote_addr":"203.0.113.7:5000","body":"{\"name\":\"x\"}"}`)
+	addCorpus(f, func(line string) { f.Add(line) })
+
+	f.Fuzz(func(t *testing.T, line string) {
+		withinTimeout(t, func() {
+			for _, format := range []LogFormat{LogFormatCombined, LogFormatALB, LogFormatJSONLines} {
+				event, err := ParseAccessLogLine(line, format)
+				if err == nil && event == nil {
+					t.Errorf("%s: no event and no error", format)
+				}
+			}
+		})
+	})
+}
+
+func FuzzParseGraphQLRequest(f *testing.F) {
+	for _, seed := range attackSeeds {
+		f.Add(seed)
+	}
+	f.Add(`{"query":"query GetUser($id: ID!) { user(id: $id) { name } }","variables":{"id":"1"}}`)
+	f.Add(`[{"query":"{ __schema { types { name } } }"},{"query":"mutation { del(s: \"\"\"}\"\"\") }"}]`)
+	f.Add(`{"query":"# comment\nsubscription S { s }","operationName":"S"}`)
+	addCorpus(f, func(line string) { f.Add(line) })
+
+	f.Fuzz(func(t *testing.T, input string) {
+		withinTimeout(t, func() {
+			operations, _ := ParseGraphQLRequest("POST", "", []byte(input))
+			operations = append(operations, NewGraphQLOperation(input, "", nil))
+			for _, operation := range operations {
+				switch operation.OperationType {
+				case GraphQLQuery, GraphQLMutation, GraphQLSubscription:
+				default:
+					t.Errorf("unexpected operation type %q", operation.OperationType)
+				}
+			}
+		})
+	})
+}
+
+func FuzzNestingDepth(f *testing.F) {
+	for _, seed := range attackSeeds {
+		f.Add(seed)
+	}
+	f.Add(`{"a":[{"b":[1,2,{"c":"]]]"}]}]}`)
+	addCorpus(f, func(line string) { f.Add(line) })
+
+	f.Fuzz(func(t *testing.T, body string) {
+		withinTimeout(t, func() {
+			depth := nestingDepth(body)
+			if depth < 0 || depth > strings.Count(body, "{")+strings.Count(body, "[") {
+				t.Errorf("nesting depth %d out of range", depth)
+			}
+		})
+	})
+}
+
+func FuzzTruncateJSONArrays(f *testing.F) {
+	f.Add([]byte(`[1,2,3,4,5]`), uint8(2))
+	f.Add([]byte(`{"items":[{"a":1},{"a":2}],"total":2}`), uint8(1))
+	f.Add([]byte(strings.Repeat("[", 5000)), uint8(0))
+	addCorpus(f, func(line string) { f.Add([]byte(line), uint8(3)) })
+
+	f.Fuzz(func(t *testing.T, body []byte, max uint8) {
+		withinTimeout(t, func() {
+			truncated, ok := truncateJSONArrays(body, int(max))
+			if ok && !json.Valid(truncated) {
+				t.Errorf("truncated body is not valid JSON: %q", truncated)
+			}
+		})
+	})
+}
+
+func FuzzReadSidecarFrame(f *testing.F) {
+	var frame bytes.Buffer
+	writeSidecarFrame(&frame, sidecarFrameAnalyze, []byte(`{"method":"GET","path":"/"}`))
+	f.Add(frame.Bytes())
+	f.Add([]byte{0, 0, 0, 0, 1})
+	f.Add([]byte{0xff, 0xff, 0xff, 0xff, 1})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		withinTimeout(t, func() {
+			_, payload, err := readSidecarFrame(bytes.NewReader(data))
+			if err == nil && len(payload) > len(data) {
+				t.Errorf("payload of %d bytes from %d bytes of input", len(payload), len(data))
+			}
+		})
+	})
+}
+
+func FuzzGossipVerify(f *testing.F) {
+	f.Add([]byte(`{"ts":0,"sig":"","decision":{"ip":"203.0.113.7"}}`))
+	f.Add([]byte(`{"decision":null}`))
+	g := &GossipPropagator{secret: "fuzz", clockSkew: time.Minute}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		withinTimeout(t, func() {
+			if _, ok := g.verify(data); ok {
+				t.Errorf("unsigned message verified: %q", data)
+			}
+		})
+	})
+}
+
+func FuzzSampleRoute(f *testing.F) {
+	for _, seed := range attackSeeds {
+		f.Add(seed)
+	}
+	f.Add("/users/42/orders/9f8e7d6c5b4a39281706f5e4d3c2b1a0")
+	f.Add("/files/550e8400-e29b-41d4-a716-446655440000")
+	addCorpus(f, func(line string) { f.Add(line) })
+
+	f.Fuzz(func(t *testing.T, path string) {
+		withinTimeout(t, func() {
+			route := sampleRoute(&SecurityEventRequest{Path: path})
+			if strings.Count(route, "/") != strings.Count(path, "/") {
+				t.Errorf("route %q changed the segments of %q", route, path)
+			}
+			if again := sampleRoute(&SecurityEventRequest{Path: route}); again != route {
+				t.Errorf("normalization is not idempotent: %q -> %q", route, again)
+			}
+		})
+	})
+}
+
+func FuzzRequestSignature(f *testing.F) {
+	f.Add("GET", "/search", "q=1", "")
+	f.Add("post", "/login", "", `{"user":"admin' --"}`)
+
+	f.Fuzz(func(t *testing.T, method, path, query, body string) {
+		withinTimeout(t, func() {
+			event := &SecurityEventRequest{Method: method, Path: path, QueryParams: query, RequestBody: body}
+			if RequestSignature(event) != RequestSignature(event) {
+				t.Error("signature is not deterministic")
+			}
+			if Fingerprint(event) != Fingerprint(event) {
+				t.Error("fingerprint is not deterministic")
+			}
+		})
+	})
+}
+
+func FuzzCheckSink(f *testing.F) {
+	for _, seed := range attackSeeds {
+		f.Add("SELECT * FROM users WHERE name = '"+seed+"'", seed)
+	}
+	addCorpus(f, func(line string) { f.Add("<p>"+line+"</p>", line) })
+
+	f.Fuzz(func(t *testing.T, value, tainted string) {
+		withinTimeout(t, func() {
+			ctx := WithTaintTracking(context.Background())
+			Taint(ctx, tainted)
+			for _, sink := range []SinkType{SinkSQL, SinkExec, SinkTemplate, SinkHTTP} {
+				CheckSink(ctx, value, sink)
+			}
+		})
+	})
+}
+
+func FuzzTemplateContexts(f *testing.F) {
+	for _, seed := range attackSeeds {
+		f.Add(`<a href="`+seed+`">`+seed+`</a><script>var x = "`+seed+`";</script>`, seed)
+	}
+	f.Add("<!-- <script> --><style>", "x")
+	addCorpus(f, func(line string) { f.Add("<div title='"+line+"'>"+line+"</div>", line) })
+
+	f.Fuzz(func(t *testing.T, doc, fragment string) {
+		withinTimeout(t, func() {
+			if contexts := scanHTMLContexts(doc); len(contexts) != len(doc) {
+				t.Errorf("%d contexts for %d bytes", len(contexts), len(doc))
+			}
+			if fragment != "" {
+				findTemplateTaint(doc, []string{fragment})
+			}
+		})
+	})
+}
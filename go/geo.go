@@ -0,0 +1,104 @@
+/**
+ * Guardial Go SDK Geo Rules
+ * Local enforcement rules keyed on country code and ASN
+ */
+
+package guardial
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Geo rule actions
+const (
+	GeoActionBlock     = "block"     // Block matching requests outright
+	GeoActionChallenge = "challenge" // Deny with Action "challenge" so the app can step up
+	GeoActionThreshold = "threshold" // Block matching requests at a lower risk score
+)
+
+// ActionChallenge is reported for requests denied by a challenge rule
+const ActionChallenge = "challenge"
+
+// GeoRule applies an action to requests from the listed countries or networks
+type GeoRule struct {
+	Countries      []string `json:"countries"`       // ISO 3166-1 alpha-2, e.g. "KP"
+	ASNs           []uint32 `json:"asns"`            // Autonomous system numbers
+	Action         string   `json:"action"`          // block, challenge, or threshold
+	BlockThreshold int      `json:"block_threshold"` // Risk score to block at for "threshold"
+}
+
+// GeoResolver enriches events with the country and network of a source IP
+type GeoResolver interface {
+	Resolve(ip string) (countryCode string, asn uint32, err error)
+}
+
+// enrichGeo fills in CountryCode and ASN from the configured resolver when not provided
+func (c *Client) enrichGeo(event *SecurityEventRequest) {
+	if c.config.GeoResolver == nil || event.SourceIP == "" {
+		return
+	}
+	if event.CountryCode != "" && event.ASN != 0 {
+		return
+	}
+	country, asn, err := c.config.GeoResolver.Resolve(event.SourceIP)
+	if err != nil {
+		c.log("Geo lookup failed:", err)
+		return
+	}
+	if event.CountryCode == "" {
+		event.CountryCode = country
+	}
+	if event.ASN == 0 {
+		event.ASN = asn
+	}
+}
+
+func (r GeoRule) matches(event *SecurityEventRequest) bool {
+	for _, country := range r.Countries {
+		if event.CountryCode != "" && strings.EqualFold(country, event.CountryCode) {
+			return true
+		}
+	}
+	for _, asn := range r.ASNs {
+		if event.ASN != 0 && asn == event.ASN {
+			return true
+		}
+	}
+	return false
+}
+
+// applyGeoRules enforces the first geo rule matching event on analysis
+func applyGeoRules(rules []GeoRule, event *SecurityEventRequest, analysis *SecurityEventResponse) {
+	for _, rule := range rules {
+		if !rule.matches(event) {
+			continue
+		}
+		origin := event.CountryCode
+		if event.ASN != 0 {
+			origin = fmt.Sprintf("%s AS%d", origin, event.ASN)
+		}
+		origin = strings.TrimSpace(origin)
+
+		switch rule.Action {
+		case GeoActionBlock:
+			analysis.Allowed = false
+			analysis.Action = "block"
+			analysis.AddReason(ReasonGeoBlocked, ReasonCategoryGeo, 0, "geo rule: blocked origin "+origin)
+		case GeoActionChallenge:
+			if analysis.Allowed {
+				analysis.Allowed = false
+				analysis.Action = ActionChallenge
+				analysis.AddReason(ReasonGeoChallenge, ReasonCategoryGeo, 0, "geo rule: challenge origin "+origin)
+			}
+		case GeoActionThreshold:
+			if analysis.Allowed && rule.BlockThreshold > 0 && analysis.RiskScore >= rule.BlockThreshold {
+				analysis.Allowed = false
+				analysis.Action = "block"
+				analysis.AddReason(ReasonGeoThreshold, ReasonCategoryGeo, 0,
+					fmt.Sprintf("geo rule: risk score %d at or above threshold %d for origin %s", analysis.RiskScore, rule.BlockThreshold, origin))
+			}
+		}
+		return
+	}
+}
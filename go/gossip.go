@@ -0,0 +1,167 @@
+/**
+ * Guardial Go SDK Block Gossip
+ * UDP gossip channel for propagating IP blocks without a shared store
+ */
+
+package guardial
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// maxGossipMessageSize bounds a single gossip datagram
+const maxGossipMessageSize = 8 * 1024
+
+// GossipPropagator is a BlockPropagator that sends decisions to a fixed set of peers over
+// UDP. Messages are signed with a shared secret so only sibling instances can inject
+// blocks. Delivery is best effort; each peer usually hears about a block within
+// milliseconds.
+type GossipPropagator struct {
+	conn      *net.UDPConn
+	peers     []*net.UDPAddr
+	secret    string
+	clockSkew time.Duration
+
+	mu   sync.Mutex
+	seen map[string]time.Time // Signatures of accepted messages, until they go stale
+}
+
+// gossipMessage is the signed envelope on the wire
+type gossipMessage struct {
+	Timestamp int64           `json:"ts"`
+	Signature string          `json:"sig"`
+	Decision  json.RawMessage `json:"decision"`
+}
+
+// NewGossipPropagator listens on listenAddr (e.g. ":7946") and gossips to peers
+// ("10.0.1.12:7946"). Every instance must share secret.
+func NewGossipPropagator(listenAddr string, peers []string, secret string) (*GossipPropagator, error) {
+	if secret == "" {
+		return nil, fmt.Errorf("gossip secret is required")
+	}
+
+	addr, err := net.ResolveUDPAddr("udp", listenAddr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gossip listen address: %w", err)
+	}
+	g := &GossipPropagator{secret: secret, clockSkew: time.Minute, seen: make(map[string]time.Time)}
+	for _, peer := range peers {
+		peerAddr, err := net.ResolveUDPAddr("udp", peer)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gossip peer %q: %w", peer, err)
+		}
+		g.peers = append(g.peers, peerAddr)
+	}
+
+	g.conn, err = net.ListenUDP("udp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for gossip: %w", err)
+	}
+	return g, nil
+}
+
+// Addr returns the local gossip address
+func (g *GossipPropagator) Addr() net.Addr {
+	return g.conn.LocalAddr()
+}
+
+// Publish sends decision to every peer
+func (g *GossipPropagator) Publish(ctx context.Context, decision BlockDecision) error {
+	body, err := json.Marshal(decision)
+	if err != nil {
+		return fmt.Errorf("failed to marshal block decision: %w", err)
+	}
+	timestamp := time.Now().Unix()
+	message, err := json.Marshal(gossipMessage{
+		Timestamp: timestamp,
+		Signature: Sign(g.secret, timestamp, body),
+		Decision:  body,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal gossip message: %w", err)
+	}
+
+	var lastErr error
+	for _, peer := range g.peers {
+		if _, err := g.conn.WriteToUDP(message, peer); err != nil {
+			lastErr = fmt.Errorf("failed to gossip to %s: %w", peer, err)
+		}
+	}
+	return lastErr
+}
+
+// Subscribe delivers signed decisions from peers until ctx is done or Close is called
+func (g *GossipPropagator) Subscribe(ctx context.Context, deliver func(BlockDecision)) error {
+	stop := context.AfterFunc(ctx, func() { g.conn.SetReadDeadline(time.Now()) })
+	defer stop()
+
+	buf := make([]byte, maxGossipMessageSize)
+	for {
+		n, _, err := g.conn.ReadFromUDP(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("failed to read gossip: %w", err)
+		}
+
+		decision, ok := g.verify(buf[:n])
+		if ok {
+			deliver(decision)
+		}
+	}
+}
+
+// verify checks a message's signature and freshness; forged and replayed messages are dropped
+func (g *GossipPropagator) verify(data []byte) (BlockDecision, bool) {
+	var message gossipMessage
+	if err := json.Unmarshal(data, &message); err != nil {
+		return BlockDecision{}, false
+	}
+	if diff := time.Since(time.Unix(message.Timestamp, 0)); diff > g.clockSkew || diff < -g.clockSkew {
+		return BlockDecision{}, false
+	}
+	expected := Sign(g.secret, message.Timestamp, message.Decision)
+	if !hmac.Equal([]byte(message.Signature), []byte(expected)) {
+		return BlockDecision{}, false
+	}
+
+	var decision BlockDecision
+	if err := json.Unmarshal(message.Decision, &decision); err != nil {
+		return BlockDecision{}, false
+	}
+	if !g.first(message.Signature, time.Unix(message.Timestamp, 0).Add(g.clockSkew)) {
+		return BlockDecision{}, false
+	}
+	return decision, true
+}
+
+// first records signature until stale and reports whether it wasn't seen before. Stale
+// messages are refused on their timestamp, so signatures are forgotten once stale.
+func (g *GossipPropagator) first(signature string, stale time.Time) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now()
+	if _, ok := g.seen[signature]; ok {
+		return false
+	}
+	for seen, expires := range g.seen {
+		if now.After(expires) {
+			delete(g.seen, seen)
+		}
+	}
+	g.seen[signature] = stale
+	return true
+}
+
+// Close stops listening for gossip
+func (g *GossipPropagator) Close() error {
+	return g.conn.Close()
+}
@@ -0,0 +1,122 @@
+/**
+ * Guardial Go SDK Quota Governor
+ * Shifts low-priority routes to local-only analysis when the plan quota would run out
+ */
+
+package guardial
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// ActionLocal marks verdicts produced without the API because the quota governor kept
+// the event local or a maintenance window was in effect. Only local checks (policy, geo
+// rules, cost budgets) were applied.
+const ActionLocal = "local"
+
+// QuotaGovernorConfig configures the quota governor. It projects the period's request
+// usage from the usage API; when the projection exceeds the plan limit, events for
+// LowPriorityPaths are analyzed locally instead of spending quota, so high-priority
+// routes keep full coverage until the period resets.
+type QuotaGovernorConfig struct {
+	LowPriorityPaths []string      `json:"low_priority_paths"` // Path prefixes shifted to local-only analysis
+	Headroom         float64       `json:"headroom"`           // Govern when projected usage exceeds this fraction of the limit (default: 1.0)
+	RefreshInterval  time.Duration `json:"refresh_interval"`   // How often usage is polled (default: 15m)
+}
+
+// minProjectionWindow keeps early-period projections from extrapolating a few busy hours
+const minProjectionWindow = 24 * time.Hour
+
+type quotaGovernor struct {
+	config QuotaGovernorConfig
+
+	mu        sync.Mutex
+	governing bool
+}
+
+func newQuotaGovernor(config *QuotaGovernorConfig) *quotaGovernor {
+	cfg := *config
+	if cfg.Headroom <= 0 {
+		cfg.Headroom = 1.0
+	}
+	if cfg.RefreshInterval <= 0 {
+		cfg.RefreshInterval = 15 * time.Minute
+	}
+	return &quotaGovernor{config: cfg}
+}
+
+// runQuotaGovernor polls usage until the client closes
+func (c *Client) runQuotaGovernor() {
+	ticker := time.NewTicker(c.governor.config.RefreshInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := c.GetUsage(c.ctx); err != nil {
+			c.warn("⚠️ Quota governor failed to fetch usage:", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-c.closing:
+			return
+		}
+	}
+}
+
+// observeQuota re-evaluates the governor against usage
+func (c *Client) observeQuota(usage Usage) {
+	g := c.governor
+	if g == nil || usage.RequestLimit <= 0 {
+		return
+	}
+	projected := projectUsage(usage, time.Now())
+	governing := projected > float64(usage.RequestLimit)*g.config.Headroom
+
+	g.mu.Lock()
+	changed := governing != g.governing
+	g.governing = governing
+	g.mu.Unlock()
+
+	if changed && governing {
+		c.log("⚡ Quota governor: projected usage", int64(projected), "exceeds plan limit", usage.RequestLimit,
+			"- low-priority routes now analyzed locally")
+	} else if changed {
+		c.log("Quota governor: usage back within plan limit, full analysis resumed")
+	}
+}
+
+// projectUsage extrapolates the requests used so far to the end of the period
+func projectUsage(usage Usage, now time.Time) float64 {
+	used := float64(usage.RequestsUsed)
+	if usage.ResetsAt.IsZero() {
+		return used
+	}
+	start := usage.PeriodStart
+	if start.IsZero() {
+		start = usage.ResetsAt.AddDate(0, -1, 0)
+	}
+	elapsed, total := now.Sub(start), usage.ResetsAt.Sub(start)
+	if elapsed >= total || total <= 0 {
+		return used
+	}
+	if elapsed < minProjectionWindow {
+		elapsed = minProjectionWindow
+	}
+	return used * float64(total) / float64(elapsed)
+}
+
+// localOnly reports whether event should skip the API because the governor is engaged
+func (g *quotaGovernor) localOnly(event *SecurityEventRequest) bool {
+	g.mu.Lock()
+	governing := g.governing
+	g.mu.Unlock()
+	if !governing {
+		return false
+	}
+	for _, prefix := range g.config.LowPriorityPaths {
+		if strings.HasPrefix(event.Path, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,285 @@
+/**
+ * Guardial Go SDK GraphQL Analysis
+ * Extracts GraphQL operations from requests and refuses blocked operations
+ */
+
+package guardial
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/divyankvijayvergiya/guardial-sdk/types"
+)
+
+// GraphQL operation types
+const (
+	GraphQLQuery        = types.GraphQLQuery
+	GraphQLMutation     = types.GraphQLMutation
+	GraphQLSubscription = types.GraphQLSubscription
+)
+
+// GraphQLOperation is one GraphQL operation carried by a request
+type GraphQLOperation = types.GraphQLOperation
+
+// GraphQLOptions makes the middleware parse GraphQL requests into structured fields and
+// refuse operations before analysis
+type GraphQLOptions struct {
+	Paths []string // Endpoints serving GraphQL (default: "/graphql")
+
+	BlockedOperations  []string // Operation names to refuse, e.g. "IntrospectionQuery"
+	BlockedTypes       []string // Operation types to refuse, e.g. GraphQLMutation on a read-only endpoint
+	BlockIntrospection bool     // Refuse operations selecting __schema or __type
+}
+
+// graphqlRequest is a GraphQL-over-HTTP request body
+type graphqlRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// ParseGraphQLRequest extracts the operations from a GraphQL-over-HTTP request: a JSON
+// body (a single request or a batch) or, for GET, the query, operationName, and
+// variables parameters
+func ParseGraphQLRequest(method, rawQuery string, body []byte) ([]*GraphQLOperation, error) {
+	var requests []graphqlRequest
+	if method == http.MethodGet {
+		params, err := url.ParseQuery(rawQuery)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse GraphQL query parameters: %w", err)
+		}
+		request := graphqlRequest{Query: params.Get("query"), OperationName: params.Get("operationName")}
+		if variables := params.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &request.Variables); err != nil {
+				return nil, fmt.Errorf("failed to parse GraphQL variables: %w", err)
+			}
+		}
+		requests = append(requests, request)
+	} else {
+		trimmed := strings.TrimSpace(string(body))
+		if strings.HasPrefix(trimmed, "[") {
+			if err := json.Unmarshal(body, &requests); err != nil {
+				return nil, fmt.Errorf("failed to parse GraphQL batch: %w", err)
+			}
+		} else {
+			var request graphqlRequest
+			if err := json.Unmarshal(body, &request); err != nil {
+				return nil, fmt.Errorf("failed to parse GraphQL request: %w", err)
+			}
+			requests = append(requests, request)
+		}
+	}
+
+	operations := make([]*GraphQLOperation, 0, len(requests))
+	for _, request := range requests {
+		if request.Query == "" {
+			return nil, fmt.Errorf("GraphQL request has no query")
+		}
+		operations = append(operations, NewGraphQLOperation(request.Query, request.OperationName, request.Variables))
+	}
+	return operations, nil
+}
+
+// NewGraphQLOperation describes the operation a request with query, operationName, and
+// variables executes, for adapters that receive GraphQL requests already decoded
+func NewGraphQLOperation(query, operationName string, variables map[string]interface{}) *GraphQLOperation {
+	document := scanGraphQL(query)
+	operation := &GraphQLOperation{
+		OperationName: operationName,
+		OperationType: GraphQLQuery,
+		Query:         query,
+		Variables:     variables,
+		Introspection: document.introspection,
+	}
+	if definition, ok := document.operation(operationName); ok {
+		operation.OperationName = definition.name
+		operation.OperationType = definition.kind
+	}
+	return operation
+}
+
+// checkGraphQL fills in event.GraphQL for requests to a GraphQL endpoint and returns
+// the rejection for a refused operation. Requests that aren't valid GraphQL are
+// analyzed as plain requests.
+func (m *middleware) checkGraphQL(event *SecurityEventRequest) *Rejection {
+	options := m.options.GraphQL
+	if options == nil {
+		return nil
+	}
+	if event.GraphQL == nil {
+		if !m.graphqlPath(event.Path) {
+			return nil
+		}
+		operations, err := ParseGraphQLRequest(event.Method, event.QueryParams, []byte(event.RequestBody))
+		if err != nil {
+			m.client.log("⚠️ Could not parse GraphQL request:", err)
+			return nil
+		}
+		event.GraphQL = operations
+	}
+
+	for _, operation := range event.GraphQL {
+		if reason := options.refusal(operation); reason != "" {
+			m.client.log("🚫 GraphQL operation refused:", operation.OperationType, operation.OperationName, reason)
+			return &Rejection{Status: http.StatusForbidden, Message: blockedMessage, Category: ReasonCategoryGraphQL}
+		}
+	}
+	return nil
+}
+
+func (m *middleware) graphqlPath(path string) bool {
+	paths := m.options.GraphQL.Paths
+	if len(paths) == 0 {
+		return path == "/graphql"
+	}
+	for _, p := range paths {
+		if path == p {
+			return true
+		}
+	}
+	return false
+}
+
+// refusal returns why operation is refused, or "" if it may proceed
+func (o *GraphQLOptions) refusal(operation *GraphQLOperation) string {
+	if o.BlockIntrospection && operation.Introspection {
+		return "introspection"
+	}
+	for _, name := range o.BlockedOperations {
+		if operation.OperationName == name {
+			return "blocked operation"
+		}
+	}
+	for _, kind := range o.BlockedTypes {
+		if operation.OperationType == kind {
+			return "blocked operation type"
+		}
+	}
+	return ""
+}
+
+// graphqlDefinition is an operation defined in a GraphQL document
+type graphqlDefinition struct {
+	kind string
+	name string
+}
+
+// graphqlDocument is what scanGraphQL finds in a document
+type graphqlDocument struct {
+	operations    []graphqlDefinition
+	introspection bool
+}
+
+// operation returns the operation a request with operationName executes
+func (d graphqlDocument) operation(operationName string) (graphqlDefinition, bool) {
+	for _, definition := range d.operations {
+		if operationName == "" || definition.name == operationName {
+			return definition, true
+		}
+	}
+	return graphqlDefinition{}, false
+}
+
+// scanGraphQL finds the operation definitions in a GraphQL document and whether it
+// selects introspection fields. It tokenizes just enough to skip strings and comments;
+// it does not validate the document.
+func scanGraphQL(query string) graphqlDocument {
+	var document graphqlDocument
+	depth := 0
+	inDefinition := false // Inside a definition at depth 0 (its header or body)
+	expectName := false   // The next name at depth 0 names the current operation
+
+	for i := 0; i < len(query); {
+		ch := query[i]
+		switch {
+		case ch == '#':
+			for i < len(query) && query[i] != '\n' {
+				i++
+			}
+		case ch == '"':
+			i = skipGraphQLString(query, i)
+			expectName = false
+		case isGraphQLNameStart(ch):
+			start := i
+			for i < len(query) && isGraphQLNameChar(query[i]) {
+				i++
+			}
+			name := query[start:i]
+			switch {
+			case depth > 0:
+				if name == "__schema" || name == "__type" {
+					document.introspection = true
+				}
+			case !inDefinition:
+				inDefinition = true
+				if name == GraphQLQuery || name == GraphQLMutation || name == GraphQLSubscription {
+					document.operations = append(document.operations, graphqlDefinition{kind: name})
+					expectName = true
+				}
+			case expectName:
+				document.operations[len(document.operations)-1].name = name
+				expectName = false
+			}
+		case ch == '{':
+			if depth == 0 && !inDefinition {
+				// Shorthand query: "{ field }"
+				document.operations = append(document.operations, graphqlDefinition{kind: GraphQLQuery})
+				inDefinition = true
+			}
+			depth++
+			expectName = false
+			i++
+		case ch == '}':
+			if depth > 0 {
+				depth--
+			}
+			if depth == 0 {
+				inDefinition = false
+			}
+			i++
+		default:
+			if ch != ' ' && ch != '\t' && ch != '\n' && ch != '\r' && ch != ',' {
+				expectName = false
+			}
+			i++
+		}
+	}
+	return document
+}
+
+// skipGraphQLString returns the index just past the string literal starting at i
+func skipGraphQLString(query string, i int) int {
+	if strings.HasPrefix(query[i:], `"""`) {
+		for j := i + 3; j < len(query); j++ {
+			if query[j] == '\\' && strings.HasPrefix(query[j:], `\"""`) {
+				j += 3
+				continue
+			}
+			if strings.HasPrefix(query[j:], `"""`) {
+				return j + 3
+			}
+		}
+		return len(query)
+	}
+	for j := i + 1; j < len(query); j++ {
+		switch query[j] {
+		case '\\':
+			j++
+		case '"', '\n':
+			return j + 1
+		}
+	}
+	return len(query)
+}
+
+func isGraphQLNameStart(ch byte) bool {
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+func isGraphQLNameChar(ch byte) bool {
+	return isGraphQLNameStart(ch) || (ch >= '0' && ch <= '9')
+}
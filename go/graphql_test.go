@@ -0,0 +1,39 @@
+package guardial
+
+import "testing"
+
+func TestParseGraphQLRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		rawQuery string
+		body     string
+		wantErr  bool
+		wantOps  []string // Operation names and types
+	}{
+		{"single", "POST", "", `{"query":"query Orders { orders { id } }","operationName":"Orders"}`, false, []string{"Orders query"}},
+		{"batch", "POST", "", `[{"query":"query A { a }","operationName":"A"},{"query":"mutation B { b }","operationName":"B"}]`, false, []string{"A query", "B mutation"}},
+		{"GET parameters", "GET", "query=query+Me+%7B+me+%7D&operationName=Me", "", false, []string{"Me query"}},
+		{"not JSON", "POST", "", `{"query":`, true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operations, err := ParseGraphQLRequest(tt.method, tt.rawQuery, []byte(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseGraphQLRequest() error = %v, want error %t", err, tt.wantErr)
+			}
+			var names []string
+			for _, operation := range operations {
+				names = append(names, operation.OperationName+" "+operation.OperationType)
+			}
+			if len(names) != len(tt.wantOps) {
+				t.Fatalf("operations = %v, want %v", names, tt.wantOps)
+			}
+			for i := range names {
+				if names[i] != tt.wantOps[i] {
+					t.Errorf("operations = %v, want %v", names, tt.wantOps)
+				}
+			}
+		})
+	}
+}
@@ -14,13 +14,19 @@ package guardial
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/divyankvijayvergiya/guardial-sdk/types"
 )
 
 // Config holds the Guardial SDK configuration
@@ -30,6 +36,125 @@ type Config struct {
 	CustomerID string        `json:"customer_id"`
 	Debug      bool          `json:"debug"`
 	Timeout    time.Duration `json:"timeout"`
+	Retry      *RetryPolicy  `json:"retry,omitempty"` // nil disables retries
+
+	// SecondaryAPIKey is used when the primary key is rejected with 401, for
+	// zero-downtime key rotation
+	SecondaryAPIKey string `json:"secondary_api_key,omitempty"`
+
+	Signing *SigningConfig `json:"signing,omitempty"` // nil sends unsigned requests
+
+	// WireEncoding serializes event and prompt payloads as EncodingJSON (default) or
+	// EncodingMsgpack; backends that don't support msgpack fall back to JSON
+	WireEncoding string `json:"wire_encoding,omitempty"`
+
+	// CompressionThreshold gzips request bodies at least this large (default: 4KB, -1 disables)
+	CompressionThreshold int `json:"compression_threshold"`
+
+	// SchemaVersion pins the events schema for older self-hosted backends (0: newest)
+	SchemaVersion int `json:"schema_version,omitempty"`
+
+	// IDGenerator returns the EventID of each event before it is sent, so it can be
+	// logged and correlated before the verdict arrives (default: NewULID). IDs must be
+	// unique; the API echoes them back in SecurityEventResponse.EventID.
+	IDGenerator func() string `json:"-"`
+
+	// TLSConfig customizes the connection to the Guardial API: client certificates for
+	// mTLS, a private CA bundle, or a minimum TLS version. See LoadClientTLS. It can't be
+	// applied to a Transport that isn't an *http.Transport; Validate reports that.
+	TLSConfig *tls.Config `json:"-"`
+
+	// ProxyURL sends API calls through this proxy instead of HTTPS_PROXY/NO_PROXY. If it
+	// doesn't parse, API calls fail instead of bypassing the proxy.
+	ProxyURL string `json:"proxy_url,omitempty"`
+
+	// Transport replaces the base RoundTripper for API calls (ProxyURL and
+	// TransportOptions are then ignored)
+	Transport http.RoundTripper `json:"-"`
+
+	// TransportOptions tunes connection pooling and dial timeouts; nil keeps net/http defaults
+	TransportOptions *TransportOptions `json:"transport_options,omitempty"`
+
+	FallbackEndpoints []string        `json:"fallback_endpoints,omitempty"` // Tried in order when Endpoint is unhealthy
+	Failover          *FailoverConfig `json:"failover,omitempty"`           // nil uses DefaultFailoverConfig
+
+	// AnalysisTimeout bounds inline analysis calls (AnalyzeEvent, PromptGuard), including
+	// retries, separately from Timeout. 0 uses Timeout alone.
+	AnalysisTimeout time.Duration `json:"analysis_timeout"`
+
+	CircuitBreaker *CircuitBreakerConfig `json:"circuit_breaker,omitempty"` // nil disables the breaker
+
+	VerdictCache *VerdictCacheConfig `json:"verdict_cache,omitempty"` // nil disables the verdict cache
+
+	IPRisk *IPRiskConfig `json:"ip_risk,omitempty"` // Per-IP verdict summaries for IPRisk; nil uses the defaults
+
+	// CoalesceRequests shares one API call among concurrent requests with the same signature
+	CoalesceRequests bool `json:"coalesce_requests"`
+
+	// AsyncMode makes AnalyzeEvent enqueue events and return immediately; a background
+	// goroutine ships them. Verdicts are never enforced in this mode.
+	AsyncMode      bool `json:"async_mode"`
+	AsyncQueueSize int  `json:"async_queue_size"` // Bounded queue size (default: 1000)
+
+	BatchSize          int           `json:"batch_size"`           // Max events per batch call (default: 100)
+	BatchFlushInterval time.Duration `json:"batch_flush_interval"` // Async mode flushes partial batches this often (default: 1s)
+
+	// PromptBatchConcurrency bounds the prompts PromptGuardBatch screens at once (default: 8)
+	PromptBatchConcurrency int `json:"prompt_batch_concurrency"`
+
+	// SeverityRules recalibrate detection severities before enforcement and reporting
+	SeverityRules []SeverityRule `json:"severity_rules,omitempty"`
+
+	Policy *PolicySchedule `json:"policy,omitempty"` // nil enforces the API's verdicts as-is
+
+	// PolicySyncInterval polls the API for the policy schedule managed in the dashboard
+	// this often, replacing Policy once fetched; 0 enforces Policy as configured
+	PolicySyncInterval time.Duration `json:"policy_sync_interval"`
+
+	GeoResolver GeoResolver `json:"-"` // Fills in CountryCode and ASN from the source IP
+
+	// TrustedProxies lists the load balancers and proxies in front of the service, as
+	// IPs or CIDRs. Behind one of them, the client address is the right-most
+	// X-Forwarded-For hop that isn't a trusted proxy; otherwise it is the peer
+	// address, and X-Forwarded-For only informs analysis.
+	TrustedProxies []string `json:"trusted_proxies,omitempty"`
+
+	// Anonymization truncates IPs and pseudonymizes user identifiers in events by origin
+	// jurisdiction before they are sent; nil sends events unchanged
+	Anonymization *AnonymizationConfig `json:"anonymization,omitempty"`
+
+	Cost *CostOptions `json:"cost,omitempty"` // nil disables request cost scoring
+
+	BlockPropagator BlockPropagator `json:"-"` // Shares BlockIP decisions with sibling instances
+
+	// OverrideSyncInterval polls the API for allow overrides granted on other instances
+	// this often; 0 honors only overrides granted through this client
+	OverrideSyncInterval time.Duration `json:"override_sync_interval"`
+
+	// Staleness sets when synced policies and overrides count as stale while refreshes
+	// fail; the last good copy stays in force either way. nil uses the defaults.
+	Staleness *StalenessConfig `json:"staleness,omitempty"`
+
+	UsageAlert *UsageAlertConfig `json:"-"` // Called as plan usage crosses thresholds
+
+	// QuotaGovernor shifts low-priority routes to local-only analysis when usage is
+	// projected to exceed the plan limit; nil disables it
+	QuotaGovernor *QuotaGovernorConfig `json:"quota_governor,omitempty"`
+
+	// Maintenance switches to local-only analysis during backend-announced maintenance
+	// windows and quiets failure warnings meanwhile; nil ignores announcements
+	Maintenance *MaintenanceConfig `json:"maintenance,omitempty"`
+
+	// Trace records the pipeline stages of requests that carry HeaderTrace with the
+	// secret, served by TraceHandler; nil disables tracing
+	Trace *TraceConfig `json:"-"`
+
+	// Sidecar sends analysis to a co-located analysis sidecar instead of the API
+	Sidecar *SidecarConfig `json:"sidecar,omitempty"`
+
+	// EventTransport delivers events in place of the API and Sidecar, e.g. a
+	// FileTransport for air-gapped audit; nil uses the API over HTTP JSON
+	EventTransport Transport `json:"-"`
 }
 
 // DefaultConfig returns a default configuration
@@ -39,80 +164,63 @@ func DefaultConfig() *Config {
 		CustomerID: "default",
 		Debug:      false,
 		Timeout:    30 * time.Second,
+		Retry:      DefaultRetryPolicy(),
+
+		CircuitBreaker: DefaultCircuitBreakerConfig(),
 	}
 }
 
-// SecurityEventRequest represents a request to be analyzed
-type SecurityEventRequest struct {
-	Method      string            `json:"method"`
-	Path        string            `json:"path"`
-	SourceIP    string            `json:"source_ip"`
-	UserAgent   string            `json:"user_agent"`
-	Headers     map[string]string `json:"headers"`
-	QueryParams string            `json:"query_params"`
-	RequestBody string            `json:"request_body"`
-	CustomerID  string            `json:"customer_id"`
-	HasAuth     bool              `json:"has_auth"`
-	CountryCode string            `json:"country_code"`
-	SessionID   string            `json:"session_id"`
-}
-
-// SecurityEventResponse represents the response from security analysis
-type SecurityEventResponse struct {
-	EventID        string           `json:"event_id"`
-	RiskScore      int              `json:"risk_score"`
-	RiskReasons    []string         `json:"risk_reasons"`
-	Action         string           `json:"action"`
-	Allowed        bool             `json:"allowed"`
-	OwaspDetected  []OwaspDetection `json:"owasp_detected"`
-	ProcessingTime string           `json:"processing_time_ms"`
-}
-
-// OwaspDetection represents an OWASP vulnerability detection
-type OwaspDetection struct {
-	ID              uint   `json:"id"`
-	SecurityEventID uint   `json:"security_event_id"`
-	OrganizationID  uint   `json:"organization_id"`
-	OwaspCategory   string `json:"owasp_category"`
-	OwaspTitle      string `json:"owasp_title"`
-	Severity        string `json:"severity"`
-	PatternMatched  string `json:"pattern_matched"`
-	Evidence        string `json:"evidence"`
-	Recommendation  string `json:"recommendation"`
-	FoundIn         string `json:"found_in"`
-	CreatedAt       string `json:"created_at"`
-}
-
-// LLMGuardRequest represents a request to analyze an LLM prompt
-type LLMGuardRequest struct {
-	Input   string            `json:"input"`
-	Context map[string]string `json:"context,omitempty"`
-}
-
-// LLMDetection represents an LLM prompt violation detection
-type LLMDetection struct {
-	RuleID         string `json:"rule_id"`
-	Title          string `json:"title"`
-	Severity       string `json:"severity"`
-	PatternMatched string `json:"pattern_matched"`
-	Evidence       string `json:"evidence"`
-	Recommendation string `json:"recommendation"`
-}
-
-// LLMGuardResponse represents the response from LLM prompt analysis
-type LLMGuardResponse struct {
-	Allowed        bool           `json:"allowed"`
-	Action         string         `json:"action"`
-	Reasons        []string       `json:"reasons"`
-	Detections     []LLMDetection `json:"detections"`
-	ProcessingTime string         `json:"processing_time_ms"`
-}
+// Wire types live in the types package so pipelines consuming mirrored events can use
+// them without importing the SDK
+type (
+	SecurityEventRequest  = types.SecurityEventRequest
+	SecurityEventResponse = types.SecurityEventResponse
+	OwaspDetection        = types.OwaspDetection
+	LLMGuardRequest       = types.LLMGuardRequest
+	LLMDetection          = types.LLMDetection
+	LLMGuardResponse      = types.LLMGuardResponse
+)
 
 // Client represents the Guardial SDK client
 type Client struct {
-	config     *Config
-	httpClient *http.Client
-	sessionID  string
+	config      *Config
+	httpClient  *http.Client
+	sessionID   string
+	breaker     *circuitBreaker
+	queue       chan *SecurityEventRequest
+	verdicts    *verdictCache
+	ipRisk      *ipRiskCache
+	inflight    *coalesceGroup
+	costs       costBudget
+	endpoints   *endpointPool
+	blocks      *blockList
+	usage       usageTracker
+	canaries    *canaryRegistry
+	reviews     *reviewRegistry
+	overrides   *overrideList
+	maintenance *maintenanceTracker
+	anonymizer  *anonymizer
+	headerSets  headerSetCache
+	governor    *quotaGovernor
+	tracer      *tracer
+	syncs       *syncTracker
+	proxies     []*net.IPNet                   // Config.TrustedProxies
+	policy      atomic.Pointer[PolicySchedule] // Config.Policy, or the synced schedule
+	transport   Transport                      // EventTransport or the sidecar; nil uses the API
+
+	// Lifecycle: ctx is canceled by Close; workers tracks background goroutines, which
+	// workersMu keeps from starting once closing is closed
+	ctx       context.Context
+	cancel    context.CancelFunc
+	closing   chan struct{}
+	closeOnce sync.Once
+	workersMu sync.Mutex
+	workers   sync.WaitGroup
+	keys      atomic.Pointer[apiKeys]
+
+	schemaVersion   atomic.Int32 // Negotiated with the backend; 0 until advertised
+	gzipRejected    atomic.Bool  // Backend answered 415 to a gzipped payload
+	msgpackRejected atomic.Bool  // Backend answered 415 to a msgpack payload
 }
 
 // NewClient creates a new Guardial client
@@ -124,13 +232,152 @@ func NewClient(config *Config) *Client {
 	// Generate session ID
 	sessionID := fmt.Sprintf("session_%d_%s", time.Now().Unix(), generateRandomString(9))
 
-	return &Client{
+	client := &Client{
 		config: config,
 		httpClient: &http.Client{
-			Timeout: config.Timeout,
+			Timeout:   config.Timeout,
+			Transport: apiTransport(config),
 		},
 		sessionID: sessionID,
+		blocks:    newBlockList(),
+		canaries:  newCanaryRegistry(),
+		reviews:   newReviewRegistry(),
+		overrides: newOverrideList(),
+		ipRisk:    newIPRiskCache(config.IPRisk),
+		syncs:     newSyncTracker(config.Staleness),
+		closing:   make(chan struct{}),
+	}
+	client.proxies = client.parseTrustedProxies(config.TrustedProxies)
+	client.ctx, client.cancel = context.WithCancel(context.Background())
+	client.keys.Store(&apiKeys{primary: config.APIKey, secondary: config.SecondaryAPIKey})
+	if config.CircuitBreaker != nil {
+		client.breaker = newCircuitBreaker(config.CircuitBreaker)
+	}
+	if len(config.FallbackEndpoints) > 0 {
+		client.endpoints = newEndpointPool(config.Endpoint, config.FallbackEndpoints, config.Failover)
+		client.goBackground(client.runRecoveryProbes)
+	}
+	if config.VerdictCache != nil {
+		client.verdicts = newVerdictCache(config.VerdictCache)
+	}
+	if config.CoalesceRequests {
+		client.inflight = newCoalesceGroup()
+	}
+	if config.AsyncMode {
+		client.startAsync()
+	}
+	if config.BlockPropagator != nil {
+		client.goBackground(client.runBlockSubscription)
+	}
+	if config.EventTransport != nil {
+		client.transport = config.EventTransport
+	} else if config.Sidecar != nil {
+		client.transport = newSidecarClient(config.Sidecar)
+	}
+	if config.QuotaGovernor != nil {
+		client.governor = newQuotaGovernor(config.QuotaGovernor)
+		client.goBackground(client.runQuotaGovernor)
+	}
+	if config.OverrideSyncInterval > 0 {
+		client.goBackground(client.runOverrideSync)
 	}
+	client.policy.Store(config.Policy)
+	if config.PolicySyncInterval > 0 {
+		client.goBackground(client.runPolicySync)
+	}
+	if config.Anonymization != nil {
+		client.anonymizer = newAnonymizer(config.Anonymization)
+	}
+	if config.Maintenance != nil {
+		client.maintenance = newMaintenanceTracker(config.Maintenance)
+		client.goBackground(client.runMaintenance)
+	}
+	if config.Trace != nil {
+		client.tracer = newTracer(config.Trace)
+	}
+	return client
+}
+
+// Close drains pending async events, stops background goroutines, and closes idle
+// connections. It returns ctx's error if the drain did not finish in time, in which case
+// in-flight deliveries are abandoned. Analysis calls after Close still work, but async
+// events are dropped.
+func (c *Client) Close(ctx context.Context) error {
+	c.closeOnce.Do(func() {
+		c.log("Closing client, flushing pending events")
+		c.workersMu.Lock()
+		close(c.closing)
+		c.workersMu.Unlock()
+	})
+
+	done := make(chan struct{})
+	go func() {
+		c.workers.Wait()
+		close(done)
+	}()
+
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("failed to flush pending events: %w", ctx.Err())
+	}
+	c.cancel()
+	c.httpClient.CloseIdleConnections()
+	if c.transport != nil {
+		if closeErr := c.transport.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("failed to close transport: %w", closeErr)
+		}
+	}
+	return err
+}
+
+// goBackground runs fn in a goroutine that Close waits for. Once Close has begun it
+// starts nothing and returns false.
+func (c *Client) goBackground(fn func()) bool {
+	c.workersMu.Lock()
+	defer c.workersMu.Unlock()
+	select {
+	case <-c.closing:
+		return false
+	default:
+	}
+	c.workers.Add(1)
+	go func() {
+		defer c.workers.Done()
+		fn()
+	}()
+	return true
+}
+
+// NewClientFromEnv creates a new Guardial client configured from environment variables
+// (GUARDIAL_API_KEY, GUARDIAL_API_KEY_SECONDARY, GUARDIAL_ENDPOINT, GUARDIAL_CUSTOMER_ID,
+// GUARDIAL_DEBUG, and GUARDIAL_TLS_CERT, GUARDIAL_TLS_KEY, GUARDIAL_TLS_CA for mTLS)
+func NewClientFromEnv() (*Client, error) {
+	config := DefaultConfig()
+	config.APIKey = os.Getenv("GUARDIAL_API_KEY")
+	if config.APIKey == "" {
+		return nil, fmt.Errorf("GUARDIAL_API_KEY environment variable is required")
+	}
+	if endpoint := os.Getenv("GUARDIAL_ENDPOINT"); endpoint != "" {
+		config.Endpoint = endpoint
+	}
+	if customerID := os.Getenv("GUARDIAL_CUSTOMER_ID"); customerID != "" {
+		config.CustomerID = customerID
+	}
+	config.SecondaryAPIKey = os.Getenv("GUARDIAL_API_KEY_SECONDARY")
+	config.Debug = strings.ToLower(os.Getenv("GUARDIAL_DEBUG")) == "true"
+
+	certFile, keyFile, caFile := os.Getenv("GUARDIAL_TLS_CERT"), os.Getenv("GUARDIAL_TLS_KEY"), os.Getenv("GUARDIAL_TLS_CA")
+	if certFile != "" || keyFile != "" || caFile != "" {
+		tlsConfig, err := LoadClientTLS(certFile, keyFile, caFile)
+		if err != nil {
+			return nil, err
+		}
+		config.TLSConfig = tlsConfig
+	}
+
+	return NewClient(config), nil
 }
 
 // SecureHTTPClient wraps the standard http.Client with security analysis
@@ -159,7 +406,7 @@ func (t *SecurityTransport) RoundTrip(req *http.Request) (*http.Response, error)
 		// Continue with request even if analysis fails
 	} else if !analysis.Allowed {
 		// Block the request if security analysis says so
-		return nil, fmt.Errorf("request blocked by Guardial: %s", strings.Join(analysis.RiskReasons, ", "))
+		return nil, &BlockedError{Analysis: analysis}
 	}
 
 	// Make the actual request
@@ -176,6 +423,12 @@ func (t *SecurityTransport) RoundTrip(req *http.Request) (*http.Response, error)
 
 // AnalyzeRequest analyzes an HTTP request for security threats
 func (c *Client) AnalyzeRequest(req *http.Request) (*SecurityEventResponse, error) {
+	return c.AnalyzeRequestContext(req.Context(), req)
+}
+
+// AnalyzeRequestContext analyzes an HTTP request for security threats, honoring ctx for
+// cancellation
+func (c *Client) AnalyzeRequestContext(ctx context.Context, req *http.Request) (*SecurityEventResponse, error) {
 	// Extract request data
 	requestData := SecurityEventRequest{
 		Method:      req.Method,
@@ -190,89 +443,333 @@ func (c *Client) AnalyzeRequest(req *http.Request) (*SecurityEventResponse, erro
 		SessionID:   c.sessionID,
 	}
 
-	return c.AnalyzeEvent(&requestData)
+	return c.AnalyzeEventContext(ctx, &requestData)
 }
 
 // AnalyzeEvent analyzes a security event
 func (c *Client) AnalyzeEvent(event *SecurityEventRequest) (*SecurityEventResponse, error) {
+	return c.AnalyzeEventContext(context.Background(), event)
+}
+
+// AnalyzeEventContext analyzes a security event, honoring ctx for cancellation and deadlines
+func (c *Client) AnalyzeEventContext(ctx context.Context, event *SecurityEventRequest) (*SecurityEventResponse, error) {
 	// Set customer ID if not provided
 	if event.CustomerID == "" {
 		event.CustomerID = c.config.CustomerID
 	}
-
-	// Marshal request
-	jsonData, err := json.Marshal(event)
+	if event.EventID == "" {
+		event.EventID = c.newEventID()
+	}
+
+	trace := traceFromContext(ctx)
+	start := time.Now()
+	c.enrichGeo(event)
+	trace.record("geo", start, event.CountryCode)
+	start = time.Now()
+	c.scoreCost(event)
+	trace.record("cost", start, "")
+
+	start = time.Now()
+	if c.inMaintenance() || (c.governor != nil && c.governor.localOnly(event)) {
+		analysis := &SecurityEventResponse{EventID: event.EventID, Allowed: true, Action: ActionLocal}
+		c.enforceCost(event, analysis)
+		c.applyPolicy(event, analysis)
+		trace.record("local_only", start, analysis.Action)
+		return analysis, nil
+	}
+
+	if c.queue != nil {
+		trace.record("async_queue", start, "")
+		return c.enqueueEvent(event), nil
+	}
+
+	// Serve repeated identical requests from the verdict cache
+	var signature string
+	if c.verdicts != nil || c.inflight != nil {
+		signature = RequestSignature(event)
+	}
+	if c.verdicts != nil {
+		start = time.Now()
+		cached, ok := c.verdicts.get(signature)
+		if ok {
+			c.log("Verdict cache hit:", event.Method, event.Path)
+			trace.record("verdict_cache", start, "hit")
+			// The verdict answers this event, not the one it was cached for
+			cached.EventID = event.EventID
+			start = time.Now()
+			c.enforceCost(event, cached)
+			c.applyPolicy(event, cached)
+			trace.record("policy", start, cached.Action)
+			// A replayed verdict isn't another analysis, so IPRisk doesn't count it
+			return cached, nil
+		}
+		trace.record("verdict_cache", start, "miss")
+	}
+
+	var analysis *SecurityEventResponse
+	var err error
+	start = time.Now()
+	if c.inflight != nil {
+		// Share one API call among concurrent identical requests
+		var shared bool
+		analysis, shared, err = c.inflight.do(ctx, signature, func(ctx context.Context) (*SecurityEventResponse, error) {
+			return c.fetchAnalysis(ctx, event, signature)
+		})
+		if shared && err == nil {
+			c.log("Coalesced with in-flight analysis:", event.Method, event.Path)
+			trace.record("coalesce", start, "shared in-flight analysis")
+			analysis.EventID = event.EventID
+		}
+	} else {
+		analysis, err = c.fetchAnalysis(ctx, event, signature)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+		trace.record("analysis", start, err.Error())
+		return nil, err
 	}
+	start = time.Now()
+	c.enforceCost(event, analysis)
+	c.applyPolicy(event, analysis)
+	trace.record("policy", start, analysis.Action)
+	c.observeRisk(event, analysis)
 
-	// Create HTTP request
-	req, err := http.NewRequest("POST", c.config.Endpoint+"/api/events", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	c.log("Security analysis completed:", *analysis)
+	return analysis, nil
+}
+
+// fetchAnalysis calls the API (or the Transport) for event and caches the verdict
+// under signature
+func (c *Client) fetchAnalysis(ctx context.Context, event *SecurityEventRequest, signature string) (*SecurityEventResponse, error) {
+	ctx, cancel := c.analysisContext(ctx)
+	defer cancel()
+
+	trace := traceFromContext(ctx)
+	var analysis *SecurityEventResponse
+	if c.transport != nil {
+		start := time.Now()
+		results, err := c.sendEvents(ctx, []*SecurityEventRequest{event})
+		if err != nil {
+			trace.record("transport", start, err.Error())
+			if !c.fallbackToAPI() || abandoned(ctx, err) {
+				return nil, err
+			}
+			c.log("⚠️ Sidecar analysis failed, falling back to API:", err)
+		} else {
+			analysis = results[0]
+			trace.record("transport", start, analysis.Action)
+		}
 	}
+	if analysis == nil {
+		start := time.Now()
+		analysis = &SecurityEventResponse{}
+		if err := c.postEvents(ctx, "/api/events", []*SecurityEventRequest{event}, singleEvent, analysis); err != nil {
+			trace.record("api_call", start, err.Error())
+			return nil, err
+		}
+		trace.record("api_call", start, analysis.Action)
+	}
+	if analysis.EventID == "" {
+		// Backends before schema 7 assign no ID of their own
+		analysis.EventID = event.EventID
+	}
+	start := time.Now()
+	enrichTaxonomy(analysis)
+	structureReasons(analysis)
+	c.recalibrateSeverity(event.Path, analysis)
+	trace.record("normalize_verdict", start, "")
 
-	// Set headers
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-API-Key", c.config.APIKey)
+	// A cached "delivered" verdict would skip delivering repeats of the event
+	if c.verdicts != nil && analysis.Action != ActionDelivered {
+		c.verdicts.put(signature, analysis)
+	}
+	return analysis, nil
+}
 
-	// Make request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+// PromptGuard analyzes an LLM prompt for injection and policy violations
+func (c *Client) PromptGuard(input string, promptContext map[string]string) (*LLMGuardResponse, error) {
+	return c.PromptGuardContext(context.Background(), input, promptContext)
+}
+
+// PromptGuardContext analyzes an LLM prompt, honoring ctx for cancellation and deadlines
+func (c *Client) PromptGuardContext(ctx context.Context, input string, promptContext map[string]string) (*LLMGuardResponse, error) {
+	request := LLMGuardRequest{
+		Input:   input,
+		Context: promptContext,
 	}
-	defer resp.Body.Close()
 
-	// Read response
-	body, err := io.ReadAll(resp.Body)
+	ctx, cancel := c.analysisContext(ctx)
+	defer cancel()
+
+	var result LLMGuardResponse
+	if err := c.postJSON(ctx, "/api/llm/guard", request, &result); err != nil {
+		return nil, err
+	}
+
+	c.log("LLM Guard analysis:", result)
+	return &result, nil
+}
+
+// analysisContext bounds ctx by Config.AnalysisTimeout, if set
+func (c *Client) analysisContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.config.AnalysisTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, c.config.AnalysisTimeout)
+}
+
+// postJSON sends payload to the Guardial API and decodes the JSON response into out
+func (c *Client) postJSON(ctx context.Context, path string, payload interface{}, out interface{}) error {
+	// Marshal request
+	data, contentType, err := c.encodePayload(payload)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	// Check status code
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API error: %d - %s", resp.StatusCode, string(body))
+	body, err := c.send(ctx, "POST", path, data, contentType)
+	if contentType == contentTypeMsgpack && isUnsupportedMediaType(err) {
+		// Backend doesn't accept msgpack; fall back to JSON from now on
+		c.msgpackRejected.Store(true)
+		c.log("Backend rejected msgpack payloads, sending JSON")
+		return c.postJSON(ctx, path, payload, out)
+	}
+	if err != nil {
+		return err
 	}
 
 	// Parse response
-	var analysis SecurityEventResponse
-	if err := json.Unmarshal(body, &analysis); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// getJSON fetches path from the Guardial API and decodes the JSON response into out
+func (c *Client) getJSON(ctx context.Context, path string, out interface{}) error {
+	body, err := c.send(ctx, "GET", path, nil, "")
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
 	}
+	return nil
+}
+
+// send performs an API call, retrying transient failures according to the retry policy
+func (c *Client) send(ctx context.Context, method, path string, payload []byte, contentType string) ([]byte, error) {
+	policy := c.config.Retry
+	attempts := 1
+	if policy != nil && policy.MaxAttempts > 1 {
+		attempts = policy.MaxAttempts
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			wait := policy.backoff(attempt, lastErr)
+			c.log(fmt.Sprintf("Retrying %s in %s (attempt %d/%d): %v", path, wait, attempt+1, attempts, lastErr))
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, fmt.Errorf("%w (last error: %v)", ctx.Err(), lastErr)
+			case <-timer.C:
+			}
+		}
+
+		// Fail fast while the API is known to be unhealthy
+		if c.breaker != nil && !c.breaker.allow() {
+			return nil, ErrCircuitOpen
+		}
 
-	c.log("Security analysis completed:", analysis)
-	return &analysis, nil
+		body, err := c.sendOnce(ctx, method, path, payload, contentType)
+		if c.breaker != nil {
+			if changed, open := c.breaker.record(err); changed && open {
+				c.warn("⚡ Circuit breaker opened after repeated API failures:", err)
+			} else if changed {
+				c.log("⚡ Circuit breaker closed, API calls resumed")
+			}
+		}
+		if err == nil {
+			return body, nil
+		}
+		lastErr = err
+		if policy == nil || !policy.retryable(ctx, method, err) {
+			break
+		}
+	}
+	return nil, lastErr
 }
 
-// PromptGuard analyzes an LLM prompt for injection and policy violations
-func (c *Client) PromptGuard(input string, context map[string]string) (*LLMGuardResponse, error) {
-	request := LLMGuardRequest{
-		Input:   input,
-		Context: context,
+// sendOnce performs a single API call and returns the body of a 200 response, falling
+// back to the secondary API key if the primary is rejected
+func (c *Client) sendOnce(ctx context.Context, method, path string, payload []byte, contentType string) ([]byte, error) {
+	keys := c.keys.Load()
+	body, err := c.sendWithKey(ctx, method, path, payload, contentType, keys.primary)
+	if isUnauthorized(err) && keys.secondary != "" {
+		// The primary key may have been revoked mid-rotation; try the secondary
+		body, err = c.sendWithKey(ctx, method, path, payload, contentType, keys.secondary)
+		if err == nil {
+			c.promoteSecondary(keys)
+		}
 	}
+	return body, err
+}
 
-	// Marshal request
-	jsonData, err := json.Marshal(request)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+// sendWithKey makes a single API call authenticated with apiKey
+func (c *Client) sendWithKey(ctx context.Context, method, path string, payload []byte, contentType, apiKey string) ([]byte, error) {
+	endpoint := c.endpoint()
+	wire, compressed := c.compressPayload(payload)
+	body, err := c.sendEncoded(ctx, endpoint, method, path, payload, wire, compressed, contentType, apiKey)
+	if compressed && isUnsupportedMediaType(err) {
+		// The 415 may be for the content type rather than gzip, so only stop compressing
+		// if the backend takes the same payload uncompressed
+		body, err = c.sendEncoded(ctx, endpoint, method, path, payload, payload, false, contentType, apiKey)
+		if err == nil {
+			c.gzipRejected.Store(true)
+			c.log("Backend rejected gzip payloads, sending uncompressed")
+		}
 	}
+	c.recordEndpoint(endpoint, err)
+	return body, err
+}
 
+// sendEncoded sends wire, the payload as it goes over the network, to endpoint
+func (c *Client) sendEncoded(ctx context.Context, endpoint, method, path string, payload, wire []byte, compressed bool, contentType, apiKey string) ([]byte, error) {
 	// Create HTTP request
-	req, err := http.NewRequest("POST", c.config.Endpoint+"/api/llm/guard", bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, method, endpoint+path, bytes.NewReader(wire))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set headers
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-API-Key", c.config.APIKey)
+	if payload != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if compressed {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	req.Header.Set("X-API-Key", apiKey)
+	c.signRequest(req, payload)
 
 	// Make request
+	return c.do(req)
+}
+
+// do executes req and returns the body of a 200 response
+func (c *Client) do(req *http.Request) ([]byte, error) {
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
 	defer resp.Body.Close()
+	c.observeSchema(resp.Header)
+	c.observeUsage(resp.Header)
+	c.observeMaintenance(resp.Header)
 
 	// Read response
 	body, err := io.ReadAll(resp.Body)
@@ -281,36 +778,30 @@ func (c *Client) PromptGuard(input string, context map[string]string) (*LLMGuard
 	}
 
 	// Check status code
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API error: %d - %s", resp.StatusCode, string(body))
+	if resp.StatusCode == http.StatusUpgradeRequired {
+		return nil, newSchemaVersionError(resp, newAPIError(resp, body))
 	}
-
-	// Parse response
-	var result LLMGuardResponse
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, body)
 	}
-
-	c.log("LLM Guard analysis:", result)
-	return &result, nil
+	return body, nil
 }
 
 // HealthCheck checks the health of the Guardial service
 func (c *Client) HealthCheck(ctx context.Context) (map[string]interface{}, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", c.config.Endpoint+"/health", nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
+	return c.healthCheck(ctx, c.endpoint())
+}
 
-	resp, err := c.httpClient.Do(req)
+// healthCheck checks the health of a single endpoint
+func (c *Client) healthCheck(ctx context.Context, endpoint string) (map[string]interface{}, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint+"/health", nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := c.do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, err
 	}
 
 	var result map[string]interface{}
@@ -324,29 +815,108 @@ func (c *Client) HealthCheck(ctx context.Context) (map[string]interface{}, error
 // Helper methods
 
 func (c *Client) getClientIP(req *http.Request) string {
+	return clientIP(req.Header.Get, req.RemoteAddr)
+}
+
+// requestAddresses resolves the SourceIP and PeerIP of req; see addresses
+func (c *Client) requestAddresses(req *http.Request, trustPeer bool) (source, peer string) {
+	return c.addresses(req.Header.Get, strings.Join(req.Header.Values("X-Forwarded-For"), ","), req.RemoteAddr, trustPeer)
+}
+
+// clientIP resolves the client address from proxy headers (looked up by canonical name)
+// or the peer address
+func clientIP(header func(name string) string, remoteAddr string) string {
 	// Try to get real IP from headers
-	if ip := req.Header.Get("X-Forwarded-For"); ip != "" {
+	if ip := header("X-Forwarded-For"); ip != "" {
 		return strings.Split(ip, ",")[0]
 	}
-	if ip := req.Header.Get("X-Real-IP"); ip != "" {
+	if ip := header("X-Real-Ip"); ip != "" {
 		return ip
 	}
-	if ip := req.Header.Get("X-Client-IP"); ip != "" {
+	if ip := header("X-Client-Ip"); ip != "" {
 		return ip
 	}
 
 	// Fallback to remote address
-	if req.RemoteAddr != "" {
-		host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if remoteAddr != "" {
+		host, _, err := net.SplitHostPort(remoteAddr)
 		if err == nil {
 			return host
 		}
-		return req.RemoteAddr
+		return remoteAddr
 	}
 
 	return "unknown"
 }
 
+// addresses resolves an event's SourceIP and PeerIP. Without trusted proxies, SourceIP
+// is what clientIP reports and PeerIP the peer address. Behind a trusted proxy (or any
+// peer, with trustPeer) both are the right-most forwardedFor hop that isn't a trusted
+// proxy, so clients can't choose the address they are blocked or allowed as.
+func (c *Client) addresses(header func(name string) string, forwardedFor, remoteAddr string, trustPeer bool) (source, peer string) {
+	peer = remoteAddr
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		peer = host
+	}
+	if !trustPeer && !c.trustedProxy(peer) {
+		if len(c.proxies) > 0 {
+			return peer, peer
+		}
+		return clientIP(header, remoteAddr), peer
+	}
+
+	hops := strings.Split(forwardedFor, ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if net.ParseIP(hop) == nil {
+			break // Not written by a proxy we trust
+		}
+		peer = hop
+		if !c.trustedProxy(hop) {
+			break
+		}
+	}
+	return peer, peer
+}
+
+// trustedProxy reports whether ip is one of Config.TrustedProxies
+func (c *Client) trustedProxy(ip string) bool {
+	if len(c.proxies) == 0 {
+		return false
+	}
+	parsed := net.ParseIP(ip)
+	for _, network := range c.proxies {
+		if parsed != nil && network.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTrustedProxies parses IPs and CIDRs, skipping invalid entries
+func (c *Client) parseTrustedProxies(entries []string) []*net.IPNet {
+	var networks []*net.IPNet
+	for _, entry := range entries {
+		if !strings.Contains(entry, "/") {
+			if ip := net.ParseIP(entry); ip != nil {
+				bits := 8 * len(ip.To4())
+				if bits == 0 {
+					bits = 8 * net.IPv6len
+				}
+				networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+				continue
+			}
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			c.log("⚠️ Ignoring invalid trusted proxy:", entry)
+			continue
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
 func (c *Client) extractHeaders(headers http.Header) map[string]string {
 	result := make(map[string]string)
 	for key, values := range headers {
@@ -375,9 +945,14 @@ func (c *Client) extractRequestBody(req *http.Request) string {
 }
 
 func (c *Client) hasAuthHeaders(headers http.Header) bool {
-	authHeaders := []string{"authorization", "x-api-key", "x-auth-token"}
-	for _, header := range authHeaders {
-		if headers.Get(header) != "" {
+	return hasAuthHeader(headers.Get)
+}
+
+// hasAuthHeader reports whether credentials are present, looking headers up by canonical name
+func hasAuthHeader(header func(name string) string) bool {
+	authHeaders := []string{"Authorization", "X-Api-Key", "X-Auth-Token"}
+	for _, name := range authHeaders {
+		if header(name) != "" {
 			return true
 		}
 	}
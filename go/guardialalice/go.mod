@@ -0,0 +1,10 @@
+module github.com/divyankvijayvergiya/guardial-sdk/guardialalice
+
+go 1.21
+
+require (
+	github.com/divyankvijayvergiya/guardial-sdk v0.1.0
+	github.com/justinas/alice v1.2.0
+)
+
+replace github.com/divyankvijayvergiya/guardial-sdk => ../
@@ -0,0 +1,26 @@
+/**
+ * Guardial Go SDK Alice Adapter
+ * The Guardial middleware as an alice constructor
+ */
+
+// Package guardialalice provides Guardial middleware for alice chains.
+//
+//	chain := guardialalice.New(client, nil, loggingHandler, authHandler)
+//	http.Handle("/", chain.Then(mux))
+package guardialalice
+
+import (
+	guardial "github.com/divyankvijayvergiya/guardial-sdk"
+	"github.com/justinas/alice"
+)
+
+// Constructor returns the Guardial middleware as an alice.Constructor
+func Constructor(client *guardial.Client, options *guardial.MiddlewareOptions) alice.Constructor {
+	return guardial.StandardMiddleware(client, options)
+}
+
+// New starts a chain with the Guardial middleware followed by constructors, so requests
+// are analyzed before the rest of the chain sees them
+func New(client *guardial.Client, options *guardial.MiddlewareOptions, constructors ...alice.Constructor) alice.Chain {
+	return alice.New(append([]alice.Constructor{Constructor(client, options)}, constructors...)...)
+}
@@ -0,0 +1,120 @@
+/**
+ * Guardial Go SDK Caddy Module
+ * The guardial Caddyfile directive
+ */
+
+package guardialcaddy
+
+import (
+	"strconv"
+
+	"github.com/caddyserver/caddy/v2/caddyconfig/caddyfile"
+	"github.com/caddyserver/caddy/v2/caddyconfig/httpcaddyfile"
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp"
+	"github.com/dustin/go-humanize"
+)
+
+func init() {
+	httpcaddyfile.RegisterHandlerDirective("guardial", parseCaddyfile)
+	// Screen requests before authentication and before they are proxied or served
+	httpcaddyfile.RegisterDirectiveOrder("guardial", httpcaddyfile.Before, "basic_auth")
+}
+
+// parseCaddyfile sets up the handler from Caddyfile tokens
+func parseCaddyfile(h httpcaddyfile.Helper) (caddyhttp.MiddlewareHandler, error) {
+	handler := new(Handler)
+	err := handler.UnmarshalCaddyfile(h.Dispenser)
+	return handler, err
+}
+
+// UnmarshalCaddyfile sets up the handler from Caddyfile tokens:
+//
+//	guardial [<api_key>] {
+//		api_key        <key>
+//		endpoint       <url>
+//		customer_id    <id>
+//		mode           enforce|monitor
+//		fail_closed
+//		exclude        <patterns...>
+//		sample_rate    <0.0-1.0>
+//		max_body_size  <size>
+//		debug
+//	}
+func (h *Handler) UnmarshalCaddyfile(d *caddyfile.Dispenser) error {
+	d.Next() // the directive name
+	if d.NextArg() {
+		h.APIKey = d.Val()
+	}
+	if d.NextArg() {
+		return d.ArgErr()
+	}
+
+	for d.NextBlock(0) {
+		switch d.Val() {
+		case "api_key", "endpoint", "customer_id", "mode":
+			name := d.Val()
+			var value string
+			if !d.Args(&value) {
+				return d.ArgErr()
+			}
+			switch name {
+			case "api_key":
+				h.APIKey = value
+			case "endpoint":
+				h.Endpoint = value
+			case "customer_id":
+				h.CustomerID = value
+			case "mode":
+				h.Mode = value
+			}
+
+		case "fail_closed":
+			if d.NextArg() {
+				return d.ArgErr()
+			}
+			h.FailClosed = true
+
+		case "debug":
+			if d.NextArg() {
+				return d.ArgErr()
+			}
+			h.Debug = true
+
+		case "exclude":
+			patterns := d.RemainingArgs()
+			if len(patterns) == 0 {
+				return d.ArgErr()
+			}
+			h.Exclude = append(h.Exclude, patterns...)
+
+		case "sample_rate":
+			var value string
+			if !d.Args(&value) {
+				return d.ArgErr()
+			}
+			rate, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return d.Errf("invalid sample_rate %q: %v", value, err)
+			}
+			h.SampleRate = rate
+
+		case "max_body_size":
+			var value string
+			if !d.Args(&value) {
+				return d.ArgErr()
+			}
+			size, err := humanize.ParseBytes(value)
+			if err != nil {
+				return d.Errf("invalid max_body_size %q: %v", value, err)
+			}
+			h.MaxBodyBytes = int64(size)
+
+		default:
+			return d.Errf("unknown guardial subdirective %q", d.Val())
+		}
+	}
+	return nil
+}
+
+// Interface guard
+var _ caddyfile.Unmarshaler = (*Handler)(nil)
@@ -0,0 +1,177 @@
+/**
+ * Guardial Go SDK Caddy Module
+ * An HTTP handler module running the Guardial middleware inside Caddy
+ */
+
+// Package guardialcaddy provides the http.handlers.guardial Caddy module, so Guardial
+// can be enabled at the proxy layer without touching application code. Build Caddy
+// with it:
+//
+//	xcaddy build --with github.com/divyankvijayvergiya/guardial-sdk/guardialcaddy
+//
+// and enable it with the guardial Caddyfile directive:
+//
+//	example.com {
+//		guardial {
+//			api_key {env.GUARDIAL_API_KEY}
+//			exclude /health "GET,HEAD /static/**"
+//		}
+//		reverse_proxy localhost:8080
+//	}
+//
+// The whole net/http pipeline runs, including canaries, degradation, and crash
+// telemetry. Handlers that share an API key and endpoint share one client, which
+// survives config reloads.
+package guardialcaddy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/caddyserver/caddy/v2"
+	"github.com/caddyserver/caddy/v2/modules/caddyhttp"
+	guardial "github.com/divyankvijayvergiya/guardial-sdk"
+)
+
+func init() {
+	caddy.RegisterModule(Handler{})
+}
+
+// clients shares one client per API key and endpoint across handlers and config reloads
+var clients = caddy.NewUsagePool()
+
+// closeTimeout bounds the flush of pending events as a client is released
+const closeTimeout = 5 * time.Second
+
+// Handler analyzes each request with Guardial and passes it on only if it may proceed.
+// Handlers further down read the verdict with guardial.FromContext.
+type Handler struct {
+	// APIKey authenticates with the Guardial API; Caddy placeholders are expanded
+	// (default: {env.GUARDIAL_API_KEY})
+	APIKey     string `json:"api_key,omitempty"`
+	Endpoint   string `json:"endpoint,omitempty"`    // Guardial API endpoint (default: the SDK's)
+	CustomerID string `json:"customer_id,omitempty"` // Customer ID sent with events
+	Debug      bool   `json:"debug,omitempty"`       // Log SDK debug output
+
+	// Mode is "enforce" (the default) to refuse blocked requests, or "monitor" to only
+	// record verdicts
+	Mode string `json:"mode,omitempty"`
+
+	// FailClosed also refuses requests when analysis fails, instead of letting them
+	// proceed unanalyzed. Blocked requests are refused either way, unless Mode is
+	// "monitor".
+	FailClosed bool `json:"fail_closed,omitempty"`
+
+	// Exclude replaces the default path exclusions (/health, /favicon.ico); entries are
+	// as in MiddlewareOptions.ExcludePaths
+	Exclude []string `json:"exclude,omitempty"`
+
+	SampleRate   float64 `json:"sample_rate,omitempty"`    // Fraction of requests analyzed (default: 1)
+	MaxBodyBytes int64   `json:"max_body_bytes,omitempty"` // Request body captured per event (default: 1 MB)
+
+	key   string
+	guard *guardial.Guard
+}
+
+// CaddyModule returns the Caddy module information
+func (Handler) CaddyModule() caddy.ModuleInfo {
+	return caddy.ModuleInfo{
+		ID:  "http.handlers.guardial",
+		New: func() caddy.Module { return new(Handler) },
+	}
+}
+
+// Provision sets up the handler's middleware and its shared client
+func (h *Handler) Provision(ctx caddy.Context) error {
+	repl := caddy.NewReplacer()
+	apiKey := h.APIKey
+	if apiKey == "" {
+		apiKey = "{env.GUARDIAL_API_KEY}"
+	}
+	config := guardial.DefaultConfig()
+	config.APIKey = repl.ReplaceAll(apiKey, "")
+	if config.APIKey == "" {
+		return fmt.Errorf("guardial: api_key is required (or set GUARDIAL_API_KEY)")
+	}
+	if h.Endpoint != "" {
+		config.Endpoint = repl.ReplaceAll(h.Endpoint, "")
+	}
+	config.CustomerID = repl.ReplaceAll(h.CustomerID, "")
+	config.Debug = h.Debug
+
+	h.key = strings.Join([]string{config.APIKey, config.Endpoint, config.CustomerID}, "\x00")
+	value, _, err := clients.LoadOrNew(h.key, func() (caddy.Destructor, error) {
+		return sharedClient{guardial.NewClient(config)}, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	options := guardial.DefaultMiddlewareOptions()
+	options.Mode = guardial.Mode(h.Mode)
+	options.FailOpen = !h.FailClosed
+	if len(h.Exclude) > 0 {
+		options.ExcludePaths = h.Exclude
+	}
+	if h.SampleRate > 0 {
+		options.SampleRate = h.SampleRate
+	}
+	if h.MaxBodyBytes > 0 {
+		options.MaxBodyBytes = h.MaxBodyBytes
+	}
+	h.guard = guardial.NewGuard(value.(sharedClient).Client, options)
+	return nil
+}
+
+// Validate checks the handler's settings
+func (h *Handler) Validate() error {
+	switch guardial.Mode(h.Mode) {
+	case "", guardial.ModeEnforce, guardial.ModeMonitor:
+	default:
+		return fmt.Errorf("guardial: unknown mode %q (want enforce or monitor)", h.Mode)
+	}
+	if h.SampleRate < 0 || h.SampleRate > 1 {
+		return fmt.Errorf("guardial: sample_rate must be between 0 and 1, got %v", h.SampleRate)
+	}
+	return nil
+}
+
+// Cleanup releases the handler's client, closing it once no handler uses it
+func (h *Handler) Cleanup() error {
+	if h.key == "" {
+		return nil
+	}
+	_, err := clients.Delete(h.key)
+	return err
+}
+
+// ServeHTTP analyzes r and calls next only if it may proceed
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request, next caddyhttp.Handler) error {
+	var nextErr error
+	h.guard.Serve(w, r, func(w http.ResponseWriter, r *http.Request) {
+		nextErr = next.ServeHTTP(w, r)
+	})
+	return nextErr
+}
+
+// sharedClient closes the client as the last handler using it is cleaned up
+type sharedClient struct {
+	*guardial.Client
+}
+
+func (c sharedClient) Destruct() error {
+	ctx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+	defer cancel()
+	return c.Close(ctx)
+}
+
+// Interface guards
+var (
+	_ caddy.Provisioner           = (*Handler)(nil)
+	_ caddy.Validator             = (*Handler)(nil)
+	_ caddy.CleanerUpper          = (*Handler)(nil)
+	_ caddyhttp.MiddlewareHandler = (*Handler)(nil)
+)
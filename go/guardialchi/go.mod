@@ -0,0 +1,10 @@
+module github.com/divyankvijayvergiya/guardial-sdk/guardialchi
+
+go 1.21
+
+require (
+	github.com/divyankvijayvergiya/guardial-sdk v0.1.0
+	github.com/go-chi/chi/v5 v5.0.12
+)
+
+replace github.com/divyankvijayvergiya/guardial-sdk => ../
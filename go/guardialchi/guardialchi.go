@@ -0,0 +1,49 @@
+/**
+ * Guardial Go SDK Chi Adapter
+ * Middleware that records chi route patterns in security events
+ */
+
+// Package guardialchi provides Guardial middleware for the chi router.
+//
+//	r := chi.NewRouter()
+//	r.Use(guardialchi.Middleware(client, nil))
+package guardialchi
+
+import (
+	"net/http"
+
+	guardial "github.com/divyankvijayvergiya/guardial-sdk"
+	"github.com/go-chi/chi/v5"
+)
+
+// Middleware returns a chi middleware that analyzes each request and records the route
+// pattern it matches (e.g. "/users/{id}") in the event, so the backend aggregates
+// detections per route rather than per unique URL
+func Middleware(client *guardial.Client, options *guardial.MiddlewareOptions) func(http.Handler) http.Handler {
+	if options == nil {
+		options = guardial.DefaultMiddlewareOptions()
+	}
+	opts := *options
+	opts.RouteFunc = RoutePattern
+	return guardial.StandardMiddleware(client, &opts)
+}
+
+// RoutePattern returns the chi route pattern r will be routed to, or "" if none matches.
+// Middleware registered with Use runs before chi has routed the request, so the pattern
+// is resolved up front against the root router.
+func RoutePattern(r *http.Request) string {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil || rctx.Routes == nil {
+		return ""
+	}
+
+	path := r.URL.RawPath
+	if path == "" {
+		path = r.URL.Path
+	}
+	match := chi.NewRouteContext()
+	if !rctx.Routes.Match(match, r.Method, path) {
+		return ""
+	}
+	return match.RoutePattern()
+}
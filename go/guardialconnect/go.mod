@@ -0,0 +1,11 @@
+module github.com/divyankvijayvergiya/guardial-sdk/guardialconnect
+
+go 1.21
+
+require (
+	connectrpc.com/connect v1.16.0
+	github.com/divyankvijayvergiya/guardial-sdk v0.1.0
+	google.golang.org/protobuf v1.33.0
+)
+
+replace github.com/divyankvijayvergiya/guardial-sdk => ../
@@ -0,0 +1,132 @@
+/**
+ * Guardial Go SDK Connect Adapter
+ * connect-go interceptor that analyzes RPCs with their decoded payloads
+ */
+
+// Package guardialconnect provides a Guardial interceptor for connect-go services.
+//
+//	path, handler := usersv1connect.NewUsersServiceHandler(svc,
+//		connect.WithInterceptors(guardialconnect.NewInterceptor(client, nil)))
+//
+// RPCs are analyzed as requests to their procedure ("/pkg.Service/Method"), with the
+// decoded request message, serialized as JSON, as the body.
+package guardialconnect
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"connectrpc.com/connect"
+	guardial "github.com/divyankvijayvergiya/guardial-sdk"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Interceptor analyzes RPCs handled by a connect-go service. Unary calls are analyzed
+// with their request message; streams when they open. Refused calls fail with
+// connect.CodePermissionDenied, with the decision headers in the error's metadata.
+// Handlers read the verdict with guardial.FromContext.
+type Interceptor struct {
+	client *guardial.Client
+	guard  *guardial.Guard
+}
+
+var _ connect.Interceptor = (*Interceptor)(nil)
+
+// NewInterceptor creates an Interceptor with the given middleware options
+func NewInterceptor(client *guardial.Client, options *guardial.MiddlewareOptions) *Interceptor {
+	return &Interceptor{client: client, guard: guardial.NewGuard(client, options)}
+}
+
+// WrapUnary implements connect.Interceptor
+func (i *Interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			return next(ctx, req)
+		}
+		event := i.client.NewEvent(requestParts(req.HTTPMethod(), req.Spec().Procedure, req.Peer().Addr, req.Header(), req.Any()))
+		ctx, err := i.check(ctx, event)
+		if err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+// WrapStreamingClient implements connect.Interceptor; client streams pass through
+func (i *Interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler implements connect.Interceptor
+func (i *Interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		event := i.client.NewEvent(requestParts(http.MethodPost, conn.Spec().Procedure, conn.Peer().Addr, conn.RequestHeader(), nil))
+		ctx, err := i.check(ctx, event)
+		if err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
+
+// check analyzes event, returning the context carrying the verdict or a connect error
+func (i *Interceptor) check(ctx context.Context, event *guardial.SecurityEventRequest) (context.Context, error) {
+	analysis, rejection := i.guard.Check(ctx, event)
+	if rejection != nil {
+		err := connect.NewError(errorCode(rejection.Status), errors.New(rejection.Message))
+		for name, value := range rejection.Headers {
+			err.Meta().Set(name, value)
+		}
+		return ctx, err
+	}
+	return guardial.NewContext(ctx, event, analysis), nil
+}
+
+func requestParts(method, procedure, remoteAddr string, header http.Header, message interface{}) guardial.RequestParts {
+	headers := make(map[string]string, len(header))
+	for name, values := range header {
+		if len(values) > 0 {
+			headers[name] = values[0]
+		}
+	}
+	parts := guardial.RequestParts{
+		Method:     method,
+		Path:       procedure,
+		RemoteAddr: remoteAddr,
+		Headers:    headers,
+	}
+	if message != nil {
+		parts.Body = marshalMessage(message)
+	}
+	return parts
+}
+
+// marshalMessage serializes a request message as JSON so detections can read its fields
+func marshalMessage(message interface{}) []byte {
+	if pm, ok := message.(proto.Message); ok {
+		if data, err := protojson.Marshal(pm); err == nil {
+			return data
+		}
+	}
+	data, _ := json.Marshal(message)
+	return data
+}
+
+// errorCode maps a rejection's HTTP status onto a connect code
+func errorCode(httpStatus int) connect.Code {
+	switch httpStatus {
+	case http.StatusForbidden:
+		return connect.CodePermissionDenied
+	case http.StatusUnauthorized:
+		return connect.CodeUnauthenticated
+	case http.StatusTooManyRequests:
+		return connect.CodeResourceExhausted
+	case http.StatusServiceUnavailable:
+		return connect.CodeUnavailable
+	default:
+		return connect.CodeInternal
+	}
+}
@@ -0,0 +1,76 @@
+/**
+ * Guardial Go SDK Echo Adapter
+ * Native echo.MiddlewareFunc middleware
+ */
+
+// Package guardialecho provides Guardial middleware for the Echo web framework.
+//
+//	e := echo.New()
+//	e.Use(guardialecho.Middleware(client, nil))
+package guardialecho
+
+import (
+	"context"
+	"net/http"
+
+	guardial "github.com/divyankvijayvergiya/guardial-sdk"
+	"github.com/labstack/echo/v4"
+)
+
+// AnalysisKey is the echo context key holding the request's *guardial.SecurityEventResponse
+const AnalysisKey = "guardial.analysis"
+
+type rejectionKey struct{}
+
+// Middleware returns an echo.MiddlewareFunc that analyzes each request and stores the
+// verdict under AnalysisKey. Refused requests return an *echo.HTTPError, so they are
+// rendered by the Echo instance's HTTPErrorHandler like any other error.
+func Middleware(client *guardial.Client, options *guardial.MiddlewareOptions) echo.MiddlewareFunc {
+	guard := guardial.NewGuard(client, options)
+	guard.OnReject(func(w http.ResponseWriter, r *http.Request, rejection guardial.Rejection) {
+		if slot, ok := r.Context().Value(rejectionKey{}).(*guardial.Rejection); ok {
+			*slot = rejection
+		}
+	})
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			var rejection guardial.Rejection
+			var err error
+			r := c.Request().WithContext(context.WithValue(c.Request().Context(), rejectionKey{}, &rejection))
+
+			guard.Serve(c.Response(), r, func(w http.ResponseWriter, r *http.Request) {
+				c.SetRequest(r)
+				if analysis := guardial.FromContext(r.Context()); analysis != nil {
+					c.Set(AnalysisKey, analysis)
+				}
+				if w != http.ResponseWriter(c.Response()) {
+					// The guard rewrites this response (degradation, canaries); route
+					// Echo's writes through it
+					original := c.Response()
+					c.SetResponse(echo.NewResponse(w, c.Echo()))
+					defer c.SetResponse(original)
+				}
+				if err = next(c); err != nil {
+					// Render the error now so it passes through the guard's writer too;
+					// Echo skips committed responses when the error reaches it again
+					c.Error(err)
+				}
+			})
+
+			if rejection.Status != 0 {
+				if rejection.Analysis != nil {
+					c.Set(AnalysisKey, rejection.Analysis)
+				}
+				return echo.NewHTTPError(rejection.Status, rejection.Message)
+			}
+			return err
+		}
+	}
+}
+
+// Analysis returns the verdict stored by Middleware, or nil
+func Analysis(c echo.Context) *guardial.SecurityEventResponse {
+	analysis, _ := c.Get(AnalysisKey).(*guardial.SecurityEventResponse)
+	return analysis
+}
@@ -0,0 +1,65 @@
+/**
+ * Guardial Go SDK Fiber Adapter
+ * Raw fasthttp.RequestHandler wrapper
+ */
+
+package guardialfiber
+
+import (
+	"encoding/json"
+
+	guardial "github.com/divyankvijayvergiya/guardial-sdk"
+	"github.com/valyala/fasthttp"
+)
+
+// Handler wraps a fasthttp.RequestHandler, analyzing each request before next runs. The
+// verdict is stored as the user value AnalysisKey; refused requests get a JSON error.
+func Handler(client *guardial.Client, options *guardial.MiddlewareOptions, next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	guard := guardial.NewGuard(client, options)
+
+	return func(ctx *fasthttp.RequestCtx) {
+		event := client.NewEvent(requestParts(ctx))
+		analysis, rejection := guard.Check(ctx, event)
+		if analysis != nil {
+			ctx.SetUserValue(AnalysisKey, analysis)
+		}
+		if rejection != nil {
+			body, _ := json.Marshal(map[string]string{"error": rejection.Message})
+			for name, value := range rejection.Headers {
+				ctx.Response.Header.Set(name, value)
+			}
+			ctx.SetStatusCode(rejection.Status)
+			ctx.SetContentType("application/json")
+			ctx.SetBody(body)
+			return
+		}
+		next(ctx)
+	}
+}
+
+// AnalysisFromRequestCtx returns the verdict stored by Handler, or nil
+func AnalysisFromRequestCtx(ctx *fasthttp.RequestCtx) *guardial.SecurityEventResponse {
+	analysis, _ := ctx.UserValue(AnalysisKey).(*guardial.SecurityEventResponse)
+	return analysis
+}
+
+// requestParts reads the request straight from fasthttp's types
+func requestParts(ctx *fasthttp.RequestCtx) guardial.RequestParts {
+	headers := make(map[string]string)
+	// fasthttp normalizes header names to canonical form
+	ctx.Request.Header.VisitAll(func(key, value []byte) {
+		name := string(key)
+		if _, ok := headers[name]; !ok {
+			headers[name] = string(value)
+		}
+	})
+
+	return guardial.RequestParts{
+		Method:     string(ctx.Method()),
+		Path:       string(ctx.Path()),
+		RawQuery:   string(ctx.URI().QueryString()),
+		RemoteAddr: ctx.RemoteAddr().String(),
+		Headers:    headers,
+		Body:       ctx.PostBody(),
+	}
+}
@@ -0,0 +1,51 @@
+/**
+ * Guardial Go SDK Fiber Adapter
+ * Native fiber.Handler middleware
+ */
+
+// Package guardialfiber provides Guardial middleware for Fiber and plain fasthttp. Events
+// are built straight from fasthttp's request types, without converting to net/http.
+//
+//	app := fiber.New()
+//	app.Use(guardialfiber.Middleware(client, nil))
+//
+// Response-rewriting features of the net/http middleware (canaries, degradation, crash
+// telemetry, client fingerprints) are not available here; exclusions, blocklists,
+// sampling, and analysis are.
+package guardialfiber
+
+import (
+	guardial "github.com/divyankvijayvergiya/guardial-sdk"
+	"github.com/gofiber/fiber/v2"
+)
+
+// AnalysisKey is the Locals key holding the request's *guardial.SecurityEventResponse
+const AnalysisKey = "guardial.analysis"
+
+// Middleware returns a fiber.Handler that analyzes each request and stores the verdict
+// in Locals under AnalysisKey. Refused requests return a *fiber.Error, so they are
+// rendered by the app's ErrorHandler.
+func Middleware(client *guardial.Client, options *guardial.MiddlewareOptions) fiber.Handler {
+	guard := guardial.NewGuard(client, options)
+
+	return func(c *fiber.Ctx) error {
+		event := client.NewEvent(requestParts(c.Context()))
+		analysis, rejection := guard.Check(c.UserContext(), event)
+		if analysis != nil {
+			c.Locals(AnalysisKey, analysis)
+		}
+		if rejection != nil {
+			for name, value := range rejection.Headers {
+				c.Set(name, value)
+			}
+			return fiber.NewError(rejection.Status, rejection.Message)
+		}
+		return c.Next()
+	}
+}
+
+// Analysis returns the verdict stored by Middleware, or nil
+func Analysis(c *fiber.Ctx) *guardial.SecurityEventResponse {
+	analysis, _ := c.Locals(AnalysisKey).(*guardial.SecurityEventResponse)
+	return analysis
+}
@@ -0,0 +1,46 @@
+/**
+ * Guardial Go SDK File-System Guard Fuzz Targets
+ * Resolved paths must never escape the configured roots
+ */
+
+package guardialfs
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func FuzzCheck(f *testing.F) {
+	for _, seed := range []string{
+		"",
+		"report.pdf",
+		"../../../../etc/passwd",
+		"a/../../b",
+		"..\\..\\windows\\win.ini",
+		"%2e%2e%2fsecret",
+		"/etc/shadow",
+		"a\x00b",
+	} {
+		f.Add(seed)
+	}
+
+	root := f.TempDir()
+	g := &Guard{Roots: []string{root}}
+	rootPath, err := resolvePath(root)
+	if err != nil {
+		f.Fatalf("failed to resolve root: %v", err)
+	}
+
+	f.Fuzz(func(t *testing.T, name string) {
+		resolved, err := g.Check(context.Background(), filepath.Join(root, name))
+		if err != nil {
+			return
+		}
+		rel, err := filepath.Rel(rootPath, resolved)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			t.Errorf("%q resolved outside the root: %q", name, resolved)
+		}
+	})
+}
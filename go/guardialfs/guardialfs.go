@@ -0,0 +1,187 @@
+/**
+ * Guardial Go SDK File-System Guard
+ * Confines file access to configured roots and flags path traversal
+ */
+
+// Package guardialfs blocks file access outside configured root directories and
+// reports path-traversal attempts that carry request-derived (tainted) paths.
+package guardialfs
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	guardial "github.com/divyankvijayvergiya/guardial-sdk"
+)
+
+// ErrOutsideRoots is returned when a path resolves outside every configured root
+var ErrOutsideRoots = errors.New("guardialfs: path outside allowed roots")
+
+// ErrTraversal is returned by guarded fs.FS values for names containing traversal sequences
+var ErrTraversal = errors.New("guardialfs: path traversal attempt")
+
+// Guard confines file access to a set of root directories
+type Guard struct {
+	Roots     []string                                       // Allowed directories; empty allows any path
+	Client    *guardial.Client                               // Optional: report findings to Guardial
+	OnFinding func(ctx context.Context, f *guardial.Finding) // Optional: called for every finding
+}
+
+// Default is the guard used by the package-level Open function
+var Default = &Guard{}
+
+// SetRoots configures the roots of the Default guard. Call it once at startup.
+func SetRoots(roots ...string) {
+	Default.Roots = roots
+}
+
+// Open opens name for reading through the Default guard
+// Usage: f, err := guardialfs.Open(r.Context(), filepath.Join(uploadDir, name))
+func Open(ctx context.Context, name string) (*os.File, error) {
+	return Default.Open(ctx, name)
+}
+
+// Open opens name for reading if it resolves inside one of the guard's roots
+func (g *Guard) Open(ctx context.Context, name string) (*os.File, error) {
+	resolved, err := g.Check(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(resolved)
+}
+
+// Check resolves name (following symlinks where the file exists) and verifies it
+// stays within the configured roots. It returns the resolved path.
+func (g *Guard) Check(ctx context.Context, name string) (string, error) {
+	requestDerived := guardial.IsTainted(ctx, name)
+	traversal := containsTraversal(name)
+
+	resolved, err := resolvePath(name)
+	if err != nil {
+		return "", &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	if !g.withinRoots(resolved) {
+		severity := "MEDIUM"
+		if requestDerived {
+			severity = "HIGH"
+		}
+		g.report(ctx, name, resolved, severity, "File access outside allowed roots", requestDerived)
+		return "", &fs.PathError{Op: "open", Path: name, Err: ErrOutsideRoots}
+	}
+
+	// Contained, but still worth knowing someone tried
+	if traversal && requestDerived {
+		g.report(ctx, name, resolved, "MEDIUM", "Path traversal attempt contained by file-system guard", requestDerived)
+	}
+
+	return resolved, nil
+}
+
+// FS wraps fsys so names with traversal sequences are rejected and reported.
+// fsys is its own root, so Roots do not apply to the returned file system.
+func (g *Guard) FS(ctx context.Context, fsys fs.FS) fs.FS {
+	return &guardedFS{ctx: ctx, guard: g, fsys: fsys}
+}
+
+type guardedFS struct {
+	ctx   context.Context
+	guard *Guard
+	fsys  fs.FS
+}
+
+// Open implements fs.FS
+func (f *guardedFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) || containsTraversal(name) {
+		severity := "MEDIUM"
+		requestDerived := guardial.IsTainted(f.ctx, name)
+		if requestDerived {
+			severity = "HIGH"
+		}
+		f.guard.report(f.ctx, name, name, severity, "Path traversal attempt against guarded file system", requestDerived)
+		return nil, &fs.PathError{Op: "open", Path: name, Err: ErrTraversal}
+	}
+	return f.fsys.Open(name)
+}
+
+func (g *Guard) withinRoots(resolved string) bool {
+	if len(g.Roots) == 0 {
+		return true
+	}
+
+	for _, root := range g.Roots {
+		rootPath, err := resolvePath(root)
+		if err != nil {
+			continue
+		}
+		rel, err := filepath.Rel(rootPath, resolved)
+		if err != nil {
+			continue
+		}
+		if rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Guard) report(ctx context.Context, name, resolved, severity, title string, requestDerived bool) {
+	finding := &guardial.Finding{
+		Type:     "path_traversal",
+		Title:    title,
+		Severity: severity,
+		Evidence: name,
+		Metadata: map[string]string{
+			"resolved_path":   resolved,
+			"request_derived": strconv.FormatBool(requestDerived),
+		},
+	}
+
+	if g.OnFinding != nil {
+		g.OnFinding(ctx, finding)
+	}
+	if g.Client != nil {
+		// Report in the background so file access is never slowed down by the API
+		g.Client.ReportFindingAsync(ctx, finding)
+	}
+}
+
+// resolvePath returns the absolute, cleaned path with symlinks resolved as far as they exist
+func resolvePath(name string) (string, error) {
+	abs, err := filepath.Abs(name)
+	if err != nil {
+		return "", err
+	}
+
+	// Resolve the longest existing prefix so symlinked directories can't escape a root
+	existing, rest := abs, ""
+	for {
+		if resolved, err := filepath.EvalSymlinks(existing); err == nil {
+			return filepath.Join(resolved, rest), nil
+		}
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			return abs, nil
+		}
+		rest = filepath.Join(filepath.Base(existing), rest)
+		existing = parent
+	}
+}
+
+func containsTraversal(name string) bool {
+	lower := strings.ToLower(name)
+	if strings.ContainsRune(name, 0) || strings.Contains(lower, "%2e%2e") || strings.Contains(lower, "%2f") {
+		return true
+	}
+	for _, segment := range strings.FieldsFunc(name, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if segment == ".." {
+			return true
+		}
+	}
+	return false
+}
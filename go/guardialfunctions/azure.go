@@ -0,0 +1,151 @@
+/**
+ * Guardial Go SDK Serverless Functions Adapter
+ * Azure Functions custom handler support
+ */
+
+package guardialfunctions
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+
+	guardial "github.com/divyankvijayvergiya/guardial-sdk"
+)
+
+// ListenAndServe serves handler on the port the Azure Functions host assigns to custom
+// handlers (FUNCTIONS_CUSTOMHANDLER_PORT). With enableForwardingHttpRequest set in
+// host.json, wrap handler with Handler; otherwise wrap it with InvocationHandler.
+func ListenAndServe(handler http.Handler) error {
+	port := os.Getenv("FUNCTIONS_CUSTOMHANDLER_PORT")
+	if port == "" {
+		port = "8080"
+	}
+	return http.ListenAndServe(net.JoinHostPort("", port), handler)
+}
+
+// invocationRequest is the payload the Azure Functions host sends a custom handler
+// when HTTP requests aren't forwarded as-is
+type invocationRequest struct {
+	Data map[string]json.RawMessage `json:"Data"`
+}
+
+// invocationHTTPRequest is an HTTP trigger binding within an invocation
+type invocationHTTPRequest struct {
+	URL     string              `json:"Url"`
+	Method  string              `json:"Method"`
+	Headers map[string][]string `json:"Headers"`
+	Body    json.RawMessage     `json:"Body"`
+}
+
+// InvocationHandler analyzes the HTTP trigger of each Azure Functions invocation
+// payload before passing the invocation to next. Refused requests are answered through
+// the "res" output binding, so function.json must name its HTTP output binding "res".
+// Invocations without an HTTP trigger pass through unanalyzed.
+func InvocationHandler(options *guardial.MiddlewareOptions, next http.Handler) http.Handler {
+	var mu sync.Mutex
+	var guard *guardial.Guard
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, err := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(payload))
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		req, ok := httpTrigger(payload)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		c, err := Client()
+		if err != nil {
+			if options != nil && !options.FailOpen {
+				writeInvocationResponse(w, &guardial.Rejection{Status: http.StatusInternalServerError, Message: "Security analysis unavailable"})
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+		mu.Lock()
+		if guard == nil {
+			guard = guardial.NewGuard(c, options)
+		}
+		mu.Unlock()
+
+		event := c.NewEvent(requestParts(req))
+		analysis, rejection := guard.Check(r.Context(), event)
+		if rejection != nil {
+			writeInvocationResponse(w, rejection)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(guardial.NewContext(r.Context(), event, analysis)))
+	})
+}
+
+// httpTrigger finds the HTTP trigger binding in an invocation payload
+func httpTrigger(payload []byte) (*invocationHTTPRequest, bool) {
+	var invocation invocationRequest
+	if err := json.Unmarshal(payload, &invocation); err != nil {
+		return nil, false
+	}
+	for _, binding := range invocation.Data {
+		var req invocationHTTPRequest
+		if json.Unmarshal(binding, &req) == nil && req.Method != "" && req.URL != "" {
+			return &req, true
+		}
+	}
+	return nil, false
+}
+
+func requestParts(req *invocationHTTPRequest) guardial.RequestParts {
+	parts := guardial.RequestParts{
+		Method:  req.Method,
+		Headers: make(map[string]string, len(req.Headers)),
+	}
+	if u, err := url.Parse(req.URL); err == nil {
+		parts.Path = u.Path
+		parts.RawQuery = u.RawQuery
+	}
+	for name, values := range req.Headers {
+		if len(values) > 0 {
+			parts.Headers[http.CanonicalHeaderKey(name)] = values[0]
+		}
+	}
+
+	// The host sends text bodies as JSON strings and JSON bodies as objects
+	var text string
+	if json.Unmarshal(req.Body, &text) == nil {
+		parts.Body = []byte(text)
+	} else if len(req.Body) > 0 && string(req.Body) != "null" {
+		parts.Body = req.Body
+	}
+	return parts
+}
+
+// writeInvocationResponse answers an invocation with an HTTP response on the "res"
+// output binding, with the same JSON error body as the net/http middleware
+func writeInvocationResponse(w http.ResponseWriter, rejection *guardial.Rejection) {
+	body, _ := json.Marshal(map[string]string{"error": rejection.Message})
+	headers := map[string]string{"Content-Type": "application/json"}
+	for name, value := range rejection.Headers {
+		headers[name] = value
+	}
+	response, _ := json.Marshal(map[string]interface{}{
+		"Outputs": map[string]interface{}{
+			"res": map[string]interface{}{
+				"statusCode": rejection.Status,
+				"headers":    headers,
+				"body":       string(body),
+			},
+		},
+	})
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(response)
+}
@@ -0,0 +1,102 @@
+/**
+ * Guardial Go SDK Serverless Functions Adapter
+ * Per-instance client caching and HTTP wrappers for Cloud Functions
+ */
+
+// Package guardialfunctions adapts Guardial to Google Cloud Functions and Azure Functions
+// custom handlers. A package-level client is created once per instance and reused by
+// every invocation, so only a cold start pays for connection setup.
+//
+//	func init() {
+//		functions.HTTP("Handle", guardialfunctions.HandlerFunc(nil, handle))
+//	}
+//
+// The client is configured from environment variables (see guardial.NewClientFromEnv)
+// unless SetClient provides one. Avoid AsyncMode: instances may be frozen between
+// invocations with events still queued.
+package guardialfunctions
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	guardial "github.com/divyankvijayvergiya/guardial-sdk"
+)
+
+var (
+	clientMu sync.Mutex
+	client   *guardial.Client
+)
+
+// Client returns the package-level client, creating it from the environment on first
+// use. A failed creation is retried on the next call.
+func Client() (*guardial.Client, error) {
+	clientMu.Lock()
+	defer clientMu.Unlock()
+	if client == nil {
+		c, err := guardial.NewClientFromEnv()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Guardial client: %w", err)
+		}
+		client = c
+	}
+	return client, nil
+}
+
+// SetClient replaces the package-level client, for functions configured in code. Call
+// it before the first invocation.
+func SetClient(c *guardial.Client) {
+	clientMu.Lock()
+	defer clientMu.Unlock()
+	client = c
+}
+
+// Warm creates the package-level client and runs its Warmup. Call it from init so the
+// cold start, which runs with full CPU, pays for DNS and TLS instead of the first
+// request.
+func Warm(ctx context.Context) error {
+	c, err := Client()
+	if err != nil {
+		return err
+	}
+	return c.Warmup(ctx)
+}
+
+// Handler wraps next with the Guardial middleware, built with the package-level client
+// on the first request. If the client can't be created, requests proceed unanalyzed
+// with options.FailOpen and fail with 500 otherwise.
+func Handler(options *guardial.MiddlewareOptions, next http.Handler) http.Handler {
+	if options == nil {
+		options = guardial.DefaultMiddlewareOptions()
+	}
+
+	var mu sync.Mutex
+	var guarded http.Handler
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		if guarded == nil {
+			if c, err := Client(); err == nil {
+				guarded = guardial.StandardMiddleware(c, options)(next)
+			} else if !options.FailOpen {
+				mu.Unlock()
+				http.Error(w, "Security analysis unavailable", http.StatusInternalServerError)
+				return
+			}
+		}
+		h := guarded
+		mu.Unlock()
+
+		if h == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// HandlerFunc wraps a Cloud Functions HTTP entrypoint; see Handler
+func HandlerFunc(options *guardial.MiddlewareOptions, fn http.HandlerFunc) http.HandlerFunc {
+	return Handler(options, fn).ServeHTTP
+}
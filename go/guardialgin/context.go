@@ -0,0 +1,84 @@
+/**
+ * Guardial Go SDK Gin Adapter
+ * Request context and response writer plumbing
+ */
+
+package guardialgin
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ginContextKey struct{}
+
+func withGinContext(ctx context.Context, c *gin.Context) context.Context {
+	return context.WithValue(ctx, ginContextKey{}, c)
+}
+
+func contextFromRequest(r *http.Request) *gin.Context {
+	c, _ := r.Context().Value(ginContextKey{}).(*gin.Context)
+	return c
+}
+
+// responseWriter sends gin's writes through the writer the guard handed downstream,
+// keeping the status and size bookkeeping gin expects
+type responseWriter struct {
+	gin.ResponseWriter
+	w      http.ResponseWriter
+	status int
+	size   int // -1 until the header is sent
+}
+
+func newResponseWriter(original gin.ResponseWriter, w http.ResponseWriter) *responseWriter {
+	return &responseWriter{ResponseWriter: original, w: w, status: http.StatusOK, size: -1}
+}
+
+func (rw *responseWriter) Header() http.Header {
+	return rw.w.Header()
+}
+
+func (rw *responseWriter) WriteHeader(code int) {
+	if code > 0 && !rw.Written() {
+		rw.status = code
+	}
+}
+
+func (rw *responseWriter) WriteHeaderNow() {
+	if !rw.Written() {
+		rw.size = 0
+		rw.w.WriteHeader(rw.status)
+	}
+}
+
+func (rw *responseWriter) Write(data []byte) (int, error) {
+	rw.WriteHeaderNow()
+	n, err := rw.w.Write(data)
+	rw.size += n
+	return n, err
+}
+
+func (rw *responseWriter) WriteString(s string) (int, error) {
+	return rw.Write([]byte(s))
+}
+
+func (rw *responseWriter) Status() int {
+	return rw.status
+}
+
+func (rw *responseWriter) Size() int {
+	return rw.size
+}
+
+func (rw *responseWriter) Written() bool {
+	return rw.size != -1
+}
+
+func (rw *responseWriter) Flush() {
+	rw.WriteHeaderNow()
+	if flusher, ok := rw.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
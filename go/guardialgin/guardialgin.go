@@ -0,0 +1,63 @@
+/**
+ * Guardial Go SDK Gin Adapter
+ * Native gin.HandlerFunc middleware
+ */
+
+// Package guardialgin provides Guardial middleware for the Gin web framework.
+//
+//	router := gin.Default()
+//	router.Use(guardialgin.Middleware(client, nil))
+package guardialgin
+
+import (
+	"net/http"
+
+	guardial "github.com/divyankvijayvergiya/guardial-sdk"
+	"github.com/gin-gonic/gin"
+)
+
+// AnalysisKey is the gin context key holding the request's *guardial.SecurityEventResponse
+const AnalysisKey = "guardial.analysis"
+
+// Middleware returns a gin.HandlerFunc that analyzes each request, aborts refused
+// requests with AbortWithStatusJSON, and stores the verdict under AnalysisKey
+func Middleware(client *guardial.Client, options *guardial.MiddlewareOptions) gin.HandlerFunc {
+	guard := guardial.NewGuard(client, options)
+	guard.OnReject(func(w http.ResponseWriter, r *http.Request, rejection guardial.Rejection) {
+		contextFromRequest(r).AbortWithStatusJSON(rejection.Status, gin.H{"error": rejection.Message})
+	})
+
+	return func(c *gin.Context) {
+		proceeded := false
+		r := c.Request.WithContext(withGinContext(c.Request.Context(), c))
+		guard.Serve(c.Writer, r, func(w http.ResponseWriter, r *http.Request) {
+			proceeded = true
+			c.Request = r
+			if analysis := guardial.FromContext(r.Context()); analysis != nil {
+				c.Set(AnalysisKey, analysis)
+			}
+			if w != http.ResponseWriter(c.Writer) {
+				// The guard rewrites this response (degradation, canaries); route
+				// gin's writes through it
+				original := c.Writer
+				c.Writer = newResponseWriter(original, w)
+				defer func() { c.Writer = original }()
+			}
+			c.Next()
+		})
+		if !proceeded && !c.IsAborted() {
+			// The guard answered without our reject hook (e.g. a canary trap URL)
+			c.Abort()
+		}
+	}
+}
+
+// Analysis returns the verdict stored by Middleware, or nil
+func Analysis(c *gin.Context) *guardial.SecurityEventResponse {
+	value, ok := c.Get(AnalysisKey)
+	if !ok {
+		return nil
+	}
+	analysis, _ := value.(*guardial.SecurityEventResponse)
+	return analysis
+}
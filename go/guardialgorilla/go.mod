@@ -0,0 +1,12 @@
+module github.com/divyankvijayvergiya/guardial-sdk/guardialgorilla
+
+go 1.21
+
+require (
+	github.com/divyankvijayvergiya/guardial-sdk v0.1.0
+	github.com/gorilla/websocket v1.5.1
+)
+
+require golang.org/x/net v0.17.0 // indirect
+
+replace github.com/divyankvijayvergiya/guardial-sdk => ../
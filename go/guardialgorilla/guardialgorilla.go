@@ -0,0 +1,96 @@
+/**
+ * Guardial Go SDK gorilla/websocket Adapter
+ * Connection wrapper that inspects received WebSocket messages
+ */
+
+// Package guardialgorilla inspects messages received on gorilla/websocket connections.
+// The upgrade request itself is analyzed by the Guardial middleware in front of the
+// handler; wrap the upgraded connection to analyze each message as well.
+//
+//	conn, err := guardialgorilla.Upgrade(&upgrader, guard, w, r, nil, nil)
+//	if err != nil {
+//		return
+//	}
+//	defer conn.Close()
+//	for {
+//		_, message, err := conn.ReadMessage() // Refused messages never arrive here
+//		...
+//	}
+package guardialgorilla
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	guardial "github.com/divyankvijayvergiya/guardial-sdk"
+	"github.com/gorilla/websocket"
+)
+
+// closeTimeout bounds the write of the close frame sent for a refused message
+const closeTimeout = time.Second
+
+// Conn is a *websocket.Conn whose reads are inspected. ReadMessage, ReadJSON, and
+// NextReader only return messages the inspector let through.
+type Conn struct {
+	*websocket.Conn
+	inspector *guardial.MessageInspector
+}
+
+// Wrap inspects the messages read from conn with inspector
+func Wrap(conn *websocket.Conn, inspector *guardial.MessageInspector) *Conn {
+	return &Conn{Conn: conn, inspector: inspector}
+}
+
+// Upgrade upgrades r with upgrader and wraps the connection with an inspector from
+// guard, configured by options
+func Upgrade(upgrader *websocket.Upgrader, guard *guardial.Guard, w http.ResponseWriter, r *http.Request, responseHeader http.Header, options *guardial.WebSocketOptions) (*Conn, error) {
+	conn, err := upgrader.Upgrade(w, r, responseHeader)
+	if err != nil {
+		return nil, err
+	}
+	return Wrap(conn, guard.Messages(r, options)), nil
+}
+
+// ReadMessage reads the next message the inspector lets through. A refused message
+// closes the connection with a policy violation and returns guardial.ErrMessageRefused,
+// unless the inspector drops refused messages.
+func (c *Conn) ReadMessage() (messageType int, p []byte, err error) {
+	for {
+		messageType, p, err = c.Conn.ReadMessage()
+		if err != nil {
+			return messageType, p, err
+		}
+		rejection := c.inspector.Inspect(p, messageType == websocket.BinaryMessage)
+		if rejection == nil {
+			return messageType, p, nil
+		}
+		if c.inspector.CloseOnReject() {
+			closeMessage := websocket.FormatCloseMessage(websocket.ClosePolicyViolation, rejection.Message)
+			_ = c.Conn.WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(closeTimeout))
+			_ = c.Conn.Close()
+			return messageType, nil, guardial.ErrMessageRefused
+		}
+	}
+}
+
+// NextReader returns a reader for the next message the inspector lets through. The
+// message is read in full first, since it is analyzed as a whole.
+func (c *Conn) NextReader() (messageType int, r io.Reader, err error) {
+	messageType, p, err := c.ReadMessage()
+	if err != nil {
+		return messageType, nil, err
+	}
+	return messageType, bytes.NewReader(p), nil
+}
+
+// ReadJSON reads the next message the inspector lets through and decodes it into v
+func (c *Conn) ReadJSON(v interface{}) error {
+	_, p, err := c.ReadMessage()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(p, v)
+}
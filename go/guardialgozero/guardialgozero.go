@@ -0,0 +1,33 @@
+/**
+ * Guardial Go SDK go-zero Adapter
+ * Middleware for go-zero rest servers
+ */
+
+// Package guardialgozero provides Guardial middleware for go-zero rest servers.
+//
+//	server := rest.MustNewServer(c.RestConf)
+//	server.Use(guardialgozero.Middleware(client, nil))
+//
+// The whole net/http pipeline runs, including canaries, degradation, and crash
+// telemetry.
+package guardialgozero
+
+import (
+	"net/http"
+
+	guardial "github.com/divyankvijayvergiya/guardial-sdk"
+	"github.com/zeromicro/go-zero/rest"
+)
+
+// Middleware returns a rest.Middleware that analyzes each request and calls next only
+// if it may proceed. Handlers read the verdict with guardial.FromContext. Pass it to
+// server.Use for every route, or to rest.WithMiddlewares for some.
+func Middleware(client *guardial.Client, options *guardial.MiddlewareOptions) rest.Middleware {
+	guard := guardial.NewGuard(client, options)
+
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			guard.Serve(w, r, next)
+		}
+	}
+}
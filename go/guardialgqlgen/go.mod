@@ -0,0 +1,16 @@
+module github.com/divyankvijayvergiya/guardial-sdk/guardialgqlgen
+
+go 1.21
+
+require (
+	github.com/99designs/gqlgen v0.17.45
+	github.com/divyankvijayvergiya/guardial-sdk v0.1.0
+)
+
+require (
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/sosodev/duration v1.2.0 // indirect
+	github.com/vektah/gqlparser/v2 v2.5.11 // indirect
+)
+
+replace github.com/divyankvijayvergiya/guardial-sdk => ../
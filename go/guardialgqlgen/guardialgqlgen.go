@@ -0,0 +1,129 @@
+/**
+ * Guardial Go SDK gqlgen Adapter
+ * gqlgen extension that analyzes each GraphQL operation
+ */
+
+// Package guardialgqlgen provides a Guardial extension for gqlgen servers.
+//
+//	srv := handler.NewDefaultServer(generated.NewExecutableSchema(cfg))
+//	srv.Use(guardialgqlgen.NewExtension(client, &guardial.MiddlewareOptions{
+//		GraphQL: &guardial.GraphQLOptions{BlockIntrospection: true},
+//	}))
+//	http.Handle("/graphql", guardialgqlgen.Handler(srv))
+//
+// Every operation is analyzed on its own, including each entry of a batch and
+// subscriptions over websockets, with its name, type, query, and variables as
+// structured fields. Wrap the server with Handler to give the analysis the HTTP
+// request's path and client address, and refused operations their decision headers.
+package guardialgqlgen
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql"
+	guardial "github.com/divyankvijayvergiya/guardial-sdk"
+)
+
+type (
+	httpRequestKey  struct{}
+	httpResponseKey struct{}
+)
+
+// Handler stores each HTTP request and its response writer in the request context for
+// the extension
+func Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), httpRequestKey{}, r)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, httpResponseKey{}, w)))
+	})
+}
+
+// Extension analyzes GraphQL operations. Refused operations get a GraphQL error
+// response, with the decision headers set if the server is wrapped with Handler and
+// the response hasn't started; they are dropped on websockets. Resolvers read the
+// verdict with guardial.FromContext.
+type Extension struct {
+	client *guardial.Client
+	guard  *guardial.Guard
+}
+
+var (
+	_ graphql.HandlerExtension     = (*Extension)(nil)
+	_ graphql.OperationInterceptor = (*Extension)(nil)
+)
+
+// NewExtension creates an Extension with the given middleware options. Set
+// options.GraphQL to refuse operations by name or type.
+func NewExtension(client *guardial.Client, options *guardial.MiddlewareOptions) *Extension {
+	return &Extension{client: client, guard: guardial.NewGuard(client, options)}
+}
+
+// ExtensionName implements graphql.HandlerExtension
+func (e *Extension) ExtensionName() string {
+	return "GuardialAnalysis"
+}
+
+// Validate implements graphql.HandlerExtension
+func (e *Extension) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+// InterceptOperation implements graphql.OperationInterceptor
+func (e *Extension) InterceptOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	oc := graphql.GetOperationContext(ctx)
+	operation := guardial.NewGraphQLOperation(oc.RawQuery, oc.OperationName, oc.Variables)
+	if oc.Operation != nil {
+		// gqlgen has parsed the document; its answer wins over the SDK's scan
+		operation.OperationName = oc.Operation.Name
+		operation.OperationType = string(oc.Operation.Operation)
+	}
+
+	event := e.client.NewEvent(requestParts(ctx, oc, operation))
+	event.GraphQL = []*guardial.GraphQLOperation{operation}
+	analysis, rejection := e.guard.Check(ctx, event)
+	if rejection != nil {
+		if w, ok := ctx.Value(httpResponseKey{}).(http.ResponseWriter); ok {
+			for name, value := range rejection.Headers {
+				w.Header().Set(name, value)
+			}
+		}
+		return graphql.OneShot(graphql.ErrorResponse(ctx, "%s", rejection.Message))
+	}
+	return next(guardial.NewContext(ctx, event, analysis))
+}
+
+func requestParts(ctx context.Context, oc *graphql.OperationContext, operation *guardial.GraphQLOperation) guardial.RequestParts {
+	parts := guardial.RequestParts{
+		Method:  http.MethodPost,
+		Path:    "/graphql",
+		Headers: make(map[string]string),
+		Body:    requestBody(operation),
+	}
+	header := oc.Headers
+	if r, ok := ctx.Value(httpRequestKey{}).(*http.Request); ok {
+		parts.Method = r.Method
+		parts.Path = r.URL.Path
+		parts.RemoteAddr = r.RemoteAddr
+		if header == nil {
+			header = r.Header
+		}
+	}
+	for name, values := range header {
+		if len(values) > 0 {
+			parts.Headers[name] = values[0]
+		}
+	}
+	return parts
+}
+
+// requestBody rebuilds the GraphQL-over-HTTP body, which gqlgen has already consumed
+func requestBody(operation *guardial.GraphQLOperation) []byte {
+	body, _ := json.Marshal(map[string]interface{}{
+		"query":         operation.Query,
+		"operationName": operation.OperationName,
+		"variables":     operation.Variables,
+	})
+	return body
+}
@@ -0,0 +1,105 @@
+/**
+ * Guardial Go SDK gRPC Adapter
+ * Server interceptors that analyze gRPC calls
+ */
+
+// Package guardialgrpc provides Guardial interceptors for gRPC servers.
+//
+//	server := grpc.NewServer(grpc.UnaryInterceptor(guardialgrpc.UnaryServerInterceptor(client, nil)))
+//
+// Calls are analyzed as POST requests to the full method name ("/pkg.Service/Method"),
+// with metadata as headers and the request message, serialized as JSON, as the body.
+package guardialgrpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/textproto"
+
+	guardial "github.com/divyankvijayvergiya/guardial-sdk"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// UnaryServerInterceptor returns an interceptor that analyzes each unary call and fails
+// refused calls with codes.PermissionDenied, with the decision headers as response
+// metadata. Handlers read the verdict with guardial.FromContext.
+func UnaryServerInterceptor(client *guardial.Client, options *guardial.MiddlewareOptions) grpc.UnaryServerInterceptor {
+	guard := guardial.NewGuard(client, options)
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		event := client.NewEvent(requestParts(ctx, info.FullMethod, req))
+		analysis, rejection := guard.Check(ctx, event)
+		if rejection != nil {
+			grpc.SetHeader(ctx, rejectionMetadata(rejection))
+			return nil, status.Error(statusCode(rejection.Status), rejection.Message)
+		}
+		return handler(guardial.NewContext(ctx, event, analysis), req)
+	}
+}
+
+// requestParts maps a call onto the parts of an HTTP request
+func requestParts(ctx context.Context, fullMethod string, message interface{}) guardial.RequestParts {
+	parts := guardial.RequestParts{
+		Method:  http.MethodPost,
+		Path:    fullMethod,
+		Headers: make(map[string]string),
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		parts.RemoteAddr = p.Addr.String()
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		// Metadata keys are lower case; events use canonical header names
+		for key, values := range md {
+			if len(values) > 0 {
+				parts.Headers[textproto.CanonicalMIMEHeaderKey(key)] = values[0]
+			}
+		}
+	}
+	if message != nil {
+		parts.Body = marshalMessage(message)
+	}
+	return parts
+}
+
+// marshalMessage serializes a request message as JSON so detections can read its fields
+func marshalMessage(message interface{}) []byte {
+	if pm, ok := message.(proto.Message); ok {
+		if data, err := protojson.Marshal(pm); err == nil {
+			return data
+		}
+	}
+	data, _ := json.Marshal(message)
+	return data
+}
+
+// rejectionMetadata carries a rejection's decision headers as gRPC metadata
+func rejectionMetadata(rejection *guardial.Rejection) metadata.MD {
+	md := metadata.MD{}
+	for name, value := range rejection.Headers {
+		md.Set(name, value)
+	}
+	return md
+}
+
+// statusCode maps a rejection's HTTP status onto a gRPC code
+func statusCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
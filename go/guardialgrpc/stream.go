@@ -0,0 +1,105 @@
+/**
+ * Guardial Go SDK gRPC Adapter
+ * Stream interceptor with stream-open and per-message analysis
+ */
+
+package guardialgrpc
+
+import (
+	"context"
+	"math/rand"
+
+	guardial "github.com/divyankvijayvergiya/guardial-sdk"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Verdict modes for inspected stream messages
+const (
+	VerdictPerStream  = "stream"  // A refused message ends the stream with its error
+	VerdictPerMessage = "message" // A refused message is dropped and the stream continues
+)
+
+// StreamOptions configures inspection of messages on a stream. Stream-open metadata is
+// always analyzed.
+type StreamOptions struct {
+	InspectMessages bool    // Analyze messages received from the client
+	SampleRate      float64 // Fraction of messages analyzed (default: 1.0)
+	VerdictMode     string  // VerdictPerStream (default) or VerdictPerMessage
+}
+
+// StreamServerInterceptor returns an interceptor that analyzes each stream when it
+// opens and, with streamOptions.InspectMessages, the messages received on it. Refused
+// streams fail with codes.PermissionDenied, with the decision headers as metadata.
+// Handlers read the stream-open verdict from the stream's context with
+// guardial.FromContext.
+func StreamServerInterceptor(client *guardial.Client, options *guardial.MiddlewareOptions, streamOptions *StreamOptions) grpc.StreamServerInterceptor {
+	guard := guardial.NewGuard(client, options)
+	var opts StreamOptions
+	if streamOptions != nil {
+		opts = *streamOptions
+	}
+	if opts.SampleRate <= 0 {
+		opts.SampleRate = 1
+	}
+	if opts.VerdictMode == "" {
+		opts.VerdictMode = VerdictPerStream
+	}
+
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := ss.Context()
+		event := client.NewEvent(requestParts(ctx, info.FullMethod, nil))
+		analysis, rejection := guard.Check(ctx, event)
+		if rejection != nil {
+			ss.SetHeader(rejectionMetadata(rejection))
+			return status.Error(statusCode(rejection.Status), rejection.Message)
+		}
+
+		return handler(srv, &guardedStream{
+			ServerStream: ss,
+			ctx:          guardial.NewContext(ctx, event, analysis),
+			client:       client,
+			guard:        guard,
+			method:       info.FullMethod,
+			options:      opts,
+		})
+	}
+}
+
+// guardedStream analyzes messages as the handler receives them
+type guardedStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	client  *guardial.Client
+	guard   *guardial.Guard
+	method  string
+	options StreamOptions
+}
+
+// Context returns the stream context carrying the stream-open verdict
+func (s *guardedStream) Context() context.Context {
+	return s.ctx
+}
+
+// RecvMsg receives the next message the stream's verdict mode lets through
+func (s *guardedStream) RecvMsg(m interface{}) error {
+	for {
+		if err := s.ServerStream.RecvMsg(m); err != nil {
+			return err
+		}
+		if !s.options.InspectMessages || (s.options.SampleRate < 1 && rand.Float64() >= s.options.SampleRate) {
+			return nil
+		}
+
+		event := s.client.NewEvent(requestParts(s.ctx, s.method, m))
+		_, rejection := s.guard.Check(s.ctx, event)
+		if rejection == nil {
+			return nil
+		}
+		if s.options.VerdictMode != VerdictPerMessage {
+			// The handler may have sent headers already; trailers still go out with the error
+			s.ServerStream.SetTrailer(rejectionMetadata(rejection))
+			return status.Error(statusCode(rejection.Status), rejection.Message)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+/**
+ * Guardial Go SDK Hertz Adapter
+ * Native app.HandlerFunc middleware for CloudWeGo Hertz
+ */
+
+// Package guardialhertz provides Guardial middleware for CloudWeGo Hertz. Events are
+// built straight from Hertz's request types, without converting to net/http.
+//
+//	h := server.Default()
+//	h.Use(guardialhertz.Middleware(client, nil))
+//
+// Response-rewriting features of the net/http middleware (canaries, degradation, crash
+// telemetry, client fingerprints) are not available here; exclusions, blocklists,
+// sampling, and analysis are.
+package guardialhertz
+
+import (
+	"context"
+	"net/textproto"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/utils"
+	guardial "github.com/divyankvijayvergiya/guardial-sdk"
+)
+
+// AnalysisKey is the RequestContext key holding the request's *guardial.SecurityEventResponse
+const AnalysisKey = "guardial.analysis"
+
+// Middleware returns an app.HandlerFunc that analyzes each request, stores the verdict
+// under AnalysisKey, and aborts refused requests with a JSON error. Handlers can also
+// read the verdict with guardial.FromContext on the context they are passed.
+func Middleware(client *guardial.Client, options *guardial.MiddlewareOptions) app.HandlerFunc {
+	guard := guardial.NewGuard(client, options)
+
+	return func(c context.Context, ctx *app.RequestContext) {
+		event := client.NewEvent(requestParts(ctx))
+		analysis, rejection := guard.Check(c, event)
+		if analysis != nil {
+			ctx.Set(AnalysisKey, analysis)
+		}
+		if rejection != nil {
+			for name, value := range rejection.Headers {
+				ctx.Response.Header.Set(name, value)
+			}
+			ctx.AbortWithStatusJSON(rejection.Status, utils.H{"error": rejection.Message})
+			return
+		}
+		ctx.Next(guardial.NewContext(c, event, analysis))
+	}
+}
+
+// Analysis returns the verdict stored by Middleware, or nil
+func Analysis(ctx *app.RequestContext) *guardial.SecurityEventResponse {
+	value, _ := ctx.Get(AnalysisKey)
+	analysis, _ := value.(*guardial.SecurityEventResponse)
+	return analysis
+}
+
+// requestParts reads the request straight from Hertz's types
+func requestParts(ctx *app.RequestContext) guardial.RequestParts {
+	headers := make(map[string]string)
+	// Hertz can be configured to keep header names as received
+	ctx.Request.Header.VisitAll(func(key, value []byte) {
+		name := textproto.CanonicalMIMEHeaderKey(string(key))
+		if _, ok := headers[name]; !ok {
+			headers[name] = string(value)
+		}
+	})
+
+	return guardial.RequestParts{
+		Method:     string(ctx.Method()),
+		Path:       string(ctx.Path()),
+		RawQuery:   string(ctx.URI().QueryString()),
+		RemoteAddr: ctx.RemoteAddr().String(),
+		Headers:    headers,
+		Body:       ctx.Request.Body(),
+	}
+}
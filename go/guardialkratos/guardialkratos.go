@@ -0,0 +1,118 @@
+/**
+ * Guardial Go SDK Kratos Adapter
+ * Middleware and HTTP filters for Kratos servers
+ */
+
+// Package guardialkratos provides Guardial middleware for Kratos HTTP servers.
+//
+//	srv := http.NewServer(
+//		http.Middleware(guardialkratos.Middleware(client, nil)),
+//	)
+//
+// Middleware runs in the Kratos middleware chain, after routing and binding, and fails
+// refused requests with a Kratos error. Kratos has consumed the body by then, so the
+// bound request message, serialized as JSON, is analyzed instead. Filter runs the full
+// net/http middleware (raw bodies, canaries, degradation, crash telemetry, client
+// fingerprints) as an HTTP filter; prefer it where the raw body matters. Kratos gRPC
+// servers are covered by guardialgrpc.
+package guardialkratos
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	guardial "github.com/divyankvijayvergiya/guardial-sdk"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Middleware returns a Kratos middleware that analyzes each HTTP request, with its
+// bound request message as the body, and fails refused ones with a Kratos error
+// carrying the rejection's status. Bodies are cut to options.MaxBodyBytes. Handlers
+// read the verdict with guardial.FromContext. Calls over other transports pass through.
+func Middleware(client *guardial.Client, options *guardial.MiddlewareOptions) middleware.Middleware {
+	guard := guardial.NewGuard(client, options)
+	limit := guardial.DefaultMiddlewareOptions().MaxBodyBytes
+	if options != nil {
+		limit = options.MaxBodyBytes
+	}
+
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			r, ok := khttp.RequestFromServerContext(ctx)
+			if !ok {
+				return handler(ctx, req)
+			}
+
+			parts, truncated := requestParts(r, req, limit)
+			event := client.NewEvent(parts)
+			event.BodyTruncated = truncated
+			analysis, rejection := guard.Check(ctx, event)
+			if rejection != nil {
+				if tr, ok := transport.FromServerContext(ctx); ok {
+					for name, value := range rejection.Headers {
+						tr.ReplyHeader().Set(name, value)
+					}
+				}
+				return nil, errors.New(rejection.Status, reason(rejection), rejection.Message)
+			}
+			return handler(guardial.NewContext(ctx, event, analysis), req)
+		}
+	}
+}
+
+// Filter returns the net/http middleware as a Kratos HTTP filter, for
+// http.Filter(guardialkratos.Filter(client, nil))
+func Filter(client *guardial.Client, options *guardial.MiddlewareOptions) khttp.FilterFunc {
+	return guardial.StandardMiddleware(client, options)
+}
+
+// reason returns the Kratos error reason for a rejection, e.g. "GUARDIAL_BLOCKLIST"
+func reason(rejection *guardial.Rejection) string {
+	if rejection.Category != "" {
+		return "GUARDIAL_" + strings.ToUpper(rejection.Category)
+	}
+	return "GUARDIAL_REFUSED"
+}
+
+// requestParts describes r, with the bound message cut to limit bytes (0: no limit) as
+// the body; it reports whether the message was cut
+func requestParts(r *khttp.Request, message interface{}, limit int64) (guardial.RequestParts, bool) {
+	parts := guardial.RequestParts{
+		Method:     r.Method,
+		Path:       r.URL.Path,
+		RawQuery:   r.URL.RawQuery,
+		RemoteAddr: r.RemoteAddr,
+		Headers:    make(map[string]string, len(r.Header)),
+	}
+	for name, values := range r.Header {
+		if len(values) > 0 {
+			parts.Headers[name] = values[0]
+		}
+	}
+	if message == nil {
+		return parts, false
+	}
+	parts.Body = marshalMessage(message)
+	if limit > 0 && int64(len(parts.Body)) > limit {
+		parts.Body = parts.Body[:limit]
+		return parts, true
+	}
+	return parts, false
+}
+
+// marshalMessage serializes a request message as JSON so detections can read its fields
+func marshalMessage(message interface{}) []byte {
+	if pm, ok := message.(proto.Message); ok {
+		if data, err := protojson.Marshal(pm); err == nil {
+			return data
+		}
+	}
+	data, _ := json.Marshal(message)
+	return data
+}
@@ -0,0 +1,10 @@
+module github.com/divyankvijayvergiya/guardial-sdk/guardiallambda
+
+go 1.21
+
+require (
+	github.com/aws/aws-lambda-go v1.47.0
+	github.com/divyankvijayvergiya/guardial-sdk v0.1.0
+)
+
+replace github.com/divyankvijayvergiya/guardial-sdk => ../
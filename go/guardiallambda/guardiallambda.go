@@ -0,0 +1,164 @@
+/**
+ * Guardial Go SDK AWS Lambda Adapter
+ * Handler wrappers for API Gateway and ALB events
+ */
+
+// Package guardiallambda analyzes API Gateway and ALB requests before a Lambda
+// handler runs, for functions that can't use net/http middleware.
+//
+//	lambda.Start(guardiallambda.Wrap(client, nil, handler))
+//
+// Refused requests get a JSON 403 proxy response and the handler is not invoked.
+// Handlers read the verdict from their context with guardial.FromContext.
+package guardiallambda
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	guardial "github.com/divyankvijayvergiya/guardial-sdk"
+)
+
+// Wrap guards a handler for API Gateway REST API (payload format 1.0) events
+func Wrap(client *guardial.Client, options *guardial.MiddlewareOptions, handler func(context.Context, events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)) func(context.Context, events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	guard := guardial.NewGuard(client, options)
+
+	return func(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		parts := guardial.RequestParts{
+			Method:     req.HTTPMethod,
+			Path:       req.Path,
+			RawQuery:   rawQuery(req.QueryStringParameters, req.MultiValueQueryStringParameters),
+			RemoteAddr: req.RequestContext.Identity.SourceIP,
+			Headers:    canonicalHeaders(req.Headers),
+			Body:       decodeBody(req.Body, req.IsBase64Encoded),
+		}
+		ctx, rejection := check(ctx, client, guard, parts)
+		if rejection != nil {
+			status, headers, body := rejectionResponse(rejection)
+			return events.APIGatewayProxyResponse{StatusCode: status, Headers: headers, Body: body}, nil
+		}
+		return handler(ctx, req)
+	}
+}
+
+// WrapV2 guards a handler for API Gateway HTTP API (payload format 2.0) events
+func WrapV2(client *guardial.Client, options *guardial.MiddlewareOptions, handler func(context.Context, events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error)) func(context.Context, events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	guard := guardial.NewGuard(client, options)
+
+	return func(ctx context.Context, req events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+		headers := canonicalHeaders(req.Headers)
+		if len(req.Cookies) > 0 {
+			// HTTP APIs move the Cookie header into Cookies
+			headers["Cookie"] = strings.Join(req.Cookies, "; ")
+		}
+		parts := guardial.RequestParts{
+			Method:     req.RequestContext.HTTP.Method,
+			Path:       req.RawPath,
+			RawQuery:   req.RawQueryString,
+			RemoteAddr: req.RequestContext.HTTP.SourceIP,
+			Headers:    headers,
+			Body:       decodeBody(req.Body, req.IsBase64Encoded),
+		}
+		ctx, rejection := check(ctx, client, guard, parts)
+		if rejection != nil {
+			status, headers, body := rejectionResponse(rejection)
+			return events.APIGatewayV2HTTPResponse{StatusCode: status, Headers: headers, Body: body}, nil
+		}
+		return handler(ctx, req)
+	}
+}
+
+// WrapALB guards a handler for Application Load Balancer target group events. ALB
+// events carry no peer address; the client IP comes from X-Forwarded-For.
+func WrapALB(client *guardial.Client, options *guardial.MiddlewareOptions, handler func(context.Context, events.ALBTargetGroupRequest) (events.ALBTargetGroupResponse, error)) func(context.Context, events.ALBTargetGroupRequest) (events.ALBTargetGroupResponse, error) {
+	guard := guardial.NewGuard(client, options)
+
+	return func(ctx context.Context, req events.ALBTargetGroupRequest) (events.ALBTargetGroupResponse, error) {
+		headers := canonicalHeaders(req.Headers)
+		for name, values := range req.MultiValueHeaders {
+			if len(values) > 0 {
+				headers[textproto.CanonicalMIMEHeaderKey(name)] = values[0]
+			}
+		}
+		parts := guardial.RequestParts{
+			Method:   req.HTTPMethod,
+			Path:     req.Path,
+			RawQuery: rawQuery(req.QueryStringParameters, req.MultiValueQueryStringParameters),
+			Headers:  headers,
+			Body:     decodeBody(req.Body, req.IsBase64Encoded),
+		}
+		ctx, rejection := check(ctx, client, guard, parts)
+		if rejection != nil {
+			status, headers, body := rejectionResponse(rejection)
+			return events.ALBTargetGroupResponse{
+				StatusCode:        status,
+				StatusDescription: http.StatusText(status),
+				Headers:           headers,
+				Body:              body,
+			}, nil
+		}
+		return handler(ctx, req)
+	}
+}
+
+// check analyzes the request, returning the context carrying the verdict or the
+// rejection to answer with
+func check(ctx context.Context, client *guardial.Client, guard *guardial.Guard, parts guardial.RequestParts) (context.Context, *guardial.Rejection) {
+	event := client.NewEvent(parts)
+	analysis, rejection := guard.Check(ctx, event)
+	if rejection != nil {
+		return ctx, rejection
+	}
+	return guardial.NewContext(ctx, event, analysis), nil
+}
+
+// rejectionResponse renders a rejection like the net/http middleware does
+func rejectionResponse(rejection *guardial.Rejection) (int, map[string]string, string) {
+	body, _ := json.Marshal(map[string]string{"error": rejection.Message})
+	headers := map[string]string{"Content-Type": "application/json"}
+	for name, value := range rejection.Headers {
+		headers[name] = value
+	}
+	return rejection.Status, headers, string(body)
+}
+
+// canonicalHeaders copies headers, which API Gateway v2 and ALB send in lower case,
+// under canonical names
+func canonicalHeaders(headers map[string]string) map[string]string {
+	canonical := make(map[string]string, len(headers))
+	for name, value := range headers {
+		canonical[textproto.CanonicalMIMEHeaderKey(name)] = value
+	}
+	return canonical
+}
+
+func rawQuery(single map[string]string, multi map[string][]string) string {
+	values := url.Values{}
+	if len(multi) > 0 {
+		for name, vs := range multi {
+			values[name] = vs
+		}
+	} else {
+		for name, v := range single {
+			values.Set(name, v)
+		}
+	}
+	return values.Encode()
+}
+
+func decodeBody(body string, isBase64 bool) []byte {
+	if !isBase64 {
+		return []byte(body)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(body)
+	if err != nil {
+		return []byte(body)
+	}
+	return decoded
+}
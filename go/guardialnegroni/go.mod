@@ -0,0 +1,10 @@
+module github.com/divyankvijayvergiya/guardial-sdk/guardialnegroni
+
+go 1.21
+
+require (
+	github.com/divyankvijayvergiya/guardial-sdk v0.1.0
+	github.com/urfave/negroni/v3 v3.1.1
+)
+
+replace github.com/divyankvijayvergiya/guardial-sdk => ../
@@ -0,0 +1,41 @@
+/**
+ * Guardial Go SDK Negroni Adapter
+ * The Guardial middleware as a negroni.Handler
+ */
+
+// Package guardialnegroni provides Guardial middleware for negroni.
+//
+//	n := negroni.Classic()
+//	n.Use(guardialnegroni.Middleware(client, nil))
+//	n.UseHandler(mux)
+package guardialnegroni
+
+import (
+	"net/http"
+
+	guardial "github.com/divyankvijayvergiya/guardial-sdk"
+	"github.com/urfave/negroni/v3"
+)
+
+// handler runs the Guardial pipeline in a negroni chain
+type handler struct {
+	guard *guardial.Guard
+}
+
+// Middleware returns a negroni.Handler that analyzes each request and calls the rest of
+// the chain only if it may proceed
+func Middleware(client *guardial.Client, options *guardial.MiddlewareOptions) negroni.Handler {
+	return &handler{guard: guardial.NewGuard(client, options)}
+}
+
+// ServeHTTP implements negroni.Handler
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	h.guard.Serve(w, r, func(w http.ResponseWriter, r *http.Request) {
+		// Response analysis, canaries, and crash telemetry wrap the writer; handlers
+		// further down may still expect a negroni.ResponseWriter
+		if _, ok := w.(negroni.ResponseWriter); !ok {
+			w = negroni.NewResponseWriter(w)
+		}
+		next(w, r)
+	})
+}
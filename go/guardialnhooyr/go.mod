@@ -0,0 +1,10 @@
+module github.com/divyankvijayvergiya/guardial-sdk/guardialnhooyr
+
+go 1.21
+
+require (
+	github.com/divyankvijayvergiya/guardial-sdk v0.1.0
+	nhooyr.io/websocket v1.8.10
+)
+
+replace github.com/divyankvijayvergiya/guardial-sdk => ../
@@ -0,0 +1,101 @@
+/**
+ * Guardial Go SDK nhooyr.io/websocket Adapter
+ * Connection wrapper that inspects received WebSocket messages
+ */
+
+// Package guardialnhooyr inspects messages received on nhooyr.io/websocket connections.
+// The upgrade request itself is analyzed by the Guardial middleware in front of the
+// handler; wrap the accepted connection to analyze each message as well.
+//
+//	conn, err := guardialnhooyr.Accept(guard, w, r, nil, nil)
+//	if err != nil {
+//		return
+//	}
+//	defer conn.CloseNow()
+//	for {
+//		_, message, err := conn.Read(ctx) // Refused messages never arrive here
+//		...
+//	}
+//
+// wsjson.Read takes the underlying *websocket.Conn and bypasses inspection; use
+// Conn.ReadJSON instead.
+package guardialnhooyr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	guardial "github.com/divyankvijayvergiya/guardial-sdk"
+	"nhooyr.io/websocket"
+)
+
+// maxCloseReason is the longest close reason a close frame can carry
+const maxCloseReason = 123
+
+// Conn is a *websocket.Conn whose reads are inspected. Read, Reader, and ReadJSON
+// only return messages the inspector let through.
+type Conn struct {
+	*websocket.Conn
+	inspector *guardial.MessageInspector
+}
+
+// Wrap inspects the messages read from conn with inspector
+func Wrap(conn *websocket.Conn, inspector *guardial.MessageInspector) *Conn {
+	return &Conn{Conn: conn, inspector: inspector}
+}
+
+// Accept accepts the WebSocket handshake in r and wraps the connection with an
+// inspector from guard, configured by options
+func Accept(guard *guardial.Guard, w http.ResponseWriter, r *http.Request, acceptOptions *websocket.AcceptOptions, options *guardial.WebSocketOptions) (*Conn, error) {
+	conn, err := websocket.Accept(w, r, acceptOptions)
+	if err != nil {
+		return nil, err
+	}
+	return Wrap(conn, guard.Messages(r, options)), nil
+}
+
+// Read reads the next message the inspector lets through. A refused message closes
+// the connection with a policy violation and returns guardial.ErrMessageRefused,
+// unless the inspector drops refused messages.
+func (c *Conn) Read(ctx context.Context) (websocket.MessageType, []byte, error) {
+	for {
+		messageType, p, err := c.Conn.Read(ctx)
+		if err != nil {
+			return messageType, p, err
+		}
+		rejection := c.inspector.Inspect(p, messageType == websocket.MessageBinary)
+		if rejection == nil {
+			return messageType, p, nil
+		}
+		if c.inspector.CloseOnReject() {
+			reason := rejection.Message
+			if len(reason) > maxCloseReason {
+				reason = reason[:maxCloseReason]
+			}
+			_ = c.Conn.Close(websocket.StatusPolicyViolation, reason)
+			return messageType, nil, guardial.ErrMessageRefused
+		}
+	}
+}
+
+// Reader returns a reader for the next message the inspector lets through. The
+// message is read in full first, since it is analyzed as a whole.
+func (c *Conn) Reader(ctx context.Context) (websocket.MessageType, io.Reader, error) {
+	messageType, p, err := c.Read(ctx)
+	if err != nil {
+		return messageType, nil, err
+	}
+	return messageType, bytes.NewReader(p), nil
+}
+
+// ReadJSON reads the next message the inspector lets through and decodes it into v
+func (c *Conn) ReadJSON(ctx context.Context, v interface{}) error {
+	_, p, err := c.Read(ctx)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(p, v)
+}
@@ -0,0 +1,7 @@
+module github.com/divyankvijayvergiya/guardial-sdk/guardialtraefik
+
+go 1.21
+
+require github.com/divyankvijayvergiya/guardial-sdk v0.1.0
+
+replace github.com/divyankvijayvergiya/guardial-sdk => ../
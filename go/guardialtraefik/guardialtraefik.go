@@ -0,0 +1,134 @@
+/**
+ * Guardial Go SDK Traefik Plugin
+ * A Traefik middleware plugin enforcing Guardial at the ingress
+ */
+
+// Package guardialtraefik is a Traefik middleware plugin, so platform teams can enforce
+// Guardial at the ingress for every backend, whatever it is written in. Traefik runs it
+// in Yaegi, its Go interpreter; it uses the standard library and the SDK only.
+//
+// Static configuration:
+//
+//	experimental:
+//	  plugins:
+//	    guardial:
+//	      moduleName: github.com/divyankvijayvergiya/guardial-sdk/guardialtraefik
+//	      version: v0.1.0
+//
+// Dynamic configuration:
+//
+//	http:
+//	  middlewares:
+//	    guardial:
+//	      plugin:
+//	        guardial:
+//	          apiKey: your-api-key # default: GUARDIAL_API_KEY
+//	          mode: monitor
+//	          excludePaths: ["/health", "GET,HEAD /static/**"]
+package guardialtraefik
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	guardial "github.com/divyankvijayvergiya/guardial-sdk"
+)
+
+// Config is the plugin's dynamic configuration
+type Config struct {
+	APIKey     string `json:"apiKey,omitempty"`     // Guardial API key (default: GUARDIAL_API_KEY)
+	Endpoint   string `json:"endpoint,omitempty"`   // Guardial API endpoint (default: the SDK's)
+	CustomerID string `json:"customerId,omitempty"` // Customer ID sent with events
+	Debug      bool   `json:"debug,omitempty"`      // Log SDK debug output
+
+	// Mode is "enforce" (the default) to refuse blocked requests, or "monitor" to only
+	// record verdicts
+	Mode string `json:"mode,omitempty"`
+
+	// FailClosed also refuses requests when analysis fails, instead of letting them
+	// proceed unanalyzed. Blocked requests are refused either way, unless Mode is
+	// "monitor".
+	FailClosed bool `json:"failClosed,omitempty"`
+
+	// ExcludePaths replaces the default path exclusions (/health, /favicon.ico); entries
+	// are as in MiddlewareOptions.ExcludePaths
+	ExcludePaths []string `json:"excludePaths,omitempty"`
+
+	SampleRate   float64 `json:"sampleRate,omitempty"`   // Fraction of requests analyzed (default: 1)
+	MaxBodyBytes int64   `json:"maxBodyBytes,omitempty"` // Request body captured per event (default: 1 MB)
+}
+
+// CreateConfig returns the default plugin configuration
+func CreateConfig() *Config {
+	return &Config{}
+}
+
+// clients shares one client per API key and endpoint. Traefik calls New again for every
+// router using the middleware and on every configuration reload, and has no hook to
+// close a plugin, so clients live as long as the process.
+var clients = struct {
+	mu sync.Mutex
+	m  map[string]*guardial.Client
+}{m: make(map[string]*guardial.Client)}
+
+// New creates the middleware named name in front of next
+func New(ctx context.Context, next http.Handler, config *Config, name string) (http.Handler, error) {
+	switch guardial.Mode(config.Mode) {
+	case "", guardial.ModeEnforce, guardial.ModeMonitor:
+	default:
+		return nil, fmt.Errorf("guardial middleware %s: unknown mode %q (want enforce or monitor)", name, config.Mode)
+	}
+	if config.SampleRate < 0 || config.SampleRate > 1 {
+		return nil, fmt.Errorf("guardial middleware %s: sampleRate must be between 0 and 1, got %v", name, config.SampleRate)
+	}
+
+	client, err := sharedClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("guardial middleware %s: %w", name, err)
+	}
+
+	options := guardial.DefaultMiddlewareOptions()
+	options.Mode = guardial.Mode(config.Mode)
+	options.FailOpen = !config.FailClosed
+	if len(config.ExcludePaths) > 0 {
+		options.ExcludePaths = config.ExcludePaths
+	}
+	if config.SampleRate > 0 {
+		options.SampleRate = config.SampleRate
+	}
+	if config.MaxBodyBytes > 0 {
+		options.MaxBodyBytes = config.MaxBodyBytes
+	}
+	return guardial.StandardMiddleware(client, options)(next), nil
+}
+
+// sharedClient returns the client for config's API key and endpoint, creating it once
+func sharedClient(config *Config) (*guardial.Client, error) {
+	clientConfig := guardial.DefaultConfig()
+	clientConfig.APIKey = config.APIKey
+	if clientConfig.APIKey == "" {
+		clientConfig.APIKey = os.Getenv("GUARDIAL_API_KEY")
+	}
+	if clientConfig.APIKey == "" {
+		return nil, fmt.Errorf("apiKey is required (or set GUARDIAL_API_KEY)")
+	}
+	if config.Endpoint != "" {
+		clientConfig.Endpoint = config.Endpoint
+	}
+	clientConfig.CustomerID = config.CustomerID
+	clientConfig.Debug = config.Debug
+
+	key := strings.Join([]string{clientConfig.APIKey, clientConfig.Endpoint, clientConfig.CustomerID}, "\x00")
+	clients.mu.Lock()
+	defer clients.mu.Unlock()
+	client, ok := clients.m[key]
+	if !ok {
+		client = guardial.NewClient(clientConfig)
+		clients.m[key] = client
+	}
+	return client, nil
+}
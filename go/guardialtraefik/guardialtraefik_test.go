@@ -0,0 +1,50 @@
+package guardialtraefik
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewRefusesBlocked(t *testing.T) {
+	tests := []struct {
+		name       string
+		verdict    string // "" fails analysis
+		config     Config
+		wantStatus int
+	}{
+		{"allowed", `{"allowed":true,"action":"allow"}`, Config{}, http.StatusOK},
+		{"blocked", `{"allowed":false,"action":"block","risk_score":95}`, Config{}, http.StatusForbidden},
+		{"blocked in monitor mode", `{"allowed":false,"action":"block","risk_score":95}`, Config{Mode: "monitor"}, http.StatusOK},
+		{"analysis failure", "", Config{}, http.StatusOK},
+		{"analysis failure, fail closed", "", Config{FailClosed: true}, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.verdict == "" {
+					http.Error(w, "unavailable", http.StatusServiceUnavailable)
+					return
+				}
+				w.Write([]byte(tt.verdict))
+			}))
+			defer api.Close()
+
+			config := tt.config
+			config.APIKey = "test"
+			config.Endpoint = api.URL
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+			handler, err := New(context.Background(), next, &config, "guardial")
+			if err != nil {
+				t.Fatalf("New() = %v", err)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest("POST", "/api/orders", nil))
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
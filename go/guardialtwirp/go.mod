@@ -0,0 +1,13 @@
+module github.com/divyankvijayvergiya/guardial-sdk/guardialtwirp
+
+go 1.21
+
+require (
+	github.com/divyankvijayvergiya/guardial-sdk v0.1.0
+	github.com/twitchtv/twirp v8.1.3+incompatible
+	google.golang.org/protobuf v1.33.0
+)
+
+require github.com/pkg/errors v0.9.1 // indirect
+
+replace github.com/divyankvijayvergiya/guardial-sdk => ../
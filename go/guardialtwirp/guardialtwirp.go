@@ -0,0 +1,113 @@
+/**
+ * Guardial Go SDK Twirp Adapter
+ * Twirp interceptor that analyzes RPCs with their decoded payloads
+ */
+
+// Package guardialtwirp provides a Guardial interceptor for Twirp services.
+//
+//	server := usersv1.NewUsersServer(svc,
+//		twirp.WithServerInterceptors(guardialtwirp.Interceptor(client, nil)))
+//	http.Handle(server.PathPrefix(), guardialtwirp.Handler(server))
+//
+// Twirp interceptors see the decoded request but not the HTTP request, so wrap the
+// server with Handler to give the analysis its headers and client address.
+package guardialtwirp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	guardial "github.com/divyankvijayvergiya/guardial-sdk"
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+type httpRequestKey struct{}
+
+// Handler stores each HTTP request in its context for Interceptor
+func Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), httpRequestKey{}, r)))
+	})
+}
+
+// Interceptor returns a twirp.Interceptor that analyzes each call with its decoded
+// request message as the body. Refused calls fail with twirp.PermissionDenied, with the
+// decision headers on the response. Methods read the verdict with guardial.FromContext.
+func Interceptor(client *guardial.Client, options *guardial.MiddlewareOptions) twirp.Interceptor {
+	guard := guardial.NewGuard(client, options)
+
+	return func(next twirp.Method) twirp.Method {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			event := client.NewEvent(requestParts(ctx, req))
+			analysis, rejection := guard.Check(ctx, event)
+			if rejection != nil {
+				for name, value := range rejection.Headers {
+					twirp.SetHTTPResponseHeader(ctx, name, value)
+				}
+				return nil, twirp.NewError(errorCode(rejection.Status), rejection.Message)
+			}
+			return next(guardial.NewContext(ctx, event, analysis), req)
+		}
+	}
+}
+
+func requestParts(ctx context.Context, message interface{}) guardial.RequestParts {
+	parts := guardial.RequestParts{
+		Method:  http.MethodPost,
+		Headers: make(map[string]string),
+		Body:    marshalMessage(message),
+	}
+
+	if r, ok := ctx.Value(httpRequestKey{}).(*http.Request); ok {
+		parts.Method = r.Method
+		parts.Path = r.URL.Path
+		parts.RawQuery = r.URL.RawQuery
+		parts.RemoteAddr = r.RemoteAddr
+		for name, values := range r.Header {
+			if len(values) > 0 {
+				parts.Headers[name] = values[0]
+			}
+		}
+		return parts
+	}
+
+	// Without Handler, rebuild the route from the names Twirp puts in the context
+	pkg, _ := twirp.PackageName(ctx)
+	service, _ := twirp.ServiceName(ctx)
+	method, _ := twirp.MethodName(ctx)
+	if pkg != "" {
+		service = pkg + "." + service
+	}
+	parts.Path = "/twirp/" + service + "/" + method
+	return parts
+}
+
+// marshalMessage serializes a request message as JSON so detections can read its fields
+func marshalMessage(message interface{}) []byte {
+	if pm, ok := message.(proto.Message); ok {
+		if data, err := protojson.Marshal(pm); err == nil {
+			return data
+		}
+	}
+	data, _ := json.Marshal(message)
+	return data
+}
+
+// errorCode maps a rejection's HTTP status onto a Twirp error code
+func errorCode(httpStatus int) twirp.ErrorCode {
+	switch httpStatus {
+	case http.StatusForbidden:
+		return twirp.PermissionDenied
+	case http.StatusUnauthorized:
+		return twirp.Unauthenticated
+	case http.StatusTooManyRequests:
+		return twirp.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		return twirp.Unavailable
+	default:
+		return twirp.Internal
+	}
+}
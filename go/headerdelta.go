@@ -0,0 +1,168 @@
+/**
+ * Guardial Go SDK Header Delta Encoding
+ * Sends a client's header set once, then only a reference plus changes
+ */
+
+package guardial
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// maxHeaderSets bounds the number of clients whose header sets are remembered
+const maxHeaderSets = 10000
+
+// headerSet is the base header map sent in full for one client
+type headerSet struct {
+	id        string
+	headers   map[string]string
+	confirmed bool // The backend accepted an event carrying the full set
+}
+
+// headerSetCache remembers the base header set of each client (session, source IP, and
+// user agent). Events reference a base only once the backend has accepted it, so
+// concurrent events never point at a set still in flight.
+type headerSetCache struct {
+	mu   sync.Mutex
+	sets map[string]*headerSet
+}
+
+// headerSetKey identifies the client an event came from
+func headerSetKey(event *SecurityEventRequest) string {
+	return event.SessionID + "|" + event.SourceIP + "|" + event.UserAgent
+}
+
+// headerSetID is a content hash of headers, so identical sets share an ID
+func headerSetID(headers map[string]string) string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	hash := sha256.New()
+	for _, name := range names {
+		hash.Write([]byte(name))
+		hash.Write([]byte{0})
+		hash.Write([]byte(headers[name]))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil)[:8])
+}
+
+// encode rewrites wire.Headers as a delta against the client's confirmed base set, or
+// marks it as a new base with HeadersID. wire.Headers is replaced, never modified.
+func (hc *headerSetCache) encode(wire *SecurityEventRequest) {
+	if len(wire.Headers) == 0 {
+		return
+	}
+	key := headerSetKey(wire)
+
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	if hc.sets == nil {
+		hc.sets = make(map[string]*headerSet)
+	}
+
+	if base := hc.sets[key]; base != nil && base.confirmed {
+		changed := make(map[string]string)
+		for name, value := range wire.Headers {
+			if baseValue, ok := base.headers[name]; !ok || baseValue != value {
+				changed[name] = value
+			}
+		}
+		var removed []string
+		for name := range base.headers {
+			if _, ok := wire.Headers[name]; !ok {
+				removed = append(removed, name)
+			}
+		}
+
+		// A delta larger than half the set means the client's headers moved on; start a
+		// new base instead
+		if len(changed)+len(removed) <= len(wire.Headers)/2 {
+			sort.Strings(removed)
+			wire.Headers = changed
+			wire.HeadersRef = base.id
+			wire.HeadersRemoved = removed
+			return
+		}
+	}
+
+	id := headerSetID(wire.Headers)
+	if base := hc.sets[key]; base == nil || base.id != id {
+		if _, exists := hc.sets[key]; !exists && len(hc.sets) >= maxHeaderSets {
+			for k := range hc.sets {
+				delete(hc.sets, k)
+				break
+			}
+		}
+		headers := make(map[string]string, len(wire.Headers))
+		for name, value := range wire.Headers {
+			headers[name] = value
+		}
+		hc.sets[key] = &headerSet{id: id, headers: headers}
+	}
+	wire.HeadersID = id
+}
+
+// confirm marks the base sets carried in full by wire as known to the backend
+func (hc *headerSetCache) confirm(wire []*SecurityEventRequest) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	for _, event := range wire {
+		if event.HeadersID == "" {
+			continue
+		}
+		if base := hc.sets[headerSetKey(event)]; base != nil && base.id == event.HeadersID {
+			base.confirmed = true
+		}
+	}
+}
+
+// reset forgets every base set, so the next event of each client carries full headers
+func (hc *headerSetCache) reset() {
+	hc.mu.Lock()
+	hc.sets = nil
+	hc.mu.Unlock()
+}
+
+// isUnknownHeaderSet reports whether the backend rejected a HeadersRef it doesn't hold,
+// e.g. after a restart
+func isUnknownHeaderSet(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusPreconditionFailed
+}
+
+// singleEvent is the postEvents payload for /api/events
+func singleEvent(wire []*SecurityEventRequest) interface{} {
+	return wire[0]
+}
+
+// postEvents sends the wire form of events to path as payload(wire). If the backend
+// lost a referenced header set, the events are resent once with full headers.
+func (c *Client) postEvents(ctx context.Context, path string, events []*SecurityEventRequest, payload func(wire []*SecurityEventRequest) interface{}, out interface{}) error {
+	for attempt := 0; ; attempt++ {
+		wire := make([]*SecurityEventRequest, len(events))
+		for i, event := range events {
+			wire[i] = c.wireEvent(event)
+		}
+
+		err := c.postJSON(ctx, path, payload(wire), out)
+		if err == nil {
+			c.headerSets.confirm(wire)
+			return nil
+		}
+		if attempt > 0 || !isUnknownHeaderSet(err) {
+			return err
+		}
+		c.log("⚠️ Backend lost header sets, resending events with full headers")
+		c.headerSets.reset()
+	}
+}
@@ -0,0 +1,85 @@
+/**
+ * Guardial Go SDK Security Response Headers
+ * Opt-in verdict headers and standard hardening headers on responses
+ */
+
+package guardial
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// HeaderRiskScore carries the verdict's risk score when SecurityHeaderOptions.Verdict is set
+const HeaderRiskScore = "X-Guardial-Risk-Score"
+
+// SecurityHeaderOptions adds headers to responses. Headers the handler sets itself
+// take precedence, since these are set before it runs.
+type SecurityHeaderOptions struct {
+	// Verdict adds X-Guardial-Event-Id, X-Guardial-Action, and X-Guardial-Risk-Score to
+	// the responses of analyzed requests. They reveal detection results to the caller,
+	// so enable them only where that is acceptable (internal APIs, staging).
+	Verdict bool
+
+	// Hardening headers; empty values are left unset
+	StrictTransportSecurity string // e.g. "max-age=31536000; includeSubDomains"; HTTPS requests only
+	ContentTypeOptions      string // "nosniff"
+	FrameOptions            string // "DENY" or "SAMEORIGIN"
+	ReferrerPolicy          string // e.g. "strict-origin-when-cross-origin"
+	ContentSecurityPolicy   string // e.g. "default-src 'self'"
+}
+
+// DefaultSecurityHeaders returns a conservative hardening set without verdict headers
+func DefaultSecurityHeaders() *SecurityHeaderOptions {
+	return &SecurityHeaderOptions{
+		StrictTransportSecurity: "max-age=31536000; includeSubDomains",
+		ContentTypeOptions:      "nosniff",
+		FrameOptions:            "DENY",
+		ReferrerPolicy:          "strict-origin-when-cross-origin",
+	}
+}
+
+// securityHeaders returns the header options for a request: its route's, if set
+func (m *middleware) securityHeaders(r *http.Request) *SecurityHeaderOptions {
+	if rule := m.route(r.Method, r.URL.Path); rule != nil && rule.SecurityHeaders != nil {
+		return rule.SecurityHeaders
+	}
+	return m.options.SecurityHeaders
+}
+
+// hardenResponse sets the hardening headers for r, on every response including refusals
+func (m *middleware) hardenResponse(w http.ResponseWriter, r *http.Request) {
+	options := m.securityHeaders(r)
+	if options == nil {
+		return
+	}
+	header := w.Header()
+	if options.StrictTransportSecurity != "" && (r.TLS != nil || strings.EqualFold(r.Header.Get("X-Forwarded-Proto"), "https")) {
+		header.Set("Strict-Transport-Security", options.StrictTransportSecurity)
+	}
+	setIfPresent(header, "X-Content-Type-Options", options.ContentTypeOptions)
+	setIfPresent(header, "X-Frame-Options", options.FrameOptions)
+	setIfPresent(header, "Referrer-Policy", options.ReferrerPolicy)
+	setIfPresent(header, "Content-Security-Policy", options.ContentSecurityPolicy)
+}
+
+// verdictHeaders sets the informational verdict headers on the response to an
+// analyzed request
+func (m *middleware) verdictHeaders(w http.ResponseWriter, r *http.Request) {
+	options := m.securityHeaders(r)
+	analysis := FromContext(r.Context())
+	if options == nil || !options.Verdict || analysis == nil {
+		return
+	}
+	header := w.Header()
+	setIfPresent(header, HeaderEventID, analysis.EventID)
+	setIfPresent(header, HeaderAction, analysis.Action)
+	header.Set(HeaderRiskScore, strconv.Itoa(analysis.RiskScore))
+}
+
+func setIfPresent(header http.Header, name, value string) {
+	if value != "" {
+		header.Set(name, value)
+	}
+}
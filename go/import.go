@@ -0,0 +1,267 @@
+/**
+ * Guardial Go SDK Log Import
+ * Converts historical access logs into events for retroactive analysis
+ */
+
+package guardial
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// LogFormat identifies an access log format understood by ImportAccessLogs
+type LogFormat string
+
+// Supported access log formats
+const (
+	LogFormatCombined  LogFormat = "combined"  // Apache/nginx combined log format
+	LogFormatALB       LogFormat = "alb"       // AWS Application Load Balancer access logs
+	LogFormatJSONLines LogFormat = "jsonlines" // One JSON object per line
+)
+
+// ImportResult summarizes an access log import
+type ImportResult struct {
+	Imported int // Events analyzed
+	Skipped  int // Lines that could not be parsed
+	Blocked  int // Events the analysis would have blocked
+}
+
+// maxLogLineSize bounds a single log line; longer lines are skipped
+const maxLogLineSize = 1024 * 1024
+
+// ImportAccessLogs reads historical access logs from r, converts each line into an event,
+// and analyzes them in batches. Unparseable lines are counted and skipped.
+func (c *Client) ImportAccessLogs(ctx context.Context, r io.Reader, format LogFormat) (*ImportResult, error) {
+	parse, err := logLineParser(format)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{}
+	batch := make([]*SecurityEventRequest, 0, c.batchSize())
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		analyses, err := c.AnalyzeEventsContext(ctx, batch)
+		for _, analysis := range analyses {
+			result.Imported++
+			if !analysis.Allowed {
+				result.Blocked++
+			}
+		}
+		batch = batch[:0]
+		return err
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLogLineSize)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		event, err := parse(line)
+		if err != nil {
+			result.Skipped++
+			continue
+		}
+		batch = append(batch, event)
+
+		if len(batch) >= c.batchSize() {
+			if err := flush(); err != nil {
+				return result, fmt.Errorf("failed to import access logs: %w", err)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return result, fmt.Errorf("failed to read access logs: %w", err)
+	}
+	if err := flush(); err != nil {
+		return result, fmt.Errorf("failed to import access logs: %w", err)
+	}
+
+	c.log(fmt.Sprintf("Imported %d events (%d skipped, %d blocked)", result.Imported, result.Skipped, result.Blocked))
+	return result, nil
+}
+
+// ParseAccessLogLine converts a single access log line in the given format into an event
+func ParseAccessLogLine(line string, format LogFormat) (*SecurityEventRequest, error) {
+	parse, err := logLineParser(format)
+	if err != nil {
+		return nil, err
+	}
+	return parse(line)
+}
+
+func logLineParser(format LogFormat) (func(string) (*SecurityEventRequest, error), error) {
+	switch format {
+	case LogFormatCombined:
+		return parseCombinedLogLine, nil
+	case LogFormatALB:
+		return parseALBLogLine, nil
+	case LogFormatJSONLines:
+		return parseJSONLogLine, nil
+	default:
+		return nil, fmt.Errorf("unsupported log format %q", format)
+	}
+}
+
+// combinedLogPattern matches: host ident user [time] "request" status bytes "referer" "user-agent"
+var combinedLogPattern = regexp.MustCompile(`^(\S+) \S+ \S+ \[([^\]]+)\] "([^"\\]*(?:\\.[^"\\]*)*)" \d{3} \S+(?: "([^"\\]*(?:\\.[^"\\]*)*)" "([^"\\]*(?:\\.[^"\\]*)*)")?`)
+
+func parseCombinedLogLine(line string) (*SecurityEventRequest, error) {
+	match := combinedLogPattern.FindStringSubmatch(line)
+	if match == nil {
+		return nil, fmt.Errorf("not a combined log line")
+	}
+
+	event, err := eventFromRequestLine(match[3])
+	if err != nil {
+		return nil, err
+	}
+	event.SourceIP = match[1]
+	event.UserAgent = match[5]
+	if match[4] != "" && match[4] != "-" {
+		event.Headers = map[string]string{"Referer": match[4]}
+	}
+	if t, err := time.Parse("02/Jan/2006:15:04:05 -0700", match[2]); err == nil {
+		event.Timestamp = t.UTC().Format(time.RFC3339)
+	}
+	return event, nil
+}
+
+func parseALBLogLine(line string) (*SecurityEventRequest, error) {
+	fields := splitQuotedFields(line)
+	// type time elb client:port target:port 3 x processing_time elb_status target_status
+	// received_bytes sent_bytes "request" "user_agent" ...
+	if len(fields) < 14 {
+		return nil, fmt.Errorf("not an ALB log line")
+	}
+
+	event, err := eventFromRequestLine(fields[12])
+	if err != nil {
+		return nil, err
+	}
+	event.SourceIP = fields[3]
+	if host, _, err := net.SplitHostPort(fields[3]); err == nil {
+		event.SourceIP = host
+	}
+	if fields[13] != "-" {
+		event.UserAgent = fields[13]
+	}
+	if t, err := time.Parse(time.RFC3339Nano, fields[1]); err == nil {
+		event.Timestamp = t.UTC().Format(time.RFC3339)
+	}
+	return event, nil
+}
+
+// jsonLogLine accepts the field names used by common JSON access loggers
+type jsonLogLine struct {
+	Time       string            `json:"time"`
+	Timestamp  string            `json:"timestamp"`
+	Method     string            `json:"method"`
+	Path       string            `json:"path"`
+	URI        string            `json:"uri"`
+	URL        string            `json:"url"`
+	Query      string            `json:"query"`
+	SourceIP   string            `json:"source_ip"`
+	RemoteAddr string            `json:"remote_addr"`
+	ClientIP   string            `json:"client_ip"`
+	UserAgent  string            `json:"user_agent"`
+	Headers    map[string]string `json:"headers"`
+	Body       string            `json:"body"`
+}
+
+func parseJSONLogLine(line string) (*SecurityEventRequest, error) {
+	var entry jsonLogLine
+	if err := json.Unmarshal([]byte(line), &entry); err != nil {
+		return nil, fmt.Errorf("invalid JSON log line: %w", err)
+	}
+
+	target := firstNonEmpty(entry.Path, entry.URI, entry.URL)
+	if entry.Method == "" || target == "" {
+		return nil, fmt.Errorf("JSON log line has no method or path")
+	}
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request target: %w", err)
+	}
+
+	event := &SecurityEventRequest{
+		Method:      strings.ToUpper(entry.Method),
+		Path:        u.Path,
+		QueryParams: firstNonEmpty(entry.Query, u.RawQuery),
+		SourceIP:    firstNonEmpty(entry.SourceIP, entry.ClientIP, entry.RemoteAddr),
+		UserAgent:   entry.UserAgent,
+		Headers:     entry.Headers,
+		RequestBody: entry.Body,
+		Timestamp:   firstNonEmpty(entry.Timestamp, entry.Time),
+	}
+	if host, _, err := net.SplitHostPort(event.SourceIP); err == nil {
+		event.SourceIP = host
+	}
+	return event, nil
+}
+
+// eventFromRequestLine parses an HTTP request line ("GET /path?q=1 HTTP/1.1")
+func eventFromRequestLine(requestLine string) (*SecurityEventRequest, error) {
+	parts := strings.Fields(requestLine)
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("malformed request line %q", truncate(requestLine, 64))
+	}
+
+	// Attack payloads can contain raw spaces; keep everything up to the protocol
+	target := parts[1]
+	if last := len(parts) - 1; last > 1 && strings.HasPrefix(parts[last], "HTTP/") {
+		target = strings.Join(parts[1:last], " ")
+	}
+
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request target: %w", err)
+	}
+	return &SecurityEventRequest{
+		Method:      parts[0],
+		Path:        u.Path,
+		QueryParams: u.RawQuery,
+	}, nil
+}
+
+// splitQuotedFields splits on spaces, keeping double-quoted fields together
+func splitQuotedFields(line string) []string {
+	var fields []string
+	var field strings.Builder
+	inQuotes := false
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case c == '"':
+			inQuotes = !inQuotes
+		case c == ' ' && !inQuotes:
+			fields = append(fields, field.String())
+			field.Reset()
+		default:
+			field.WriteByte(c)
+		}
+	}
+	return append(fields, field.String())
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,39 @@
+package guardial
+
+import "testing"
+
+func TestParseAccessLogLine(t *testing.T) {
+	tests := []struct {
+		name      string
+		format    LogFormat
+		line      string
+		wantErr   bool
+		wantIP    string
+		wantPath  string
+		wantQuery string
+	}{
+		{"combined", LogFormatCombined, `203.0.113.7 - - [10/Oct/2024:13:55:36 -0700] "GET /search?q=%27%20OR%201=1 HTTP/1.1" 200 512 "-" "sqlmap/1.7"`,
+			false, "203.0.113.7", "/search", "q=%27%20OR%201=1"},
+		{"alb", LogFormatALB, `https 2024-10-10T13:55:36.123456Z app/my-alb/50dc6c495c0c9188 203.0.113.7:2817 10.0.0.1:80 0.000 0.001 0.000 200 200 34 366 "GET https://example.com:443/login?user=admin HTTP/1.1" "curl/8.0" - -`,
+			false, "203.0.113.7", "/login", "user=admin"},
+		{"json lines", LogFormatJSONLines, `{"time":"2024-10-10T13:55:36Z","method":"post","uri":"/api/users?id=1","remote_addr":"203.0.113.7:5000"}`,
+			false, "203.0.113.7", "/api/users", "id=1"},
+		{"combined garbage", LogFormatCombined, "' OR 1=1 --", true, "", "", ""},
+		{"json garbage", LogFormatJSONLines, "{", true, "", "", ""},
+		{"unknown format", LogFormat("w3c"), "GET / HTTP/1.1", true, "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := ParseAccessLogLine(tt.line, tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAccessLogLine() error = %v, want error %t", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if event.SourceIP != tt.wantIP || event.Path != tt.wantPath || event.QueryParams != tt.wantQuery {
+				t.Errorf("event = %s %s?%s, want %s %s?%s", event.SourceIP, event.Path, event.QueryParams, tt.wantIP, tt.wantPath, tt.wantQuery)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+/**
+ * Guardial Go SDK IP Risk
+ * Expiring per-IP summaries of recent verdicts, for risk-aware business logic
+ */
+
+package guardial
+
+import (
+	"container/list"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// IP risk defaults
+const (
+	defaultIPRiskTTL    = 15 * time.Minute
+	defaultIPRiskMaxIPs = 10000
+)
+
+// IPRiskConfig configures the per-IP verdict summaries behind Client.IPRisk
+type IPRiskConfig struct {
+	TTL    time.Duration `json:"ttl"`     // An IP's summary is dropped this long after its last analysis (default: 15m)
+	MaxIPs int           `json:"max_ips"` // IPs tracked; the least recently analyzed are dropped first (default: 10000)
+}
+
+// RiskSummary is Guardial's view of an IP from recent analyses. The zero value (Known
+// false) means no request from the IP was analyzed within the TTL.
+type RiskSummary struct {
+	IP            string    `json:"ip"`
+	Analyzed      int       `json:"analyzed"`        // Analyses since the IP was first seen, without verdict cache hits
+	Blocked       int       `json:"blocked"`         // Of those, the verdicts that didn't allow the request
+	MaxRiskScore  int       `json:"max_risk_score"`  // Highest risk score seen
+	LastRiskScore int       `json:"last_risk_score"` // Risk score of the latest analysis
+	Categories    []string  `json:"categories"`      // Reason categories seen, e.g. "injection", sorted
+	FirstSeen     time.Time `json:"first_seen"`
+	LastSeen      time.Time `json:"last_seen"`
+}
+
+// Known reports whether the summary holds any analyses
+func (s RiskSummary) Known() bool {
+	return s.Analyzed > 0
+}
+
+type ipRiskEntry struct {
+	summary    RiskSummary
+	categories map[string]bool
+}
+
+// ipRiskCache is a size-bounded LRU of per-IP summaries whose entries expire after a TTL
+type ipRiskCache struct {
+	config IPRiskConfig
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // Front is most recently analyzed
+}
+
+func newIPRiskCache(config *IPRiskConfig) *ipRiskCache {
+	var cfg IPRiskConfig
+	if config != nil {
+		cfg = *config
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = defaultIPRiskTTL
+	}
+	if cfg.MaxIPs <= 0 {
+		cfg.MaxIPs = defaultIPRiskMaxIPs
+	}
+	return &ipRiskCache{config: cfg, entries: make(map[string]*list.Element), order: list.New()}
+}
+
+// IPRisk returns a summary of the verdicts for requests from ip analyzed within
+// Config.IPRisk's TTL, for business logic such as order review or signup friction. It
+// reads local state only and never calls the API; check Known before relying on it.
+func (c *Client) IPRisk(ip string) RiskSummary {
+	return c.ipRisk.get(ip)
+}
+
+// RequestRisk returns the IPRisk summary for the peer address of r, as the middleware
+// resolved it (see Config.TrustedProxies)
+func (c *Client) RequestRisk(r *http.Request) RiskSummary {
+	if state := requestStateFromContext(r.Context()); state != nil && state.event != nil {
+		return c.IPRisk(state.event.PeerIP)
+	}
+	_, peerIP := c.requestAddresses(r, false)
+	return c.IPRisk(peerIP)
+}
+
+// observeRisk adds the verdict for event to its peer IP's summary; a forged
+// X-Forwarded-For could otherwise pin an attacker's verdicts on someone else. Verdicts
+// that aren't analyses (local-only, queued, delivered) are ignored.
+func (c *Client) observeRisk(event *SecurityEventRequest, analysis *SecurityEventResponse) {
+	if event.PeerIP == "" {
+		return
+	}
+	switch analysis.Action {
+	case ActionLocal, ActionQueued, ActionDropped, ActionDelivered:
+		return
+	}
+	c.ipRisk.observe(event.PeerIP, analysis, time.Now())
+}
+
+func (rc *ipRiskCache) get(ip string) RiskSummary {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	element, ok := rc.entries[ip]
+	if !ok {
+		return RiskSummary{IP: ip}
+	}
+	entry := element.Value.(*ipRiskEntry)
+	if time.Since(entry.summary.LastSeen) > rc.config.TTL {
+		rc.order.Remove(element)
+		delete(rc.entries, ip)
+		return RiskSummary{IP: ip}
+	}
+
+	summary := entry.summary
+	summary.Categories = append([]string(nil), summary.Categories...)
+	return summary
+}
+
+func (rc *ipRiskCache) observe(ip string, analysis *SecurityEventResponse, now time.Time) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	element, ok := rc.entries[ip]
+	var entry *ipRiskEntry
+	if ok {
+		entry = element.Value.(*ipRiskEntry)
+		if now.Sub(entry.summary.LastSeen) > rc.config.TTL {
+			*entry = ipRiskEntry{}
+		}
+		rc.order.MoveToFront(element)
+	} else {
+		entry = &ipRiskEntry{}
+		rc.entries[ip] = rc.order.PushFront(entry)
+	}
+
+	summary := &entry.summary
+	if summary.Analyzed == 0 {
+		*summary = RiskSummary{IP: ip, FirstSeen: now}
+		entry.categories = make(map[string]bool)
+	}
+	summary.Analyzed++
+	if !analysis.Allowed {
+		summary.Blocked++
+	}
+	if analysis.RiskScore > summary.MaxRiskScore {
+		summary.MaxRiskScore = analysis.RiskScore
+	}
+	summary.LastRiskScore = analysis.RiskScore
+	summary.LastSeen = now
+	for _, reason := range analysis.Reasons {
+		if reason.Category != "" && !entry.categories[reason.Category] {
+			entry.categories[reason.Category] = true
+			summary.Categories = append(summary.Categories, reason.Category)
+			sort.Strings(summary.Categories)
+		}
+	}
+
+	// Evict least recently analyzed IPs
+	for rc.order.Len() > rc.config.MaxIPs {
+		oldest := rc.order.Back()
+		rc.order.Remove(oldest)
+		delete(rc.entries, oldest.Value.(*ipRiskEntry).summary.IP)
+	}
+}
@@ -0,0 +1,36 @@
+package guardial
+
+import (
+	"context"
+	"testing"
+)
+
+func TestObserveRisk(t *testing.T) {
+	tests := []struct {
+		name         string
+		configure    func(config *Config)
+		requests     int
+		wantAnalyzed int
+	}{
+		{"every analysis counts", nil, 3, 3},
+		{"cache hits don't count", func(c *Config) { c.VerdictCache = DefaultVerdictCacheConfig() }, 3, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newConfiguredTestClient(t, verdictAPI(blockedVerdict), tt.configure)
+			for i := 0; i < tt.requests; i++ {
+				event := &SecurityEventRequest{Method: "GET", Path: "/api/orders", SourceIP: "203.0.113.7", PeerIP: "192.0.2.1"}
+				if _, err := client.AnalyzeEventContext(context.Background(), event); err != nil {
+					t.Fatalf("AnalyzeEventContext() = %v", err)
+				}
+			}
+
+			if got := client.IPRisk("192.0.2.1"); got.Analyzed != tt.wantAnalyzed || got.Blocked != tt.wantAnalyzed {
+				t.Errorf("IPRisk(peer) analyzed %d, blocked %d; want %d each", got.Analyzed, got.Blocked, tt.wantAnalyzed)
+			}
+			if client.IPRisk("203.0.113.7").Known() {
+				t.Error("verdicts were recorded against the forwarded IP")
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+/**
+ * Guardial Go SDK API Key Rotation
+ * Primary/secondary API keys with automatic fallback and runtime rotation
+ */
+
+package guardial
+
+import (
+	"errors"
+	"net/http"
+)
+
+// apiKeys is the current key pair; it is replaced as a whole, never modified
+type apiKeys struct {
+	primary   string
+	secondary string
+}
+
+// RotateKey makes newKey the primary API key without restarting the process. The
+// previous primary is kept as the secondary, so calls keep working while the new key
+// propagates on the backend.
+func (c *Client) RotateKey(newKey string) {
+	for {
+		current := c.keys.Load()
+		next := &apiKeys{primary: newKey, secondary: current.primary}
+		if current.primary == newKey {
+			next.secondary = current.secondary
+		}
+		if c.keys.CompareAndSwap(current, next) {
+			break
+		}
+	}
+	c.log("🔑 API key rotated")
+}
+
+// promoteSecondary swaps the keys after the secondary succeeded where the primary got a
+// 401. It is a no-op if the keys changed in the meantime.
+func (c *Client) promoteSecondary(seen *apiKeys) {
+	if c.keys.CompareAndSwap(seen, &apiKeys{primary: seen.secondary, secondary: seen.primary}) {
+		c.log("🔑 Primary API key rejected, switched to secondary key")
+	}
+}
+
+// isUnauthorized reports whether err is a 401 from the API
+func isUnauthorized(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized
+}
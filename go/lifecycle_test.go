@@ -0,0 +1,99 @@
+package guardial
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// blockingPropagator is a BlockPropagator whose Subscribe runs until ctx is done
+type blockingPropagator struct {
+	stopped atomic.Bool
+}
+
+func (p *blockingPropagator) Publish(ctx context.Context, decision BlockDecision) error {
+	return nil
+}
+
+func (p *blockingPropagator) Subscribe(ctx context.Context, deliver func(BlockDecision)) error {
+	<-ctx.Done()
+	p.stopped.Store(true)
+	return ctx.Err()
+}
+
+func TestCloseStopsBackgroundWork(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"allowed":true}`))
+	}))
+	defer api.Close()
+
+	tests := []struct {
+		name      string
+		configure func(config *Config)
+	}{
+		{"no background work", func(config *Config) {}},
+		{"block propagator", func(config *Config) { config.BlockPropagator = &blockingPropagator{} }},
+		{"async mode", func(config *Config) { config.AsyncMode = true }},
+		{"override sync", func(config *Config) { config.OverrideSyncInterval = time.Hour }},
+		{"policy sync", func(config *Config) { config.PolicySyncInterval = time.Hour }},
+		{"maintenance", func(config *Config) { config.Maintenance = &MaintenanceConfig{} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			config.Endpoint = api.URL
+			config.APIKey = "test"
+			tt.configure(config)
+			client := NewClient(config)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			start := time.Now()
+			if err := client.Close(ctx); err != nil {
+				t.Fatalf("Close() = %v, want nil", err)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("Close() took %v, want it to return without waiting out its deadline", elapsed)
+			}
+			if p, ok := config.BlockPropagator.(*blockingPropagator); ok && !p.stopped.Load() {
+				t.Error("block subscription still running after Close")
+			}
+		})
+	}
+}
+
+func TestGoBackgroundAfterClose(t *testing.T) {
+	client := NewClient(&Config{APIKey: "test"})
+
+	ran := make(chan struct{})
+	if !client.goBackground(func() { close(ran) }) {
+		t.Fatal("goBackground() = false before Close, want true")
+	}
+	<-ran
+
+	if err := client.Close(context.Background()); err != nil {
+		t.Fatalf("Close() = %v, want nil", err)
+	}
+	if client.goBackground(func() { t.Error("background work started after Close") }) {
+		t.Error("goBackground() = true after Close, want false")
+	}
+}
+
+func TestCloseConcurrentWithBackgroundWork(t *testing.T) {
+	client := NewClient(&Config{APIKey: "test"})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			client.goBackground(func() {})
+		}
+	}()
+	if err := client.Close(context.Background()); err != nil {
+		t.Fatalf("Close() = %v, want nil", err)
+	}
+	<-done
+}
@@ -0,0 +1,218 @@
+/**
+ * Guardial Go SDK LLM Router Guard
+ * Provider-specific prompt policies for apps that route prompts across model providers
+ */
+
+package guardial
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LLM routing actions
+const (
+	LLMRouteForwarded  = "forwarded"  // Sent to the selected provider
+	LLMRouteRestricted = "restricted" // High risk; sent to the restricted provider instead
+	LLMRouteReview     = "review"     // High risk; handed to the review queue
+	LLMRouteBlocked    = "blocked"    // Refused by the API or the provider's policy
+)
+
+// LLMProvider is a model endpoint the router can send prompts to
+type LLMProvider struct {
+	Name     string                                                   // e.g. "openai:gpt-4o"
+	Complete func(ctx context.Context, prompt string) (string, error) // Sends the prompt to the model
+
+	// Provider-specific guard policy, applied on top of the API's verdict. BlockSeverity
+	// refuses prompts with a detection at or above it (e.g. "MEDIUM" for third-party
+	// providers); BlockedRules refuses prompts matching any of these rule IDs.
+	BlockSeverity string
+	BlockedRules  []string
+
+	// Context is sent with the prompt to PromptGuard, e.g. {"model": "gpt-4o"}
+	Context map[string]string
+}
+
+// LLMRouteDecision records how the router handled a prompt
+type LLMRouteDecision struct {
+	Selected string            // Provider chosen by Select
+	Provider string            // Provider that handled the prompt; empty if blocked or reviewed
+	Action   string            // LLMRouteForwarded, LLMRouteRestricted, LLMRouteReview, or LLMRouteBlocked
+	Reason   string            // Why the prompt was rerouted or blocked
+	Guard    *LLMGuardResponse // PromptGuard verdict
+	Duration time.Duration     // Time spent in the provider or review queue
+}
+
+// LLMRouterOptions configures an LLMRouter
+type LLMRouterOptions struct {
+	Providers []LLMProvider
+
+	// Select picks the provider for a prompt by name; nil always picks the first
+	Select func(ctx context.Context, prompt string) string
+
+	// High-risk prompts have a detection at or above RiskSeverity (default: "HIGH").
+	// They go to Review when set, or else to the Restricted provider when set; otherwise
+	// they are routed normally and the selected provider's policy decides.
+	RiskSeverity string
+	Restricted   string
+	Review       func(ctx context.Context, prompt string, decision *LLMRouteDecision) (string, error)
+
+	// OnDecision records which provider handled each prompt, e.g. for audit logs
+	OnDecision func(ctx context.Context, decision *LLMRouteDecision)
+}
+
+// LLMRouter guards prompts before routing them to one of several model providers
+type LLMRouter struct {
+	client    *Client
+	options   LLMRouterOptions
+	providers map[string]*LLMProvider
+}
+
+// PromptBlockedError is returned when the router refuses a prompt
+type PromptBlockedError struct {
+	Decision *LLMRouteDecision
+}
+
+// Error implements the error interface
+func (e *PromptBlockedError) Error() string {
+	return fmt.Sprintf("prompt blocked by Guardial: %s", e.Decision.Reason)
+}
+
+// Is makes errors.Is(err, ErrBlocked) true for blocked prompts
+func (e *PromptBlockedError) Is(target error) bool {
+	return target == ErrBlocked
+}
+
+// NewLLMRouter creates a router over options.Providers
+func (c *Client) NewLLMRouter(options *LLMRouterOptions) (*LLMRouter, error) {
+	if options == nil || len(options.Providers) == 0 {
+		return nil, fmt.Errorf("LLM router needs at least one provider")
+	}
+	router := &LLMRouter{client: c, options: *options, providers: make(map[string]*LLMProvider)}
+	if router.options.RiskSeverity == "" {
+		router.options.RiskSeverity = "HIGH"
+	}
+	for i := range router.options.Providers {
+		provider := &router.options.Providers[i]
+		if provider.Name == "" || provider.Complete == nil {
+			return nil, fmt.Errorf("LLM provider %d needs a Name and Complete", i)
+		}
+		router.providers[provider.Name] = provider
+	}
+	if name := router.options.Restricted; name != "" && router.providers[name] == nil {
+		return nil, fmt.Errorf("restricted LLM provider %q is not configured", name)
+	}
+	return router, nil
+}
+
+// Complete guards prompt and sends it to the provider it is routed to. A refused prompt
+// returns a *PromptBlockedError, which matches ErrBlocked. The decision is returned
+// (and passed to OnDecision) whatever the outcome.
+func (r *LLMRouter) Complete(ctx context.Context, prompt string, promptContext map[string]string) (string, *LLMRouteDecision, error) {
+	selected := r.selectProvider(ctx, prompt)
+	decision := &LLMRouteDecision{Selected: selected.Name, Action: LLMRouteForwarded}
+	defer func() {
+		if r.options.OnDecision != nil {
+			r.options.OnDecision(ctx, decision)
+		}
+	}()
+
+	guardContext := map[string]string{"provider": selected.Name}
+	for key, value := range selected.Context {
+		guardContext[key] = value
+	}
+	for key, value := range promptContext {
+		guardContext[key] = value
+	}
+	guard, err := r.client.PromptGuardContext(ctx, prompt, guardContext)
+	if err != nil {
+		return "", decision, fmt.Errorf("failed to guard prompt: %w", err)
+	}
+	decision.Guard = guard
+
+	if !guard.Allowed {
+		return "", decision, r.block(decision, "blocked by prompt guard: "+strings.Join(guard.Reasons, ", "))
+	}
+
+	provider := selected
+	if detection := highestDetection(guard, r.options.RiskSeverity); detection != nil {
+		reason := fmt.Sprintf("high-risk prompt: %s (%s)", detection.Title, detection.Severity)
+		switch {
+		case r.options.Review != nil:
+			decision.Action = LLMRouteReview
+			decision.Reason = reason
+			r.client.log("⚠️ Prompt sent to review:", detection.RuleID)
+			started := time.Now()
+			response, err := r.options.Review(ctx, prompt, decision)
+			decision.Duration = time.Since(started)
+			return response, decision, err
+		case r.options.Restricted != "":
+			provider = r.providers[r.options.Restricted]
+			decision.Action = LLMRouteRestricted
+			decision.Reason = reason
+			r.client.log("⚠️ Prompt rerouted to restricted provider:", provider.Name, detection.RuleID)
+		}
+	}
+
+	if reason := provider.refuses(guard); reason != "" {
+		return "", decision, r.block(decision, provider.Name+" policy: "+reason)
+	}
+
+	decision.Provider = provider.Name
+	started := time.Now()
+	response, err := provider.Complete(ctx, prompt)
+	decision.Duration = time.Since(started)
+	if err != nil {
+		return "", decision, fmt.Errorf("%s completion failed: %w", provider.Name, err)
+	}
+	return response, decision, nil
+}
+
+func (r *LLMRouter) selectProvider(ctx context.Context, prompt string) *LLMProvider {
+	if r.options.Select != nil {
+		if provider := r.providers[r.options.Select(ctx, prompt)]; provider != nil {
+			return provider
+		}
+	}
+	return &r.options.Providers[0]
+}
+
+func (r *LLMRouter) block(decision *LLMRouteDecision, reason string) error {
+	decision.Action = LLMRouteBlocked
+	decision.Provider = ""
+	decision.Reason = reason
+	r.client.log("🚫 Prompt blocked:", reason)
+	return &PromptBlockedError{Decision: decision}
+}
+
+// refuses returns why the provider's policy refuses a prompt, or ""
+func (p *LLMProvider) refuses(guard *LLMGuardResponse) string {
+	for _, detection := range guard.Detections {
+		if containsString(p.BlockedRules, detection.RuleID) {
+			return "rule " + detection.RuleID + " not allowed"
+		}
+	}
+	if p.BlockSeverity != "" {
+		if detection := highestDetection(guard, p.BlockSeverity); detection != nil {
+			return fmt.Sprintf("%s detection at or above %s", detection.Severity, strings.ToUpper(p.BlockSeverity))
+		}
+	}
+	return ""
+}
+
+// highestDetection returns the most severe detection at or above minSeverity, or nil
+func highestDetection(guard *LLMGuardResponse, minSeverity string) *LLMDetection {
+	var highest *LLMDetection
+	for i := range guard.Detections {
+		detection := &guard.Detections[i]
+		if severityRank(detection.Severity) < severityRank(minSeverity) {
+			continue
+		}
+		if highest == nil || severityRank(detection.Severity) > severityRank(highest.Severity) {
+			highest = detection
+		}
+	}
+	return highest
+}
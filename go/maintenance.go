@@ -0,0 +1,254 @@
+/**
+ * Guardial Go SDK Maintenance Windows
+ * Local-only operation during backend-announced maintenance, with quiet failure alerts
+ */
+
+package guardial
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Maintenance headers the API may send on any response, including /health, to announce
+// a window without waiting for the next poll
+const (
+	HeaderMaintenanceStart = "X-Guardial-Maintenance-Start" // RFC 3339
+	HeaderMaintenanceEnd   = "X-Guardial-Maintenance-End"   // RFC 3339
+)
+
+// Maintenance defaults
+const (
+	defaultMaintenancePoll      = 5 * time.Minute
+	defaultMaintenanceLead      = time.Minute
+	defaultMaintenanceMaxWindow = 4 * time.Hour
+)
+
+// maintenanceHeaderPrefix marks the IDs of windows announced in response headers
+const maintenanceHeaderPrefix = "header:"
+
+// MaintenanceWindow is a period during which the backend is unavailable or degraded
+type MaintenanceWindow struct {
+	ID       string    `json:"id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Message  string    `json:"message,omitempty"`
+}
+
+// MaintenanceConfig enables handling of backend-announced maintenance windows. During
+// a window (from Lead before it starts until it ends) events are analyzed locally only,
+// as with the quota governor, and the SDK's API failure warnings are suppressed; normal
+// analysis resumes automatically when the window ends.
+type MaintenanceConfig struct {
+	PollInterval time.Duration `json:"poll_interval"` // How often /api/maintenance is polled (default: 5m)
+	Lead         time.Duration `json:"lead"`          // Switch to local-only this long before a window starts (default: 1m)
+
+	// MaxWindow caps how long a window keeps analysis local-only, however long it was
+	// announced for; analysis resumes MaxWindow after StartsAt (default: 4h)
+	MaxWindow time.Duration `json:"max_window"`
+
+	// OnChange is called from a background goroutine as a window begins (active) and
+	// ends, e.g. to silence alerting on Guardial failures
+	OnChange func(window MaintenanceWindow, active bool) `json:"-"`
+}
+
+// maintenanceTracker holds announced windows and the one in effect
+type maintenanceTracker struct {
+	config MaintenanceConfig
+	active atomic.Pointer[MaintenanceWindow]
+	wake   chan struct{} // Signals that windows changed
+
+	mu         sync.Mutex
+	windows    map[string]MaintenanceWindow
+	suppressed int // Warnings dropped in the current window
+}
+
+func newMaintenanceTracker(config *MaintenanceConfig) *maintenanceTracker {
+	cfg := *config
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultMaintenancePoll
+	}
+	if cfg.Lead <= 0 {
+		cfg.Lead = defaultMaintenanceLead
+	}
+	if cfg.MaxWindow <= 0 {
+		cfg.MaxWindow = defaultMaintenanceMaxWindow
+	}
+	return &maintenanceTracker{config: cfg, wake: make(chan struct{}, 1), windows: make(map[string]MaintenanceWindow)}
+}
+
+// InMaintenance reports whether the client is in a maintenance window, and which
+func (c *Client) InMaintenance() (MaintenanceWindow, bool) {
+	if c.maintenance == nil {
+		return MaintenanceWindow{}, false
+	}
+	if window := c.maintenance.active.Load(); window != nil {
+		return *window, true
+	}
+	return MaintenanceWindow{}, false
+}
+
+// inMaintenance reports whether events are analyzed locally because of maintenance
+func (c *Client) inMaintenance() bool {
+	return c.maintenance != nil && c.maintenance.active.Load() != nil
+}
+
+// warn logs an API failure warning unless a maintenance window is in effect
+func (c *Client) warn(args ...interface{}) {
+	if c.inMaintenance() {
+		c.maintenance.mu.Lock()
+		c.maintenance.suppressed++
+		c.maintenance.mu.Unlock()
+		return
+	}
+	c.log(args...)
+}
+
+// observeMaintenance records a window announced in API response headers
+func (c *Client) observeMaintenance(header http.Header) {
+	if c.maintenance == nil || header.Get(HeaderMaintenanceStart) == "" {
+		return
+	}
+	start, err := time.Parse(time.RFC3339, header.Get(HeaderMaintenanceStart))
+	if err != nil {
+		return
+	}
+	end, err := time.Parse(time.RFC3339, header.Get(HeaderMaintenanceEnd))
+	if err != nil {
+		return
+	}
+	c.maintenance.announce([]MaintenanceWindow{{ID: maintenanceHeaderPrefix + start.Format(time.RFC3339), StartsAt: start, EndsAt: end}}, false)
+}
+
+// announce adds windows, cut to MaxWindow; replace drops windows no longer announced
+func (m *maintenanceTracker) announce(windows []MaintenanceWindow, replace bool) {
+	m.mu.Lock()
+	changed := replace && len(m.windows) != len(windows)
+	if replace {
+		fresh := make(map[string]MaintenanceWindow, len(windows))
+		for id, window := range m.windows {
+			if strings.HasPrefix(id, maintenanceHeaderPrefix) {
+				fresh[id] = window // Header announcements have no poll counterpart
+			}
+		}
+		m.windows = fresh
+	}
+	for _, window := range windows {
+		if window.ID == "" || !window.EndsAt.After(window.StartsAt) {
+			continue
+		}
+		if limit := window.StartsAt.Add(m.config.MaxWindow); window.EndsAt.After(limit) {
+			window.EndsAt = limit
+		}
+		if existing, ok := m.windows[window.ID]; !ok || existing != window {
+			changed = true
+		}
+		m.windows[window.ID] = window
+	}
+	m.mu.Unlock()
+
+	if changed {
+		select {
+		case m.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// runMaintenance polls for windows and switches local-only mode on and off as windows
+// begin and end, until the client closes
+func (c *Client) runMaintenance() {
+	m := c.maintenance
+	nextPoll := time.Now()
+	for {
+		now := time.Now()
+		if !now.Before(nextPoll) {
+			var response struct {
+				Windows []MaintenanceWindow `json:"windows"`
+			}
+			if err := c.getJSON(c.ctx, "/api/maintenance", &response); err != nil {
+				c.warn("⚠️ Failed to fetch maintenance windows:", err)
+			} else {
+				m.announce(response.Windows, true)
+			}
+			nextPoll = now.Add(m.config.PollInterval)
+		}
+
+		wake := c.evaluateMaintenance(time.Now())
+		if wake.IsZero() || wake.After(nextPoll) {
+			wake = nextPoll
+		}
+		timer := time.NewTimer(time.Until(wake))
+		select {
+		case <-timer.C:
+		case <-m.wake:
+			timer.Stop()
+		case <-c.closing:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// evaluateMaintenance switches to the window in effect at now, forgets past windows,
+// and returns when the next change is due (zero if none is known)
+func (c *Client) evaluateMaintenance(now time.Time) time.Time {
+	m := c.maintenance
+	m.mu.Lock()
+	var current *MaintenanceWindow
+	var next time.Time
+	ids := make([]string, 0, len(m.windows))
+	for id := range m.windows {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids) // Deterministic choice among overlapping windows
+	for _, id := range ids {
+		window := m.windows[id]
+		begins := window.StartsAt.Add(-m.config.Lead)
+		switch {
+		case !now.Before(window.EndsAt):
+			delete(m.windows, id)
+			continue
+		case !now.Before(begins):
+			if current == nil || window.EndsAt.After(current.EndsAt) {
+				w := window
+				current = &w
+			}
+			if next.IsZero() || window.EndsAt.Before(next) {
+				next = window.EndsAt
+			}
+		default:
+			if next.IsZero() || begins.Before(next) {
+				next = begins
+			}
+		}
+	}
+	previous := m.active.Load()
+	suppressed := m.suppressed
+	if current == nil {
+		m.suppressed = 0
+	}
+	m.mu.Unlock()
+
+	switch {
+	case current != nil && (previous == nil || previous.ID != current.ID || previous.EndsAt != current.EndsAt):
+		m.active.Store(current)
+		if previous == nil {
+			c.log("🛠️ Maintenance window", current.ID, "until", current.EndsAt.Format(time.RFC3339), "- analyzing locally:", current.Message)
+			if m.config.OnChange != nil {
+				m.config.OnChange(*current, true)
+			}
+		}
+	case current == nil && previous != nil:
+		m.active.Store(nil)
+		c.log("🛠️ Maintenance window", previous.ID, "ended, resuming analysis; warnings suppressed:", suppressed)
+		if m.config.OnChange != nil {
+			m.config.OnChange(*previous, false)
+		}
+	}
+	return next
+}
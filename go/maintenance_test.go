@@ -0,0 +1,43 @@
+package guardial
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestMaintenanceHeaderWindows(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	tests := []struct {
+		name       string
+		start      time.Time
+		end        time.Time
+		wantActive bool
+		wantEnd    time.Time
+	}{
+		{"short window", now.Add(-time.Minute), now.Add(time.Hour), true, now.Add(time.Hour)},
+		{"window over the limit", now.Add(-time.Minute), now.Add(365 * 24 * time.Hour), true, now.Add(-time.Minute).Add(defaultMaintenanceMaxWindow)},
+		{"ancient start", now.Add(-24 * time.Hour), now.Add(24 * time.Hour), false, time.Time{}},
+		{"ended", now.Add(-2 * time.Hour), now.Add(-time.Hour), false, time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(&Config{APIKey: "test"})
+			client.maintenance = newMaintenanceTracker(&MaintenanceConfig{})
+
+			header := http.Header{}
+			header.Set(HeaderMaintenanceStart, tt.start.Format(time.RFC3339))
+			header.Set(HeaderMaintenanceEnd, tt.end.Format(time.RFC3339))
+			client.observeMaintenance(header)
+			client.evaluateMaintenance(now)
+
+			window, active := client.InMaintenance()
+			if active != tt.wantActive {
+				t.Fatalf("InMaintenance() = %t, want %t", active, tt.wantActive)
+			}
+			if active && !window.EndsAt.Equal(tt.wantEnd) {
+				t.Errorf("window ends at %v, want %v", window.EndsAt, tt.wantEnd)
+			}
+		})
+	}
+}
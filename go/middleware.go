@@ -6,16 +6,160 @@
 package guardial
 
 import (
-	"bytes"
-	"io"
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
-	"strings"
+	"time"
+)
+
+// Mode selects whether the middleware enforces its decisions
+type Mode string
+
+// Middleware modes
+const (
+	ModeEnforce Mode = "enforce" // Refuse blocked requests (the default)
+	ModeMonitor Mode = "monitor" // Analyze and record, but let every request through
 )
 
 // MiddlewareOptions configures the middleware behavior
 type MiddlewareOptions struct {
+	// ExcludePaths skips analysis of matching requests. Entries are path prefixes
+	// ("/health"), globs ("/static/**"), or regular expressions prefixed with "~", and
+	// may start with a method filter: "GET,HEAD /static/**" skips only those methods,
+	// "!POST,PUT,PATCH /api/**" skips every other method.
 	ExcludePaths []string
-	FailOpen     bool // If true, allow requests on analysis failure
+
+	// FailOpen lets requests through when analysis fails. It only covers failures:
+	// blocked verdicts are refused regardless. Earlier versions also let blocked requests
+	// through with FailOpen, marking them X-Guardial-Blocked; use Mode ModeMonitor for that.
+	FailOpen bool
+
+	// MaxBodyBytes caps how much of a request body is buffered into the event; the
+	// rest streams to the handler unread. Zero means no limit.
+	MaxBodyBytes int64
+
+	// SkipContentTypes lists media types whose bodies are never captured, e.g.
+	// "multipart/form-data", "application/x-protobuf", or "video/*"
+	SkipContentTypes []string
+
+	// SampleRate is the fraction of requests analyzed, from 0.0 to 1.0, to stay within
+	// plan quota; 0 (unset) analyzes every request. Requests AlwaysAnalyze picks out
+	// are analyzed regardless; the rest proceed unanalyzed unless sampled.
+	SampleRate float64
+
+	// AlwaysAnalyze picks out requests exempt from SampleRate (default:
+	// DefaultAlwaysAnalyze, which covers auth endpoints and requests with bodies)
+	AlwaysAnalyze func(event *SecurityEventRequest) bool
+
+	// Prefilter lets obviously benign requests (static assets, health probes) proceed
+	// without an API call; nil analyzes them like any other request
+	Prefilter *PrefilterOptions
+
+	// Multipart parses multipart/form-data bodies into form fields and file
+	// descriptions instead of sending the raw body; nil sends it raw
+	Multipart *MultipartOptions
+
+	// Webhooks verify the signatures, age, and novelty of deliveries to webhook
+	// endpoints before analysis; see WebhookMiddlewareOptions
+	Webhooks []WebhookOptions
+
+	// Mode ModeMonitor sends events and records verdicts but never refuses a request
+	// or degrades a response, for safe rollouts. Requests that would have been refused
+	// are reported to OnWouldBlock and logged. Empty means ModeEnforce.
+	Mode Mode
+
+	// OnWouldBlock is called in monitor mode for each request that would have been
+	// refused; rejection.Analysis holds the verdict when it came from analysis
+	OnWouldBlock func(ctx context.Context, event *SecurityEventRequest, rejection Rejection)
+
+	// OnAbandoned is called for each request whose client disconnected before the
+	// verdict arrived (see ActionAbandoned). ctx keeps the request's values but is no
+	// longer canceled.
+	OnAbandoned func(ctx context.Context, event *SecurityEventRequest)
+
+	// WouldBlockHeader adds "X-Guardial-Would-Block: true" to the responses of requests
+	// that monitor mode let through, for checking a rollout from the client side. It
+	// reveals the detection to the caller, so avoid it on production traffic.
+	WouldBlockHeader bool
+
+	// SecurityHeaders sets informational verdict headers and standard hardening headers
+	// (HSTS, X-Content-Type-Options, X-Frame-Options) on responses; see
+	// DefaultSecurityHeaders. The inbound request is never modified.
+	SecurityHeaders *SecurityHeaderOptions
+
+	// DisableDecisionHeaders leaves the X-Guardial-Event-Id, X-Guardial-Action, and
+	// X-Guardial-Category headers off blocked responses
+	DisableDecisionHeaders bool
+
+	// Routes override FailOpen, the policy, or exclusion for matching requests, e.g.
+	// fail closed on /admin/* and monitor only on /public/*. The first match applies.
+	Routes []RouteRule
+
+	// BlockHandler renders the response to blocked requests (a custom 403 page, JSON
+	// shape, or redirect) instead of the default JSON error. analysis is nil when the
+	// request was refused before analysis (blocked IP, client fingerprint, GraphQL
+	// operation). Analysis failures with FailOpen unset still get the default 500.
+	BlockHandler func(w http.ResponseWriter, r *http.Request, analysis *SecurityEventResponse)
+
+	// UserFunc identifies the authenticated user of r, e.g. from a session or token, so
+	// allow overrides granted to a user apply (see Client.GrantOverride)
+	UserFunc func(r *http.Request) string
+
+	// RouteFunc returns the route pattern r matches (e.g. "/users/{id}"), recorded in
+	// the event so detections aggregate per route. Router adapters such as guardialchi
+	// set it.
+	RouteFunc func(r *http.Request) string
+
+	// CrashTelemetry enables the recovery layer, which reports panics and repeated
+	// 500s tied to the same input as potential exploitation attempts
+	CrashTelemetry *CrashTelemetryOptions
+
+	// Forensics captures the complete raw request for blocked or CRITICAL events
+	Forensics *ForensicOptions
+
+	// ClientFingerprints restrict machine-to-machine routes to the expected callers
+	ClientFingerprints []ClientFingerprint
+
+	// Canary embeds canary tokens in responses to high-risk requests and blocks anyone
+	// who later uses one
+	Canary *CanaryOptions
+
+	// Sampling analyzes only part of the traffic, adapting to recent detections and
+	// staying within an events-per-minute budget. Unsampled requests proceed unanalyzed.
+	Sampling *AdaptiveSamplingOptions
+
+	// Degrade serves degraded responses to requests analyzed with Action "degrade"
+	// instead of passing them through untouched
+	Degrade *DegradeOptions
+
+	// GraphQL parses requests to GraphQL endpoints into operations on the event and
+	// refuses blocked operations
+	GraphQL *GraphQLOptions
+
+	// Review sends verdicts with Action "review" to a human-review queue, and holds
+	// the request for a decision or lets it through provisionally
+	Review *ReviewOptions
+
+	// Trust keeps a per-identity trust score from request history and adjusts
+	// enforcement and analysis volume by it
+	Trust *TrustOptions
+
+	// ResponseAnalysis captures outgoing responses (status, headers, bounded body) and
+	// submits them for leakage, stack trace, and reflection detection
+	ResponseAnalysis *ResponseAnalysisOptions
+
+	// SessionAnalytics ships periodic per-session rollups (request counts, distinct
+	// paths, risk trajectory) alongside the per-request events
+	SessionAnalytics *SessionAnalyticsOptions
+
+	// Concurrency bounds the analyses running at once; requests that can't get a slot
+	// in time are handled per FailOpen. nil doesn't limit them.
+	Concurrency *ConcurrencyOptions
+
+	// Watchdog times the canary detector and disables it for a cool-down when its p99
+	// exceeds its budget; client fingerprints and GraphQL rules always run
+	Watchdog *WatchdogOptions
 }
 
 // DefaultMiddlewareOptions returns default middleware options
@@ -23,150 +167,496 @@ func DefaultMiddlewareOptions() *MiddlewareOptions {
 	return &MiddlewareOptions{
 		ExcludePaths: []string{"/health", "/favicon.ico"},
 		FailOpen:     true,
+		MaxBodyBytes: defaultMaxBodyBytes,
+		Multipart:    &MultipartOptions{HashFiles: true},
+		Prefilter:    DefaultPrefilter(),
 	}
 }
 
-// GinMiddleware returns a Gin middleware handler
-// Usage: router.Use(guardial.GinMiddleware(client))
-func GinMiddleware(client *Client, options *MiddlewareOptions) func(http.ResponseWriter, *http.Request, func()) {
+// middleware holds the state shared by every framework adapter
+type middleware struct {
+	client       *Client
+	options      *MiddlewareOptions
+	crashes      *crashTracker
+	fingerprints []compiledFingerprint
+	exclusions   []pathExclusion
+	degrader     *degrader
+	canary       *canaryGuard
+	sampler      *adaptiveSampler
+	sessions     *sessionExporter
+	trust        *trustScorer
+	responses    *responseAnalyzer
+	watchdog     *detectorWatchdog
+	bulkhead     *bulkhead
+	webhooks     []*webhookGuard
+	rejecter     func(http.ResponseWriter, *http.Request, Rejection)
+	trustPeer    bool // The peer is a trusted proxy, e.g. for ProxyOptions.TrustForwardedHeaders
+}
+
+// blockedMessage is the error message of default block responses
+const blockedMessage = "Request blocked by security policy"
+
+// Rejection describes a request the middleware refused to pass downstream
+type Rejection struct {
+	Status   int                    // HTTP status to answer with
+	Message  string                 // Error message for the response body
+	Analysis *SecurityEventResponse // The verdict, when the rejection came from analysis
+	Category string                 // Reason category of refusals made before analysis, e.g. "blocklist"
+
+	// Headers are the decision headers (HeaderEventID, HeaderAction, HeaderCategory)
+	// for the response; nil for analysis failures or with DisableDecisionHeaders
+	Headers map[string]string
+}
+
+// reject writes the response for a refused request
+func (m *middleware) reject(w http.ResponseWriter, r *http.Request, rejection Rejection) {
+	if m.options.BlockHandler != nil && rejection.Status == http.StatusForbidden {
+		m.options.BlockHandler(w, r, rejection.Analysis)
+		return
+	}
+	if m.rejecter != nil {
+		m.rejecter(w, r, rejection)
+		return
+	}
+	body, _ := json.Marshal(map[string]string{"error": rejection.Message})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(rejection.Status)
+	w.Write(body)
+}
+
+func newMiddleware(client *Client, options *MiddlewareOptions) *middleware {
 	if options == nil {
 		options = DefaultMiddlewareOptions()
 	}
 
-	return func(w http.ResponseWriter, r *http.Request, next func()) {
-		// Check if path should be excluded
-		for _, excludePath := range options.ExcludePaths {
-			if strings.HasPrefix(r.URL.Path, excludePath) {
-				next()
-				return
-			}
+	m := &middleware{client: client, options: options}
+	if options.CrashTelemetry != nil {
+		m.crashes = newCrashTracker(options.CrashTelemetry)
+	}
+	m.compileFingerprints()
+	m.compileExclusions()
+	if options.Degrade != nil && options.Mode != ModeMonitor {
+		m.degrader = newDegrader(client, options.Degrade)
+	}
+	if options.Canary != nil {
+		m.canary = newCanaryGuard(client, options.Canary)
+	}
+	if options.Sampling != nil {
+		m.sampler = newAdaptiveSampler(client, options.Sampling)
+	}
+	if options.SessionAnalytics != nil {
+		m.sessions = newSessionExporter(client, options.SessionAnalytics)
+	}
+	if options.Trust != nil {
+		m.trust = newTrustScorer(client, options.Trust)
+	}
+	if options.ResponseAnalysis != nil {
+		m.responses = newResponseAnalyzer(client, options.ResponseAnalysis)
+	}
+	if options.Watchdog != nil {
+		m.watchdog = newDetectorWatchdog(client, options.Watchdog)
+	}
+	for _, webhook := range options.Webhooks {
+		m.webhooks = append(m.webhooks, newWebhookGuard(client, webhook))
+	}
+	if options.Concurrency != nil {
+		m.bulkhead = newBulkhead(options.Concurrency)
+	}
+	return m
+}
+
+// requestState is what the middleware knows about a request once it has been analyzed
+type requestState struct {
+	event     *SecurityEventRequest
+	analysis  *SecurityEventResponse
+	user      string // From UserFunc
+	trust     string // Trust identity, when trust scoring is enabled
+	abandoned bool   // The client went away during analysis
+}
+
+type requestStateKey struct{}
+
+func requestStateFromContext(ctx context.Context) *requestState {
+	state, _ := ctx.Value(requestStateKey{}).(*requestState)
+	return state
+}
+
+// FromContext returns the verdict the middleware attached to a request's context, or
+// nil if the request was not analyzed (excluded path, not sampled, analysis failure with
+// FailOpen)
+func FromContext(ctx context.Context) *SecurityEventResponse {
+	if state := requestStateFromContext(ctx); state != nil {
+		return state.analysis
+	}
+	return nil
+}
+
+// EventIDFromContext returns the ID of the event the middleware built for a request.
+// It is set before analysis, so handlers can log it while the verdict is still pending
+// or queued (AsyncMode). It returns "" for excluded requests.
+func EventIDFromContext(ctx context.Context) string {
+	if state := requestStateFromContext(ctx); state != nil && state.event != nil {
+		return state.event.EventID
+	}
+	return ""
+}
+
+// NewContext returns a copy of ctx carrying event and its verdict, for adapters that
+// don't go through the net/http middleware; FromContext reads it back
+func NewContext(ctx context.Context, event *SecurityEventRequest, analysis *SecurityEventResponse) context.Context {
+	return context.WithValue(ctx, requestStateKey{}, &requestState{event: event, analysis: analysis})
+}
+
+// serve runs the analysis and, if the request may proceed, calls next inside the recovery layer
+func (m *middleware) serve(w http.ResponseWriter, r *http.Request, next func(http.ResponseWriter, *http.Request)) {
+	m.hardenResponse(w, r)
+	r, trace := m.client.startTrace(w, r)
+	r, proceed := m.handle(w, r)
+	m.client.finishTrace(trace, proceed)
+	if m.sessions != nil {
+		m.sessions.observe(r, !proceed)
+	}
+	if !proceed {
+		return
+	}
+	m.verdictHeaders(w, r)
+
+	// Upgraded connections are hijacked, so response wrappers would only get in the way
+	if IsWebSocketUpgrade(r) {
+		next(w, r)
+		return
+	}
+	if m.responses != nil {
+		var done func()
+		w, done = m.responses.wrap(w, r)
+		defer done()
+	}
+	if m.degrader != nil && !trace.dryRun() {
+		var done func()
+		w, done, proceed = m.degrader.wrap(w, r)
+		if !proceed {
+			return
 		}
+		defer done()
+	}
+	if m.canary != nil {
+		var done func()
+		w, done = m.canary.wrap(w, r)
+		defer done()
+	}
+
+	if m.crashes == nil {
+		next(w, r)
+		return
+	}
+
+	// Recovery layer: watch for panics and server errors caused by this input
+	rec := &statusRecorder{ResponseWriter: w}
+	defer m.recoverCrash(rec, r)
+	next(rec, r)
+	m.observeStatus(rec, r)
+}
 
-		// Capture request body
-		var bodyBytes []byte
-		if r.Body != nil {
-			bodyBytes, _ = io.ReadAll(r.Body)
-			r.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+// handle analyzes r. It returns the request to pass downstream and whether to proceed;
+// when proceed is false a response has already been written.
+func (m *middleware) handle(w http.ResponseWriter, r *http.Request) (*http.Request, bool) {
+	client, options := m.client, m.options
+	trace := traceFromContext(r.Context())
+
+	// Check if path should be excluded
+	if m.excluded(r.Method, r.URL.Path) {
+		trace.record("exclusion", time.Now(), "excluded")
+		return r, true
+	}
+
+	// Track user-derived values so sink guards can spot them downstream
+	state := &requestState{}
+	ctx := withTaintTracker(r.Context(), func(finding TaintFinding) {
+		client.log("⚠️ Tainted data reached sink:", finding.SinkType, finding.Tainted)
+	})
+	r = r.WithContext(context.WithValue(ctx, requestStateKey{}, state))
+	for _, values := range r.URL.Query() {
+		for _, value := range values {
+			Taint(r.Context(), value)
 		}
+	}
+
+	// Capture request body
+	start := time.Now()
+	bodyBytes, truncated := m.captureBody(r)
+	trace.record("body_capture", start, fmt.Sprintf("%d bytes, truncated: %t", len(bodyBytes), truncated))
 
-		// Prepare security event
-		event := &SecurityEventRequest{
-			Method:      r.Method,
-			Path:        r.URL.Path,
-			SourceIP:    client.getClientIP(r),
-			UserAgent:   r.UserAgent(),
-			Headers:     client.extractHeaders(r.Header),
-			QueryParams: r.URL.RawQuery,
-			RequestBody: string(bodyBytes),
-			CustomerID:  client.config.CustomerID,
-			HasAuth:     client.hasAuthHeaders(r.Header),
-			SessionID:   client.sessionID,
+	// Prepare security event
+	sourceIP, peerIP := client.requestAddresses(r, m.trustPeer)
+	state.event = &SecurityEventRequest{
+		EventID:     client.newEventID(),
+		Method:      r.Method,
+		Path:        r.URL.Path,
+		SourceIP:    sourceIP,
+		PeerIP:      peerIP,
+		UserAgent:   r.UserAgent(),
+		Headers:     client.extractHeaders(r.Header),
+		QueryParams: r.URL.RawQuery,
+		RequestBody: string(bodyBytes),
+		CustomerID:  client.config.CustomerID,
+		HasAuth:     client.hasAuthHeaders(r.Header),
+		SessionID:   client.sessionID,
+
+		BodyTruncated: truncated,
+	}
+	start = time.Now()
+	m.expandMultipart(state.event)
+	trace.record("multipart", start, fmt.Sprintf("%d files", len(state.event.Files)))
+	if options.RouteFunc != nil {
+		state.event.Route = options.RouteFunc(r)
+	}
+	if options.UserFunc != nil {
+		state.user = options.UserFunc(r)
+	}
+	if m.trust != nil {
+		state.trust = m.trust.identity(r, state)
+	}
+
+	// Reject IPs blocked here or by a sibling instance
+	start = time.Now()
+	decision, blocked := client.IsBlocked(state.event.PeerIP)
+	trace.record("blocklist", start, fmt.Sprintf("blocked: %t", blocked))
+	if blocked && m.overridden(r.Context(), state.event) == nil {
+		client.log("🚫 Request from blocked IP:", decision.IP, decision.Reason)
+		rejection := &Rejection{Status: http.StatusForbidden, Message: blockedMessage, Category: ReasonCategoryBlocklist}
+		if m.enforce(w, r, state.event, rejection) {
+			m.reject(w, r, *rejection)
+			return r, false
 		}
+	}
 
-		// Analyze request
-		analysis, err := client.AnalyzeEvent(event)
-		if err != nil {
-			client.log("Guardial analysis failed:", err)
-			if options.FailOpen {
-				next()
-				return
-			}
-			http.Error(w, "Security analysis failed", http.StatusInternalServerError)
-			return
+	// Refuse forged and replayed webhook deliveries before analyzing their payloads
+	if rejection := m.checkWebhook(r.Context(), state.event, r.Header, bodyBytes, truncated); m.enforce(w, r, state.event, rejection) {
+		m.reject(w, r, *rejection)
+		return r, false
+	}
+
+	// Catch replayed canary tokens before anything else sees the request
+	tripped := false
+	if m.canary != nil {
+		m.watch(r.Context(), DetectorCanary, func() { tripped = m.canary.tripped(r, state.event) })
+	}
+	if tripped {
+		if m.enforce(w, r, state.event, &Rejection{Status: http.StatusUnauthorized, Message: "Canary token replayed", Category: ReasonCategoryCanary}) {
+			m.canary.respond(w, r)
+			return r, false
 		}
+	}
 
-		if !analysis.Allowed {
-			client.log("🚫 Request blocked:", r.Method, r.URL.Path, analysis.RiskReasons)
-			if options.FailOpen {
-				// Store in request context for logging
-				r.Header.Set("X-Guardial-Blocked", "true")
-				r.Header.Set("X-Guardial-Risk-Score", string(rune(analysis.RiskScore)))
-				next()
-				return
-			}
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusForbidden)
-			w.Write([]byte(`{"error":"Request blocked by security policy"}`))
-			return
+	// Check machine-to-machine routes against their expected callers
+	expected := true
+	m.watch(r.Context(), DetectorFingerprint, func() { expected = m.checkFingerprint(r, state.event) })
+	if !expected {
+		rejection := &Rejection{Status: http.StatusForbidden, Message: blockedMessage, Category: ReasonCategoryFingerprint}
+		if m.enforce(w, r, state.event, rejection) {
+			m.reject(w, r, *rejection)
+			return r, false
 		}
+	}
 
-		// Store analysis in request header for downstream handlers
-		r.Header.Set("X-Guardial-Risk-Score", string(rune(analysis.RiskScore)))
-		r.Header.Set("X-Guardial-Event-ID", analysis.EventID)
+	if rejection := m.watchGraphQL(r.Context(), state.event); m.enforce(w, r, state.event, rejection) {
+		m.reject(w, r, *rejection)
+		return r, false
+	}
 
-		next()
+	// Analyze request
+	analysis, rejection := m.analyze(r.Context(), state.event)
+	if rejection.abandoned() {
+		// Nobody is left to answer
+		return r, false
+	}
+	if analysis != nil {
+		state.analysis = analysis
+		m.captureForensics(r, bodyBytes, analysis)
 	}
+	if m.enforce(w, r, state.event, rejection) {
+		m.reject(w, r, *rejection)
+		return r, false
+	}
+
+	// Downstream handlers read the verdict with FromContext
+	return r, true
 }
 
-// StandardMiddleware returns a standard net/http middleware
-// Usage: http.Handle("/", guardial.StandardMiddleware(client)(yourHandler))
-func StandardMiddleware(client *Client, options *MiddlewareOptions) func(http.Handler) http.Handler {
-	if options == nil {
-		options = DefaultMiddlewareOptions()
+// enforce reports whether a request must be refused with rejection; the caller then
+// writes the response. In monitor mode the rejection is reported instead and the
+// request proceeds.
+func (m *middleware) enforce(w http.ResponseWriter, r *http.Request, event *SecurityEventRequest, rejection *Rejection) bool {
+	if rejection == nil {
+		return false
+	}
+	if m.monitor(r.Context(), event, rejection) {
+		if m.options.WouldBlockHeader {
+			w.Header().Set("X-Guardial-Would-Block", "true")
+		}
+		return false
+	}
+	m.decisionHeaders(rejection)
+	for name, value := range rejection.Headers {
+		w.Header().Set(name, value)
 	}
+	return true
+}
 
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Check if path should be excluded
-			for _, excludePath := range options.ExcludePaths {
-				if strings.HasPrefix(r.URL.Path, excludePath) {
-					next.ServeHTTP(w, r)
-					return
-				}
-			}
+// monitor reports rejection and returns true if the middleware is in monitor mode, or
+// the request is a traced dry run
+func (m *middleware) monitor(ctx context.Context, event *SecurityEventRequest, rejection *Rejection) bool {
+	trace := traceFromContext(ctx)
+	trace.record("refusal", time.Now(), fmt.Sprintf("%d %s", rejection.Status, rejection.Message))
+	if m.options.Mode != ModeMonitor && !trace.dryRun() {
+		return false
+	}
+	m.client.log("👀 Would block:", event.Method, event.Path, rejection.Status, rejection.Message, event.EventID)
+	if m.options.OnWouldBlock != nil {
+		m.options.OnWouldBlock(ctx, event, *rejection)
+	}
+	return true
+}
 
-			// Capture request body
-			var bodyBytes []byte
-			if r.Body != nil {
-				bodyBytes, _ = io.ReadAll(r.Body)
-				r.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
-			}
+// excluded reports whether a request is exempt from analysis. Exclusions see the
+// cleaned path, so "/static/../admin" isn't skipped as a "/static/" request.
+func (m *middleware) excluded(method, path string) bool {
+	path = cleanPath(path)
+	for i := range m.exclusions {
+		if m.exclusions[i].matches(method, path) {
+			return true
+		}
+	}
+	if rule := m.route(method, path); rule != nil {
+		return rule.Skip
+	}
+	return false
+}
 
-			// Prepare security event
-			event := &SecurityEventRequest{
-				Method:      r.Method,
-				Path:        r.URL.Path,
-				SourceIP:    client.getClientIP(r),
-				UserAgent:   r.UserAgent(),
-				Headers:     client.extractHeaders(r.Header),
-				QueryParams: r.URL.RawQuery,
-				RequestBody: string(bodyBytes),
-				CustomerID:  client.config.CustomerID,
-				HasAuth:     client.hasAuthHeaders(r.Header),
-				SessionID:   client.sessionID,
-			}
+// analyze samples and analyzes event. It returns the verdict, nil if the request
+// proceeds unanalyzed, and the rejection to answer with if it must not proceed.
+func (m *middleware) analyze(ctx context.Context, event *SecurityEventRequest) (*SecurityEventResponse, *Rejection) {
+	trace := traceFromContext(ctx)
+	if event.RouteMeta == nil {
+		event.RouteMeta = annotatedRoute(event)
+	}
+	// Payment and PII routes are always analyzed
+	sensitive := sensitiveRoute(event.RouteMeta)
+	start := time.Now()
+	if !sensitive && m.benign(event) {
+		trace.record("prefilter", start, "benign, not analyzed")
+		return nil, nil
+	}
 
-			// Analyze request
-			analysis, err := client.AnalyzeEvent(event)
-			if err != nil {
-				client.log("Guardial analysis failed:", err)
-				if options.FailOpen {
-					next.ServeHTTP(w, r)
-					return
-				}
-				http.Error(w, "Security analysis failed", http.StatusInternalServerError)
-				return
+	var identity string
+	var trust TrustRecord
+	var level *TrustLevel
+	if m.trust != nil {
+		if identity = m.trustIdentity(ctx, event); identity != "" {
+			start = time.Now()
+			trust = m.trust.load(ctx, identity)
+			level = m.trust.level(trust.Score)
+			if !sensitive && m.trust.skip(level) {
+				trace.record("trust", start, fmt.Sprintf("score %d, not analyzed", trust.Score))
+				return nil, nil
 			}
+			trace.record("trust", start, fmt.Sprintf("score %d", trust.Score))
+		}
+	}
+	start = time.Now()
+	if !sensitive && !m.sampled(event) {
+		trace.record("sampling", start, "not sampled")
+		return nil, nil
+	}
+	if !sensitive && m.sampler != nil && !m.sampler.sample(event) {
+		trace.record("adaptive_sampling", start, "not sampled")
+		return nil, nil
+	}
 
-			if !analysis.Allowed {
-				client.log("🚫 Request blocked:", r.Method, r.URL.Path, analysis.RiskReasons)
-				if options.FailOpen {
-					r.Header.Set("X-Guardial-Blocked", "true")
-					r.Header.Set("X-Guardial-Risk-Score", string(rune(analysis.RiskScore)))
-					next.ServeHTTP(w, r)
-					return
-				}
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusForbidden)
-				w.Write([]byte(`{"error":"Request blocked by security policy"}`))
-				return
-			}
+	rule := m.route(event.Method, event.Path)
+	failOpen := m.failOpen(rule, sensitive)
+	start = time.Now()
+	analysis, err := m.analyzeEvent(ctx, event)
+	if abandoned(ctx, err) {
+		return m.abandon(ctx, event, start)
+	}
+	if err != nil {
+		m.client.warn("Guardial analysis failed:", err)
+		trace.record("analysis", start, fmt.Sprintf("failed, fail open: %t", failOpen))
+		if failOpen {
+			return nil, nil
+		}
+		return nil, &Rejection{Status: http.StatusInternalServerError, Message: "Security analysis failed"}
+	}
+	trace.record("analysis", start, fmt.Sprintf("allowed: %t, action %q, risk %d", analysis.Allowed, analysis.Action, analysis.RiskScore))
+	if rule != nil && rule.Policy != nil {
+		start = time.Now()
+		enforcePolicy(*rule.Policy, "route "+rule.Path, event, analysis)
+		trace.record("route_policy", start, fmt.Sprintf("route %s, allowed: %t", rule.Path, analysis.Allowed))
+	} else if sensitive {
+		start = time.Now()
+		enforcePolicy(Policy{BlockThreshold: sensitiveBlockThreshold}, event.RouteMeta.DataClass+" route", event, analysis)
+		trace.record("route_policy", start, fmt.Sprintf("%s route, allowed: %t", event.RouteMeta.DataClass, analysis.Allowed))
+	}
+	if identity != "" {
+		start = time.Now()
+		m.trust.observe(ctx, identity, trust, analysis)
+		m.trust.enforce(level, trust.Score, event, analysis)
+		trace.record("trust_enforcement", start, fmt.Sprintf("allowed: %t", analysis.Allowed))
+	}
+	if m.sampler != nil {
+		m.sampler.observe(event, analysis)
+	}
+	if m.options.Review != nil && m.options.Review.Queue != nil && analysis.Action == ActionReview {
+		start = time.Now()
+		m.review(ctx, event, analysis)
+		trace.record("review", start, fmt.Sprintf("allowed: %t", analysis.Allowed))
+	}
+
+	if !analysis.Allowed {
+		if override := m.overridden(ctx, event); override != nil {
+			m.client.log("🔓 Blocked request allowed by override:", event.Method, event.Path, override.ID)
+			applyOverride(analysis, override)
+			trace.record("override", time.Now(), override.ID)
+			return analysis, nil
+		}
+		m.client.log("🚫 Request blocked:", event.Method, event.Path, analysis.RiskReasons, event.EventID)
+		return analysis, &Rejection{Status: http.StatusForbidden, Message: blockedMessage, Analysis: analysis}
+	}
+	return analysis, nil
+}
+
+// GinMiddleware returns a Gin-style middleware handler
+// Usage: router.Use(guardial.GinMiddleware(client))
+//
+// next serves the original writer and request, so response inspection, canaries, crash
+// telemetry, and sink guards can't see what it does. HandlerMiddleware hands next the
+// wrapped writer and analyzed request instead.
+//
+// Deprecated: the returned function does not satisfy gin.HandlerFunc. Use
+// guardialgin.Middleware from github.com/divyankvijayvergiya/guardial-sdk/guardialgin.
+func GinMiddleware(client *Client, options *MiddlewareOptions) func(http.ResponseWriter, *http.Request, func()) {
+	m := newMiddleware(client, options)
+	return func(w http.ResponseWriter, r *http.Request, next func()) {
+		m.serve(w, r, func(http.ResponseWriter, *http.Request) { next() })
+	}
+}
+
+// HandlerMiddleware returns a middleware handler for routers that chain with a next
+// function. next must serve the writer and request it is given: they carry the response
+// wrappers and analysis state.
+func HandlerMiddleware(client *Client, options *MiddlewareOptions) func(http.ResponseWriter, *http.Request, func(http.ResponseWriter, *http.Request)) {
+	return newMiddleware(client, options).serve
+}
 
-			// Store analysis in request header
-			r.Header.Set("X-Guardial-Risk-Score", string(rune(analysis.RiskScore)))
-			r.Header.Set("X-Guardial-Event-ID", analysis.EventID)
+// StandardMiddleware returns a standard net/http middleware
+// Usage: http.Handle("/", guardial.StandardMiddleware(client)(yourHandler))
+func StandardMiddleware(client *Client, options *MiddlewareOptions) func(http.Handler) http.Handler {
+	m := newMiddleware(client, options)
 
-			next.ServeHTTP(w, r)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			m.serve(w, r, next.ServeHTTP)
 		})
 	}
 }
@@ -181,5 +671,46 @@ func Middleware(options *MiddlewareOptions) (func(http.ResponseWriter, *http.Req
 	return GinMiddleware(client, options), nil
 }
 
+// HandlerMiddlewareFromEnv is HandlerMiddleware with a client created from environment
+// variables
+func HandlerMiddlewareFromEnv(options *MiddlewareOptions) (func(http.ResponseWriter, *http.Request, func(http.ResponseWriter, *http.Request)), error) {
+	client, err := NewClientFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return HandlerMiddleware(client, options), nil
+}
+
+// statusRecorder captures the status code written by downstream handlers
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
 
+func (s *statusRecorder) WriteHeader(code int) {
+	if !s.wroteHeader {
+		s.status = code
+		s.wroteHeader = true
+	}
+	s.ResponseWriter.WriteHeader(code)
+}
 
+func (s *statusRecorder) Write(b []byte) (int, error) {
+	if !s.wroteHeader {
+		s.WriteHeader(http.StatusOK)
+	}
+	return s.ResponseWriter.Write(b)
+}
+
+// Flush implements http.Flusher when the underlying writer supports it
+func (s *statusRecorder) Flush() {
+	if flusher, ok := s.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
@@ -0,0 +1,147 @@
+package guardial
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newTestClient returns a client whose API answers every call with handler
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	return newConfiguredTestClient(t, handler, nil)
+}
+
+// newConfiguredTestClient is newTestClient with configure applied to the config, if set
+func newConfiguredTestClient(t *testing.T, handler http.HandlerFunc, configure func(config *Config)) *Client {
+	t.Helper()
+	api := httptest.NewServer(handler)
+	t.Cleanup(api.Close)
+
+	config := DefaultConfig()
+	config.Endpoint = api.URL
+	config.APIKey = "test"
+	config.Retry = nil
+	config.CircuitBreaker = nil
+	if configure != nil {
+		configure(config)
+	}
+	client := NewClient(config)
+	t.Cleanup(func() { client.Close(context.Background()) })
+	return client
+}
+
+// verdictAPI answers event analysis with verdict, and fails with 503 for an empty one
+func verdictAPI(verdict string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if verdict == "" {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(verdict))
+	}
+}
+
+const (
+	allowedVerdict = `{"allowed":true,"action":"allow","risk_score":5}`
+	blockedVerdict = `{"allowed":false,"action":"block","risk_score":95}`
+)
+
+func TestMiddlewareVerdicts(t *testing.T) {
+	tests := []struct {
+		name       string
+		verdict    string // "" fails analysis
+		configure  func(options *MiddlewareOptions)
+		wantStatus int
+		wantWould  bool // OnWouldBlock called and X-Guardial-Would-Block set
+	}{
+		{"allowed", allowedVerdict, nil, http.StatusOK, false},
+		{"blocked by default", blockedVerdict, nil, http.StatusForbidden, false},
+		{"blocked with FailOpen", blockedVerdict, func(o *MiddlewareOptions) { o.FailOpen = true }, http.StatusForbidden, false},
+		{"blocked without FailOpen", blockedVerdict, func(o *MiddlewareOptions) { o.FailOpen = false }, http.StatusForbidden, false},
+		{"blocked on FailOpen route", blockedVerdict, func(o *MiddlewareOptions) {
+			o.Routes = []RouteRule{{Path: "/api/*", FailOpen: true}}
+		}, http.StatusForbidden, false},
+		{"analysis failure with FailOpen", "", nil, http.StatusOK, false},
+		{"analysis failure without FailOpen", "", func(o *MiddlewareOptions) { o.FailOpen = false }, http.StatusInternalServerError, false},
+		{"analysis failure on FailClosed route", "", func(o *MiddlewareOptions) {
+			o.Routes = []RouteRule{{Path: "/api/*", FailClosed: true}}
+		}, http.StatusInternalServerError, false},
+		{"monitor blocked", blockedVerdict, func(o *MiddlewareOptions) { o.Mode = ModeMonitor }, http.StatusOK, true},
+		{"monitor allowed", allowedVerdict, func(o *MiddlewareOptions) { o.Mode = ModeMonitor }, http.StatusOK, false},
+		{"monitor analysis failure with FailOpen", "", func(o *MiddlewareOptions) { o.Mode = ModeMonitor }, http.StatusOK, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, verdictAPI(tt.verdict))
+			options := DefaultMiddlewareOptions()
+			options.WouldBlockHeader = true
+			wouldBlock := false
+			options.OnWouldBlock = func(ctx context.Context, event *SecurityEventRequest, rejection Rejection) {
+				wouldBlock = true
+			}
+			if tt.configure != nil {
+				tt.configure(options)
+			}
+			handler := StandardMiddleware(client, options)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest("POST", "/api/orders", nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if wouldBlock != tt.wantWould {
+				t.Errorf("OnWouldBlock called = %t, want %t", wouldBlock, tt.wantWould)
+			}
+			if got := rec.Header().Get("X-Guardial-Would-Block") == "true"; got != tt.wantWould {
+				t.Errorf("X-Guardial-Would-Block set = %t, want %t", got, tt.wantWould)
+			}
+		})
+	}
+}
+
+func TestNilMiddlewareOptionsRefuseBlocked(t *testing.T) {
+	client := newTestClient(t, verdictAPI(blockedVerdict))
+	handler := StandardMiddleware(client, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("blocked request reached the handler")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/api/orders", nil))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
+
+func TestGinMiddlewareCallsNext(t *testing.T) {
+	tests := []struct {
+		name       string
+		verdict    string
+		wantNext   bool
+		wantStatus int
+	}{
+		{"allowed", allowedVerdict, true, http.StatusOK},
+		{"blocked", blockedVerdict, false, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, verdictAPI(tt.verdict))
+			handler := GinMiddleware(client, DefaultMiddlewareOptions())
+
+			called := false
+			rec := httptest.NewRecorder()
+			handler(rec, httptest.NewRequest("GET", "/api/orders", nil), func() { called = true })
+			if called != tt.wantNext {
+				t.Errorf("next called = %t, want %t", called, tt.wantNext)
+			}
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+/**
+ * Guardial Go SDK Wire Encoding
+ * MessagePack encoding of event and prompt payloads as a cheaper alternative to JSON
+ */
+
+package guardial
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// Wire encodings for event and prompt payloads
+const (
+	EncodingJSON    = "json"
+	EncodingMsgpack = "msgpack"
+)
+
+// Content types for each wire encoding
+const (
+	contentTypeJSON    = "application/json"
+	contentTypeMsgpack = "application/msgpack"
+)
+
+// encodePayload serializes payload in the configured wire encoding. Only event and
+// prompt payloads use msgpack; everything else, and backends that rejected msgpack,
+// get JSON.
+func (c *Client) encodePayload(payload interface{}) ([]byte, string, error) {
+	if c.config.WireEncoding == EncodingMsgpack && !c.msgpackRejected.Load() {
+		switch payload.(type) {
+		case *SecurityEventRequest, LLMGuardRequest, batchRequest:
+			data, err := marshalMsgpack(payload)
+			if err != nil {
+				return nil, "", err
+			}
+			return data, contentTypeMsgpack, nil
+		}
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, contentTypeJSON, nil
+}
+
+// marshalMsgpack encodes v as MessagePack, naming struct fields after their json tags
+func marshalMsgpack(v interface{}) ([]byte, error) {
+	var e msgpackEncoder
+	if err := e.encode(reflect.ValueOf(v)); err != nil {
+		return nil, fmt.Errorf("failed to encode msgpack: %w", err)
+	}
+	return e.buf, nil
+}
+
+type msgpackEncoder struct {
+	buf []byte
+}
+
+func (e *msgpackEncoder) encode(v reflect.Value) error {
+	if !v.IsValid() {
+		e.buf = append(e.buf, 0xc0)
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			e.buf = append(e.buf, 0xc0)
+			return nil
+		}
+		return e.encode(v.Elem())
+	case reflect.Bool:
+		if v.Bool() {
+			e.buf = append(e.buf, 0xc3)
+		} else {
+			e.buf = append(e.buf, 0xc2)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		e.encodeInt(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		e.encodeUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		e.buf = append(e.buf, 0xcb)
+		e.buf = binary.BigEndian.AppendUint64(e.buf, math.Float64bits(v.Float()))
+	case reflect.String:
+		e.encodeString(v.String())
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			e.buf = append(e.buf, 0xc0)
+			return nil
+		}
+		e.encodeLength(v.Len(), 0x90, 0xdc, 0xdd)
+		for i := 0; i < v.Len(); i++ {
+			if err := e.encode(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			e.buf = append(e.buf, 0xc0)
+			return nil
+		}
+		if v.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("unsupported map key type %s", v.Type().Key())
+		}
+		e.encodeLength(v.Len(), 0x80, 0xde, 0xdf)
+		iter := v.MapRange()
+		for iter.Next() {
+			e.encodeString(iter.Key().String())
+			if err := e.encode(iter.Value()); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		return e.encodeStruct(v)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// encodeStruct writes a map of the exported fields, honoring json tag names, "-", and omitempty
+func (e *msgpackEncoder) encodeStruct(v reflect.Value) error {
+	type field struct {
+		name  string
+		value reflect.Value
+	}
+	var fields []field
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name, options, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		value := v.Field(i)
+		if strings.Contains(options, "omitempty") && value.IsZero() {
+			continue
+		}
+		fields = append(fields, field{name, value})
+	}
+
+	e.encodeLength(len(fields), 0x80, 0xde, 0xdf)
+	for _, f := range fields {
+		e.encodeString(f.name)
+		if err := e.encode(f.value); err != nil {
+			return fmt.Errorf("field %s: %w", f.name, err)
+		}
+	}
+	return nil
+}
+
+func (e *msgpackEncoder) encodeInt(n int64) {
+	switch {
+	case n >= 0:
+		e.encodeUint(uint64(n))
+	case n >= -32:
+		e.buf = append(e.buf, byte(n))
+	case n >= math.MinInt8:
+		e.buf = append(e.buf, 0xd0, byte(n))
+	case n >= math.MinInt16:
+		e.buf = binary.BigEndian.AppendUint16(append(e.buf, 0xd1), uint16(n))
+	case n >= math.MinInt32:
+		e.buf = binary.BigEndian.AppendUint32(append(e.buf, 0xd2), uint32(n))
+	default:
+		e.buf = binary.BigEndian.AppendUint64(append(e.buf, 0xd3), uint64(n))
+	}
+}
+
+func (e *msgpackEncoder) encodeUint(n uint64) {
+	switch {
+	case n <= 0x7f:
+		e.buf = append(e.buf, byte(n))
+	case n <= math.MaxUint8:
+		e.buf = append(e.buf, 0xcc, byte(n))
+	case n <= math.MaxUint16:
+		e.buf = binary.BigEndian.AppendUint16(append(e.buf, 0xcd), uint16(n))
+	case n <= math.MaxUint32:
+		e.buf = binary.BigEndian.AppendUint32(append(e.buf, 0xce), uint32(n))
+	default:
+		e.buf = binary.BigEndian.AppendUint64(append(e.buf, 0xcf), n)
+	}
+}
+
+func (e *msgpackEncoder) encodeString(s string) {
+	switch n := len(s); {
+	case n <= 31:
+		e.buf = append(e.buf, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		e.buf = append(e.buf, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		e.buf = binary.BigEndian.AppendUint16(append(e.buf, 0xda), uint16(n))
+	default:
+		e.buf = binary.BigEndian.AppendUint32(append(e.buf, 0xdb), uint32(n))
+	}
+	e.buf = append(e.buf, s...)
+}
+
+// encodeLength writes an array or map header: fix is the fix-format prefix for up to 15
+// entries, then the 16- and 32-bit formats
+func (e *msgpackEncoder) encodeLength(n int, fix, len16, len32 byte) {
+	switch {
+	case n <= 15:
+		e.buf = append(e.buf, fix|byte(n))
+	case n <= math.MaxUint16:
+		e.buf = binary.BigEndian.AppendUint16(append(e.buf, len16), uint16(n))
+	default:
+		e.buf = binary.BigEndian.AppendUint32(append(e.buf, len32), uint32(n))
+	}
+}
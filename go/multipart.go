@@ -0,0 +1,155 @@
+/**
+ * Guardial Go SDK Multipart Parsing
+ * Form fields and file uploads of multipart/form-data bodies as structured event data
+ */
+
+package guardial
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/url"
+	"strings"
+
+	"github.com/divyankvijayvergiya/guardial-sdk/types"
+)
+
+// UploadedFile describes one file part of a multipart/form-data request
+type UploadedFile = types.UploadedFile
+
+// Multipart parsing defaults
+const (
+	defaultMaxFieldBytes = 64 * 1024
+	defaultMaxParts      = 100
+)
+
+// MultipartOptions configures parsing of multipart/form-data bodies. The form fields
+// replace the raw body in RequestBody, URL-encoded, and file parts are described in
+// the event's Files. Parsing works on the captured body, so files past MaxBodyBytes
+// are marked truncated and aren't hashed.
+type MultipartOptions struct {
+	MaxFieldBytes int  // Bytes kept per form field value (default: 64KB)
+	MaxParts      int  // Parts examined; later ones are left out and the body marked truncated (default: 100)
+	HashFiles     bool // Add the SHA-256 of each complete file, for malware lookups
+	SampleBytes   int  // Bytes from the start of each file included, base64-encoded (default: 0, none)
+}
+
+// expandMultipart replaces a multipart/form-data RequestBody with its form fields and
+// file descriptions; other bodies, and bodies that don't parse, are left as they are
+func (m *middleware) expandMultipart(event *SecurityEventRequest) {
+	options := m.options.Multipart
+	if options == nil || event.RequestBody == "" {
+		return
+	}
+	mediaType, params, err := mime.ParseMediaType(event.Headers["Content-Type"])
+	if err != nil || mediaType != "multipart/form-data" || params["boundary"] == "" {
+		return
+	}
+
+	maxField := options.MaxFieldBytes
+	if maxField <= 0 {
+		maxField = defaultMaxFieldBytes
+	}
+	maxParts := options.MaxParts
+	if maxParts <= 0 {
+		maxParts = defaultMaxParts
+	}
+
+	reader := multipart.NewReader(strings.NewReader(event.RequestBody), params["boundary"])
+	fields := url.Values{}
+	var files []*UploadedFile
+	truncated := false
+	for parts := 0; ; parts++ {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if parts == 0 {
+				m.client.log("⚠️ Could not parse multipart body:", err)
+				return
+			}
+			truncated = true // Cut off by the body capture limit, or malformed
+			break
+		}
+		if parts == maxParts {
+			part.Close()
+			truncated = true
+			break
+		}
+
+		if part.FileName() == "" {
+			value, complete := readField(part, maxField)
+			fields.Add(part.FormName(), value)
+			truncated = truncated || !complete
+		} else {
+			file := readFile(part, options)
+			files = append(files, file)
+			truncated = truncated || file.Truncated
+		}
+		part.Close()
+	}
+
+	event.RequestBody = fields.Encode()
+	event.Files = files
+	event.BodyTruncated = event.BodyTruncated || truncated
+}
+
+// readField reads up to limit bytes of a form field and reports whether that was all of it
+func readField(part *multipart.Part, limit int) (string, bool) {
+	value, err := io.ReadAll(io.LimitReader(part, int64(limit)))
+	if err != nil {
+		return string(value), false
+	}
+	extra, err := io.Copy(io.Discard, part)
+	return string(value), extra == 0 && err == nil
+}
+
+// readFile describes a file part, hashing and sampling its content as configured
+func readFile(part *multipart.Part, options *MultipartOptions) *UploadedFile {
+	file := &UploadedFile{
+		Field:       part.FormName(),
+		Filename:    part.FileName(),
+		ContentType: part.Header.Get("Content-Type"),
+	}
+
+	hash := sha256.New()
+	var sample bytes.Buffer
+	sink := io.Writer(hash)
+	if options.SampleBytes > 0 {
+		sink = io.MultiWriter(hash, &limitedWriter{buf: &sample, limit: options.SampleBytes})
+	}
+	size, err := io.Copy(sink, part)
+	file.Size = size
+	file.Truncated = err != nil // The captured body ends mid-file
+
+	if options.HashFiles && !file.Truncated {
+		file.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	}
+	if sample.Len() > 0 {
+		file.Sample = base64.StdEncoding.EncodeToString(sample.Bytes())
+	}
+	return file
+}
+
+// limitedWriter keeps the first limit bytes written to it and discards the rest
+type limitedWriter struct {
+	buf   *bytes.Buffer
+	limit int
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if remaining := w.limit - w.buf.Len(); remaining > 0 {
+		if len(p) > remaining {
+			w.buf.Write(p[:remaining])
+		} else {
+			w.buf.Write(p)
+		}
+	}
+	return len(p), nil
+}
@@ -0,0 +1,240 @@
+/**
+ * Guardial Go SDK Allow Overrides
+ * Time-boxed, audited "break glass" bypasses for false positives
+ */
+
+package guardial
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ActionOverride is reported for blocked verdicts an allow override let through
+const ActionOverride = "override"
+
+// maxOverrideTTL bounds how long a single override can last
+const maxOverrideTTL = 7 * 24 * time.Hour
+
+// OverrideSpec describes a temporary bypass. Every field set must match a request for
+// the override to apply, and at least one of IP, User, and Path is required.
+type OverrideSpec struct {
+	IP   string // Client IP or CIDR range, matched against the event's PeerIP
+	User string // User as identified by MiddlewareOptions.UserFunc
+	Path string // Path pattern, as in RouteRule.Path
+
+	TTL      time.Duration // How long the override lasts (at most 7 days)
+	Reason   string        // Why, e.g. the support ticket; required for the audit trail
+	Approver string        // Who approved it; required for the audit trail
+}
+
+// Override is an active allow override
+type Override struct {
+	ID        string    `json:"id"`
+	IP        string    `json:"ip,omitempty"`
+	User      string    `json:"user,omitempty"`
+	Path      string    `json:"path,omitempty"`
+	Reason    string    `json:"reason"`
+	Approver  string    `json:"approver"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Origin    string    `json:"origin"` // Session ID of the instance that granted it
+
+	network *net.IPNet
+}
+
+// matches reports whether the override applies to a request
+func (o *Override) matches(ip, user, requestPath string, now time.Time) bool {
+	if now.After(o.ExpiresAt) {
+		return false
+	}
+	if o.IP != "" {
+		if ip == "" {
+			return false
+		}
+		if o.network != nil {
+			parsed := net.ParseIP(ip)
+			if parsed == nil || !o.network.Contains(parsed) {
+				return false
+			}
+		} else if o.IP != ip {
+			return false
+		}
+	}
+	if o.User != "" && o.User != user {
+		return false
+	}
+	return o.Path == "" || matchPathPattern(o.Path, requestPath)
+}
+
+// prepare parses the override's IP range, if it has one
+func (o *Override) prepare() {
+	if strings.Contains(o.IP, "/") {
+		_, o.network, _ = net.ParseCIDR(o.IP)
+	}
+}
+
+// overrideList holds the overrides this instance knows about
+type overrideList struct {
+	mu        sync.RWMutex
+	overrides map[string]*Override
+}
+
+func newOverrideList() *overrideList {
+	return &overrideList{overrides: make(map[string]*Override)}
+}
+
+// GrantOverride creates a time-boxed allow override: requests matching spec that would
+// be blocked (by analysis or the IP block list) are let through and recorded with
+// Action "override". The override is registered with the API, which keeps the audit
+// trail, and applies on this instance at once; instances with OverrideSyncInterval set
+// pick it up on their next sync.
+func (c *Client) GrantOverride(ctx context.Context, spec OverrideSpec) (*Override, error) {
+	if spec.IP == "" && spec.User == "" && spec.Path == "" {
+		return nil, fmt.Errorf("override needs an IP, User, or Path")
+	}
+	if spec.Reason == "" || spec.Approver == "" {
+		return nil, fmt.Errorf("override needs a Reason and an Approver")
+	}
+	if spec.TTL <= 0 || spec.TTL > maxOverrideTTL {
+		return nil, fmt.Errorf("override TTL must be positive and at most 7 days")
+	}
+	if strings.Contains(spec.IP, "/") {
+		if _, _, err := net.ParseCIDR(spec.IP); err != nil {
+			return nil, fmt.Errorf("invalid override IP range: %w", err)
+		}
+	} else if spec.IP != "" && net.ParseIP(spec.IP) == nil {
+		return nil, fmt.Errorf("invalid override IP %q", spec.IP)
+	}
+
+	now := time.Now()
+	override := &Override{
+		IP:        spec.IP,
+		User:      spec.User,
+		Path:      spec.Path,
+		Reason:    spec.Reason,
+		Approver:  spec.Approver,
+		CreatedAt: now.UTC(),
+		ExpiresAt: now.Add(spec.TTL).UTC(),
+		Origin:    c.sessionID,
+	}
+	var registered Override
+	if err := c.postJSON(ctx, "/api/overrides", override, &registered); err != nil {
+		return nil, fmt.Errorf("failed to register override: %w", err)
+	}
+	override.ID = registered.ID
+	if override.ID == "" {
+		override.ID = "override_" + generateRandomString(16)
+	}
+	override.prepare()
+
+	c.overrides.mu.Lock()
+	c.overrides.overrides[override.ID] = override
+	c.overrides.mu.Unlock()
+	c.log("🔓 Override granted:", override.ID, spec.IP, spec.User, spec.Path, "by", spec.Approver, "until", override.ExpiresAt)
+	return override, nil
+}
+
+// RevokeOverride ends an override before it expires, here and, through the API, on
+// instances that sync overrides
+func (c *Client) RevokeOverride(ctx context.Context, id string) error {
+	if err := c.postJSON(ctx, "/api/overrides/"+url.PathEscape(id)+"/revoke", map[string]string{"origin": c.sessionID}, nil); err != nil {
+		return fmt.Errorf("failed to revoke override: %w", err)
+	}
+	c.overrides.mu.Lock()
+	delete(c.overrides.overrides, id)
+	c.overrides.mu.Unlock()
+	c.log("🔒 Override revoked:", id)
+	return nil
+}
+
+// ActiveOverrides returns the unexpired overrides this instance honors
+func (c *Client) ActiveOverrides() []Override {
+	now := time.Now()
+	c.overrides.mu.RLock()
+	defer c.overrides.mu.RUnlock()
+	var active []Override
+	for _, override := range c.overrides.overrides {
+		if now.Before(override.ExpiresAt) {
+			active = append(active, *override)
+		}
+	}
+	return active
+}
+
+// overridden returns the override letting a request through, or nil. IPs match the
+// PeerIP, as a client-supplied X-Forwarded-For could claim any SourceIP.
+func (m *middleware) overridden(ctx context.Context, event *SecurityEventRequest) *Override {
+	user := ""
+	if state := requestStateFromContext(ctx); state != nil {
+		user = state.user
+	}
+	return m.client.override(event.PeerIP, user, event.Path)
+}
+
+// override returns the first active override matching a request, or nil
+func (c *Client) override(ip, user, requestPath string) *Override {
+	now := time.Now()
+	c.overrides.mu.RLock()
+	defer c.overrides.mu.RUnlock()
+	for _, override := range c.overrides.overrides {
+		if override.matches(ip, user, requestPath, now) {
+			return override
+		}
+	}
+	return nil
+}
+
+// runOverrideSync replaces the known overrides with the API's list every
+// OverrideSyncInterval until the client closes
+func (c *Client) runOverrideSync() {
+	ticker := time.NewTicker(c.config.OverrideSyncInterval)
+	defer ticker.Stop()
+	for {
+		c.refreshed(SyncSourceOverrides, c.syncOverrides(c.ctx))
+		select {
+		case <-ticker.C:
+		case <-c.closing:
+			return
+		}
+	}
+}
+
+func (c *Client) syncOverrides(ctx context.Context) error {
+	var response struct {
+		Overrides []*Override `json:"overrides"`
+	}
+	if err := c.getJSON(ctx, "/api/overrides", &response); err != nil {
+		return err
+	}
+	overrides := make(map[string]*Override, len(response.Overrides))
+	for _, override := range response.Overrides {
+		if override.ID == "" {
+			continue
+		}
+		if override.IP == "" && override.User == "" && override.Path == "" {
+			// It would match every request
+			c.warn("⚠️ Ignoring synced override without an IP, User, or Path:", override.ID)
+			continue
+		}
+		override.prepare()
+		overrides[override.ID] = override
+	}
+	c.overrides.mu.Lock()
+	c.overrides.overrides = overrides
+	c.overrides.mu.Unlock()
+	return nil
+}
+
+// applyOverride lets a blocked verdict through under override
+func applyOverride(analysis *SecurityEventResponse, override *Override) {
+	analysis.Allowed = true
+	analysis.Action = ActionOverride
+	analysis.AddReason(ReasonOverride, ReasonCategoryPolicy, 0,
+		fmt.Sprintf("override %s approved by %s: %s", override.ID, override.Approver, override.Reason))
+}
@@ -0,0 +1,109 @@
+package guardial
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// overrideAPI registers overrides, lists synced, and blocks every analyzed event
+func overrideAPI(synced string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/overrides" && r.Method == "POST":
+			w.Write([]byte(`{"id":"override_1"}`))
+		case r.URL.Path == "/api/overrides":
+			w.Write([]byte(synced))
+		default:
+			w.Write([]byte(blockedVerdict))
+		}
+	}
+}
+
+func TestOverrideMatchesPeerIP(t *testing.T) {
+	tests := []struct {
+		name         string
+		spec         OverrideSpec
+		remoteAddr   string
+		forwardedFor string
+		wantStatus   int
+	}{
+		{"peer matches", OverrideSpec{IP: "192.0.2.1"}, "192.0.2.1:4321", "", http.StatusOK},
+		{"peer in range", OverrideSpec{IP: "192.0.2.0/24"}, "192.0.2.77:4321", "", http.StatusOK},
+		{"spoofed forwarded IP", OverrideSpec{IP: "192.0.2.1"}, "198.51.100.9:4321", "192.0.2.1", http.StatusForbidden},
+		{"spoofed forwarded IP in range", OverrideSpec{IP: "192.0.2.0/24"}, "198.51.100.9:4321", "192.0.2.1", http.StatusForbidden},
+		{"other peer", OverrideSpec{IP: "192.0.2.1"}, "198.51.100.9:4321", "", http.StatusForbidden},
+		{"path matches", OverrideSpec{Path: "/api/*"}, "198.51.100.9:4321", "", http.StatusOK},
+		{"path and peer", OverrideSpec{IP: "192.0.2.1", Path: "/api/*"}, "198.51.100.9:4321", "192.0.2.1", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, overrideAPI(""))
+			spec := tt.spec
+			spec.TTL, spec.Reason, spec.Approver = time.Hour, "SUP-1", "oncall@example.com"
+			if _, err := client.GrantOverride(context.Background(), spec); err != nil {
+				t.Fatalf("GrantOverride() = %v", err)
+			}
+			handler := StandardMiddleware(client, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+			req := httptest.NewRequest("POST", "/api/orders", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestOverriddenIgnoresSourceIP(t *testing.T) {
+	client := newTestClient(t, overrideAPI(""))
+	if _, err := client.GrantOverride(context.Background(), OverrideSpec{
+		IP: "192.0.2.1", TTL: time.Hour, Reason: "SUP-1", Approver: "oncall@example.com",
+	}); err != nil {
+		t.Fatalf("GrantOverride() = %v", err)
+	}
+
+	event := &SecurityEventRequest{Path: "/api/orders", SourceIP: "192.0.2.1", PeerIP: "198.51.100.9"}
+	m := newMiddleware(client, nil)
+	if override := m.overridden(context.Background(), event); override != nil {
+		t.Errorf("overridden() = %s for a forwarded IP, want nil", override.ID)
+	}
+	event.PeerIP = "192.0.2.1"
+	if override := m.overridden(context.Background(), event); override == nil {
+		t.Error("overridden() = nil for the peer IP, want the override")
+	}
+}
+
+func TestSyncOverridesRejectsMatcherless(t *testing.T) {
+	expires := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	synced := `{"overrides":[
+		{"id":"everything","reason":"r","approver":"a","expires_at":"` + expires + `"},
+		{"id":"by_path","path":"/api/*","reason":"r","approver":"a","expires_at":"` + expires + `"},
+		{"id":"by_ip","ip":"192.0.2.1","reason":"r","approver":"a","expires_at":"` + expires + `"},
+		{"path":"/no-id","reason":"r","approver":"a","expires_at":"` + expires + `"}
+	]}`
+	client := newTestClient(t, overrideAPI(strings.ReplaceAll(synced, "\n", "")))
+	if err := client.syncOverrides(context.Background()); err != nil {
+		t.Fatalf("syncOverrides() = %v", err)
+	}
+
+	got := map[string]bool{}
+	for _, override := range client.ActiveOverrides() {
+		got[override.ID] = true
+	}
+	want := map[string]bool{"by_path": true, "by_ip": true}
+	if len(got) != len(want) || !got["by_path"] || !got["by_ip"] {
+		t.Errorf("ActiveOverrides() = %v, want %v", got, want)
+	}
+	if client.override("198.51.100.9", "", "/other") != nil {
+		t.Error("a request matching no override's matchers was overridden")
+	}
+}
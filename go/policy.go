@@ -0,0 +1,247 @@
+/**
+ * Guardial Go SDK Local Policy
+ * Scheduled enforcement policies evaluated locally with timezone awareness
+ */
+
+package guardial
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// ActionMonitor is reported for requests that would have been blocked under a monitor-only policy
+const ActionMonitor = "monitor"
+
+// ActionDegrade is reported for allowed requests that should get degraded responses
+const ActionDegrade = "degrade"
+
+// Policy adjusts how analysis verdicts are enforced
+type Policy struct {
+	// BlockThreshold blocks requests whose risk score is at or above it, in addition to
+	// requests the API already blocked. 0 keeps the API's verdict.
+	BlockThreshold int `json:"block_threshold"`
+
+	// DegradeThreshold marks allowed requests at or above this risk score with Action
+	// "degrade", so the middleware serves degraded responses instead of blocking. 0 disables.
+	DegradeThreshold int `json:"degrade_threshold,omitempty"`
+
+	// MonitorOnly never blocks; would-be blocks are reported with Action "monitor"
+	MonitorOnly bool `json:"monitor_only"`
+
+	// GeoRules apply per-country and per-network actions; the first matching rule wins
+	GeoRules []GeoRule `json:"geo_rules,omitempty"`
+}
+
+// PolicyWindow applies a policy during a recurring time window
+type PolicyWindow struct {
+	Name   string         `json:"name"`
+	Days   []time.Weekday `json:"days"`  // Empty means every day
+	Start  string         `json:"start"` // "HH:MM", inclusive
+	End    string         `json:"end"`   // "HH:MM", exclusive; wraps past midnight if before Start
+	Policy Policy         `json:"policy"`
+}
+
+// PolicySchedule selects the policy in effect at a given time. The first matching
+// window wins; Default applies outside all windows.
+type PolicySchedule struct {
+	Timezone string         `json:"timezone"` // IANA name, e.g. "America/New_York" (default: UTC)
+	Default  Policy         `json:"default"`
+	Windows  []PolicyWindow `json:"windows"`
+
+	locationOnce sync.Once
+	location     *time.Location
+}
+
+// LoadPolicyFile reads a JSON policy schedule from path
+func LoadPolicyFile(path string) (*PolicySchedule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+
+	var schedule PolicySchedule
+	if err := json.Unmarshal(data, &schedule); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file: %w", err)
+	}
+	return &schedule, nil
+}
+
+// validate reports the timezone and window times that would make the schedule fall
+// back to UTC or skip windows, and schedules that enforce nothing at all, which a
+// truncated or blank response would otherwise swap in for the last good schedule
+func (s *PolicySchedule) validate() error {
+	if s.empty() {
+		return fmt.Errorf("invalid policy schedule: no policy set")
+	}
+	if s.Timezone != "" {
+		if _, err := time.LoadLocation(s.Timezone); err != nil {
+			return fmt.Errorf("invalid policy timezone: %w", err)
+		}
+	}
+	for _, window := range s.Windows {
+		for _, clock := range []string{window.Start, window.End} {
+			if _, err := parseClock(clock); err != nil {
+				return fmt.Errorf("invalid time in policy window %q: %w", window.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// empty reports whether every policy of the schedule is the zero Policy
+func (s *PolicySchedule) empty() bool {
+	if !s.Default.zero() {
+		return false
+	}
+	for _, window := range s.Windows {
+		if !window.Policy.zero() {
+			return false
+		}
+	}
+	return true
+}
+
+func (p Policy) zero() bool {
+	return p.BlockThreshold == 0 && p.DegradeThreshold == 0 && !p.MonitorOnly && len(p.GeoRules) == 0
+}
+
+// runPolicySync replaces the enforced policy schedule with the API's every
+// PolicySyncInterval until the client closes. Until the first successful sync,
+// Config.Policy is enforced; afterwards the last good schedule is.
+func (c *Client) runPolicySync() {
+	ticker := time.NewTicker(c.config.PolicySyncInterval)
+	defer ticker.Stop()
+	for {
+		c.refreshed(SyncSourcePolicy, c.syncPolicy(c.ctx))
+		select {
+		case <-ticker.C:
+		case <-c.closing:
+			return
+		}
+	}
+}
+
+func (c *Client) syncPolicy(ctx context.Context) error {
+	schedule := &PolicySchedule{}
+	if err := c.getJSON(ctx, "/api/policy", schedule); err != nil {
+		return err
+	}
+	if err := schedule.validate(); err != nil {
+		return err
+	}
+	c.policy.Store(schedule)
+	return nil
+}
+
+// Active returns the policy in effect at t and the name of the window that selected it
+// ("" for the default policy)
+func (s *PolicySchedule) Active(t time.Time) (Policy, string) {
+	local := t.In(s.loc())
+	for _, window := range s.Windows {
+		if window.contains(local) {
+			return window.Policy, window.Name
+		}
+	}
+	return s.Default, ""
+}
+
+func (s *PolicySchedule) loc() *time.Location {
+	s.locationOnce.Do(func() {
+		s.location = time.UTC
+		if s.Timezone == "" {
+			return
+		}
+		if location, err := time.LoadLocation(s.Timezone); err == nil {
+			s.location = location
+		}
+	})
+	return s.location
+}
+
+// contains reports whether local (already in the schedule's timezone) falls in the window
+func (w PolicyWindow) contains(local time.Time) bool {
+	start, err := parseClock(w.Start)
+	if err != nil {
+		return false
+	}
+	end, err := parseClock(w.End)
+	if err != nil {
+		return false
+	}
+
+	minute := local.Hour()*60 + local.Minute()
+	day := local.Weekday()
+	if end <= start && minute < end {
+		// Early-morning part of a window that started the previous day
+		day = (day + 6) % 7
+	}
+	if len(w.Days) > 0 && !containsWeekday(w.Days, day) {
+		return false
+	}
+
+	if start < end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
+
+// parseClock converts "HH:MM" to minutes after midnight
+func parseClock(clock string) (int, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("invalid clock time %q: %w", clock, err)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func containsWeekday(days []time.Weekday, day time.Weekday) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// applyPolicy enforces the scheduled policy in effect now on the analysis of event
+func (c *Client) applyPolicy(event *SecurityEventRequest, analysis *SecurityEventResponse) {
+	schedule := c.policy.Load()
+	if schedule == nil {
+		return
+	}
+	policy, window := schedule.Active(time.Now())
+	label := "default policy"
+	if window != "" {
+		label = "policy window " + window
+	}
+	enforcePolicy(policy, label, event, analysis)
+}
+
+// enforcePolicy applies policy to the analysis of event; label names the policy in reasons
+func enforcePolicy(policy Policy, label string, event *SecurityEventRequest, analysis *SecurityEventResponse) {
+	if analysis.Allowed && policy.BlockThreshold > 0 && analysis.RiskScore >= policy.BlockThreshold {
+		analysis.Allowed = false
+		analysis.Action = "block"
+		analysis.AddReason(ReasonPolicyThreshold, ReasonCategoryPolicy, 0,
+			fmt.Sprintf("%s: risk score %d at or above threshold %d", label, analysis.RiskScore, policy.BlockThreshold))
+	}
+
+	if analysis.Allowed && policy.DegradeThreshold > 0 && analysis.RiskScore >= policy.DegradeThreshold {
+		analysis.Action = ActionDegrade
+		analysis.AddReason(ReasonPolicyDegrade, ReasonCategoryPolicy, 0,
+			fmt.Sprintf("%s: risk score %d at or above degrade threshold %d", label, analysis.RiskScore, policy.DegradeThreshold))
+	}
+
+	applyGeoRules(policy.GeoRules, event, analysis)
+
+	if !analysis.Allowed && policy.MonitorOnly {
+		analysis.Allowed = true
+		analysis.Action = ActionMonitor
+		analysis.AddReason(ReasonMonitorOnly, ReasonCategoryPolicy, 0, label+": monitor only")
+	}
+}
@@ -0,0 +1,38 @@
+package guardial
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestSyncPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		wantErr   bool
+		wantBlock int // Default BlockThreshold in force afterwards
+	}{
+		{"valid schedule", `{"default":{"block_threshold":60}}`, false, 60},
+		{"window only", `{"windows":[{"name":"night","start":"22:00","end":"06:00","policy":{"monitor_only":true}}]}`, false, 0},
+		{"empty object", `{}`, true, 80},
+		{"zero policies", `{"default":{"block_threshold":0},"windows":[{"name":"night","start":"22:00","end":"06:00"}]}`, true, 80},
+		{"null", `null`, true, 80},
+		{"bad timezone", `{"timezone":"Mars/Olympus","default":{"block_threshold":60}}`, true, 80},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newConfiguredTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(tt.body))
+			}, func(c *Config) { c.Policy = &PolicySchedule{Default: Policy{BlockThreshold: 80}} })
+
+			err := client.syncPolicy(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("syncPolicy() = %v, want error %t", err, tt.wantErr)
+			}
+			if got := client.policy.Load().Default.BlockThreshold; got != tt.wantBlock {
+				t.Errorf("BlockThreshold in force = %d, want %d", got, tt.wantBlock)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+/**
+ * Guardial Go SDK Benign Pre-filter
+ * Cheap local checks that let obviously benign requests skip the API round-trip
+ */
+
+package guardial
+
+import (
+	"net/http"
+	"path"
+	"strings"
+)
+
+// PrefilterOptions lets obviously benign requests proceed unanalyzed, saving the API
+// round-trip and quota. Only GET and HEAD requests without a body qualify, and only if
+// they have no query string and their path is plain (letters, digits, "/", "-", "_",
+// "." and no ".."), so payloads in the URL still reach analysis. Blocked IPs are still refused.
+type PrefilterOptions struct {
+	// StaticExtensions are file extensions of static assets, e.g. ".css"
+	StaticExtensions []string
+
+	// ProbePaths and ProbeUserAgents identify health probes; a request qualifies if its
+	// path is in ProbePaths and, unless ProbeUserAgents is empty, its User-Agent starts
+	// with one of them. The User-Agent is the caller's to choose, so it never qualifies
+	// a request on its own.
+	ProbePaths      []string
+	ProbeUserAgents []string
+
+	// PlainGets lets through every qualifying request, not only assets and probes.
+	// Their headers go unanalyzed, so leave it off where headers are attack surface.
+	PlainGets bool
+}
+
+// DefaultPrefilter returns a pre-filter for static assets and common health probes
+func DefaultPrefilter() *PrefilterOptions {
+	return &PrefilterOptions{
+		StaticExtensions: []string{
+			".css", ".js", ".mjs", ".map", ".png", ".jpg", ".jpeg", ".gif", ".webp", ".avif", ".svg", ".ico",
+			".woff", ".woff2", ".ttf", ".otf", ".eot", ".mp4", ".webm", ".mp3",
+		},
+		ProbePaths:      []string{"/health", "/healthz", "/livez", "/readyz", "/ready", "/ping"},
+		ProbeUserAgents: []string{"kube-probe/", "ELB-HealthChecker/", "GoogleHC/", "Consul Health Check", "Envoy/HC"},
+	}
+}
+
+// benign reports whether the pre-filter lets event through unanalyzed
+func (m *middleware) benign(event *SecurityEventRequest) bool {
+	options := m.options.Prefilter
+	if options == nil {
+		return false
+	}
+	if event.Method != http.MethodGet && event.Method != http.MethodHead {
+		return false
+	}
+	if event.RequestBody != "" || event.BodyTruncated || event.QueryParams != "" || !plainPath(event.Path) {
+		return false
+	}
+
+	if containsString(options.ProbePaths, event.Path) && probeAgent(options.ProbeUserAgents, event.UserAgent) {
+		return true
+	}
+	if extension := strings.ToLower(path.Ext(event.Path)); extension != "" && containsString(options.StaticExtensions, extension) {
+		return true
+	}
+	return options.PlainGets
+}
+
+// probeAgent reports whether userAgent starts with one of agents, or agents is empty
+func probeAgent(agents []string, userAgent string) bool {
+	if len(agents) == 0 {
+		return true
+	}
+	for _, agent := range agents {
+		if agent != "" && strings.HasPrefix(userAgent, agent) {
+			return true
+		}
+	}
+	return false
+}
+
+// plainPath reports whether p holds only characters that can't carry a payload
+func plainPath(p string) bool {
+	if strings.Contains(p, "..") {
+		return false
+	}
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '/', c == '-', c == '_', c == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
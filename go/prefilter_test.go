@@ -0,0 +1,34 @@
+package guardial
+
+import "testing"
+
+func TestPrefilterBenign(t *testing.T) {
+	tests := []struct {
+		name    string
+		options *PrefilterOptions
+		event   SecurityEventRequest
+		want    bool
+	}{
+		{"static asset", DefaultPrefilter(), SecurityEventRequest{Method: "GET", Path: "/assets/app.css"}, true},
+		{"probe path and agent", DefaultPrefilter(), SecurityEventRequest{Method: "GET", Path: "/healthz", UserAgent: "kube-probe/1.29"}, true},
+		{"probe path, browser agent", DefaultPrefilter(), SecurityEventRequest{Method: "GET", Path: "/healthz", UserAgent: "Mozilla/5.0"}, false},
+		{"probe agent on an API path", DefaultPrefilter(), SecurityEventRequest{Method: "GET", Path: "/api/users", UserAgent: "kube-probe/1.29"}, false},
+		{"probe path, any agent allowed", &PrefilterOptions{ProbePaths: []string{"/healthz"}}, SecurityEventRequest{Method: "GET", Path: "/healthz", UserAgent: "curl/8.0"}, true},
+		{"probe with query", DefaultPrefilter(), SecurityEventRequest{Method: "GET", Path: "/healthz", QueryParams: "q=1", UserAgent: "kube-probe/1.29"}, false},
+		{"POST to probe path", DefaultPrefilter(), SecurityEventRequest{Method: "POST", Path: "/healthz", UserAgent: "kube-probe/1.29"}, false},
+		{"traversal in asset path", DefaultPrefilter(), SecurityEventRequest{Method: "GET", Path: "/assets/../admin.js"}, false},
+		{"plain GET", DefaultPrefilter(), SecurityEventRequest{Method: "GET", Path: "/api/users"}, false},
+		{"plain GET with PlainGets", &PrefilterOptions{PlainGets: true}, SecurityEventRequest{Method: "GET", Path: "/api/users"}, true},
+		{"disabled", nil, SecurityEventRequest{Method: "GET", Path: "/assets/app.css"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultMiddlewareOptions()
+			options.Prefilter = tt.options
+			m := newMiddleware(NewClient(&Config{APIKey: "test"}), options)
+			if got := m.benign(&tt.event); got != tt.want {
+				t.Errorf("benign() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+/**
+ * Guardial Go SDK Bulk Prompt Screening
+ * Concurrent PromptGuard calls over large prompt sets, for offline jobs
+ */
+
+package guardial
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+const defaultPromptBatchConcurrency = 8
+
+// PromptBatchError reports the prompts of a PromptGuardBatch call that could not be
+// screened; the others have their verdicts. errors.Is and errors.As see through it
+// to the individual errors, e.g. ErrCircuitOpen or context.Canceled.
+type PromptBatchError struct {
+	Failed map[int]error // Errors by index into the inputs
+	Total  int           // Number of inputs
+}
+
+// Error implements the error interface
+func (e *PromptBatchError) Error() string {
+	first := e.Indexes()[0]
+	return fmt.Sprintf("guardial: %d of %d prompts failed to screen (input %d: %v)", len(e.Failed), e.Total, first, e.Failed[first])
+}
+
+// Unwrap returns the individual errors, in input order
+func (e *PromptBatchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed))
+	for _, i := range e.Indexes() {
+		errs = append(errs, e.Failed[i])
+	}
+	return errs
+}
+
+// Indexes returns the indexes of the failed inputs in ascending order, e.g. to retry them
+func (e *PromptBatchError) Indexes() []int {
+	indexes := make([]int, 0, len(e.Failed))
+	for i := range e.Failed {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	return indexes
+}
+
+// PromptGuardBatch screens many prompts, e.g. to rescan archived prompts for newly
+// discovered jailbreak patterns. Up to Config.PromptBatchConcurrency prompts are in
+// flight at once, each a PromptGuard call with its own retries and AnalysisTimeout.
+//
+// Verdicts are returned in input order. One prompt failing doesn't stop the others:
+// the error is then a *PromptBatchError listing the failed inputs, whose verdicts are
+// left zero. Canceling ctx stops screening; the inputs not yet screened fail with
+// ctx's error.
+func (c *Client) PromptGuardBatch(ctx context.Context, inputs []LLMGuardRequest) ([]LLMGuardResponse, error) {
+	results := make([]LLMGuardResponse, len(inputs))
+	workers := c.config.PromptBatchConcurrency
+	if workers <= 0 {
+		workers = defaultPromptBatchConcurrency
+	}
+	if workers > len(inputs) {
+		workers = len(inputs)
+	}
+
+	var mu sync.Mutex
+	failed := make(map[int]error)
+	fail := func(i int, err error) {
+		mu.Lock()
+		failed[i] = err
+		mu.Unlock()
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				result, err := c.PromptGuardContext(ctx, inputs[i].Input, inputs[i].Context)
+				if err != nil {
+					fail(i, err)
+					continue
+				}
+				results[i] = *result
+			}
+		}()
+	}
+
+dispatch:
+	for i := range inputs {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			for ; i < len(inputs); i++ {
+				fail(i, ctx.Err())
+			}
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if len(failed) > 0 {
+		c.log("⚠️ Prompt batch:", len(failed), "of", len(inputs), "prompts failed to screen")
+		return results, &PromptBatchError{Failed: failed, Total: len(inputs)}
+	}
+	return results, nil
+}
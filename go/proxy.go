@@ -0,0 +1,104 @@
+/**
+ * Guardial Go SDK Reverse Proxy
+ * A WAF-style reverse proxy that analyzes requests before forwarding them
+ */
+
+package guardial
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"time"
+)
+
+// clientIPHeaders are the request headers clientIP takes the client address from
+var clientIPHeaders = []string{"X-Forwarded-For", "X-Real-Ip", "X-Client-Ip"}
+
+// ProxyOptions configures NewReverseProxy
+type ProxyOptions struct {
+	Middleware *MiddlewareOptions // nil uses DefaultMiddlewareOptions
+
+	// TrustForwardedHeaders keeps the X-Forwarded-For, X-Real-Ip, and X-Client-Ip
+	// headers of incoming requests, for proxies behind a load balancer. The client is
+	// then the right-most X-Forwarded-For hop that isn't in Config.TrustedProxies, the
+	// one the load balancer appended, as entries to its left are client-supplied.
+	// Otherwise the headers are dropped, so clients can't choose the IP they are
+	// analyzed and blocked as, and the upstream sees the peer address in X-Forwarded-For.
+	TrustForwardedHeaders bool
+
+	// PreserveHost forwards the incoming Host header instead of the target's host
+	PreserveHost bool
+
+	// FlushInterval flushes the response to the client this often while copying the
+	// body; negative flushes after every write. Streaming responses (no Content-Length,
+	// or text/event-stream) are always flushed immediately.
+	FlushInterval time.Duration
+
+	Transport http.RoundTripper // Transport to the upstream; nil uses http.DefaultTransport
+}
+
+// ReverseProxy forwards requests to an upstream once the middleware lets them through.
+// The embedded httputil.ReverseProxy can be customized (ModifyResponse, ErrorHandler,
+// BufferPool) before it starts serving.
+type ReverseProxy struct {
+	*httputil.ReverseProxy
+	handler http.Handler
+}
+
+// NewReverseProxy returns a reverse proxy to target that runs the full middleware
+// pipeline on each request and forwards only those that may proceed; blocked verdicts
+// are refused whatever FailOpen says, which only covers analysis failures. It sets
+// X-Forwarded-For, X-Forwarded-Host, and X-Forwarded-Proto, and streams request and
+// response bodies, so it can front services in any language; cmd/guardial-proxy runs
+// it as a standalone sidecar.
+func NewReverseProxy(target *url.URL, client *Client, options *ProxyOptions) *ReverseProxy {
+	var opts ProxyOptions
+	if options != nil {
+		opts = *options
+	}
+
+	proxy := &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.SetURL(target)
+			if opts.PreserveHost {
+				pr.Out.Host = pr.In.Host
+			}
+			if opts.TrustForwardedHeaders {
+				// Rewrite starts without them; SetXForwarded appends the peer
+				pr.Out.Header["X-Forwarded-For"] = pr.In.Header["X-Forwarded-For"]
+			}
+			pr.SetXForwarded()
+		},
+		FlushInterval: opts.FlushInterval,
+		Transport:     opts.Transport,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			if abandoned(r.Context(), err) {
+				return // The client is gone; nobody to answer
+			}
+			client.log("⚠️ Upstream request failed:", r.Method, r.URL.Path, err)
+			body, _ := json.Marshal(map[string]string{"error": "Upstream unavailable"})
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write(body)
+		},
+	}
+
+	m := newMiddleware(client, opts.Middleware)
+	m.trustPeer = opts.TrustForwardedHeaders
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !opts.TrustForwardedHeaders {
+			for _, name := range clientIPHeaders {
+				r.Header.Del(name)
+			}
+		}
+		m.serve(w, r, proxy.ServeHTTP)
+	})
+	return &ReverseProxy{ReverseProxy: proxy, handler: handler}
+}
+
+// ServeHTTP analyzes r and forwards it to the upstream if it may proceed
+func (p *ReverseProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.handler.ServeHTTP(w, r)
+}
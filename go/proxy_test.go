@@ -0,0 +1,130 @@
+package guardial
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+)
+
+func TestReverseProxyRefusesBlocked(t *testing.T) {
+	forwarded := 0
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwarded++
+	}))
+	defer upstream.Close()
+	target, _ := url.Parse(upstream.URL)
+
+	tests := []struct {
+		name          string
+		verdict       string
+		options       *ProxyOptions
+		wantStatus    int
+		wantForwarded bool
+	}{
+		{"allowed", allowedVerdict, nil, http.StatusOK, true},
+		{"blocked with nil options", blockedVerdict, nil, http.StatusForbidden, false},
+		{"blocked with default options", blockedVerdict, &ProxyOptions{Middleware: DefaultMiddlewareOptions()}, http.StatusForbidden, false},
+		{"analysis failure fails open", "", nil, http.StatusOK, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forwarded = 0
+			client := newTestClient(t, verdictAPI(tt.verdict))
+			proxy := NewReverseProxy(target, client, tt.options)
+
+			rec := httptest.NewRecorder()
+			proxy.ServeHTTP(rec, httptest.NewRequest("POST", "/api/orders", nil))
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := forwarded > 0; got != tt.wantForwarded {
+				t.Errorf("forwarded = %t, want %t", got, tt.wantForwarded)
+			}
+		})
+	}
+}
+
+func TestReverseProxyClientIP(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer upstream.Close()
+	target, _ := url.Parse(upstream.URL)
+
+	tests := []struct {
+		name           string
+		trustForwarded bool
+		forwardedFor   string
+		wantSourceIP   string
+	}{
+		{"untrusted header dropped", false, "203.0.113.7", "192.0.2.1"},
+		{"trusted single hop", true, "203.0.113.7", "203.0.113.7"},
+		{"trusted spoofed left-most entry", true, "1.2.3.4, 203.0.113.7", "203.0.113.7"},
+		{"trusted without header", true, "", "192.0.2.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var sourceIP string
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				var event SecurityEventRequest
+				json.NewDecoder(r.Body).Decode(&event)
+				mu.Lock()
+				sourceIP = event.SourceIP
+				mu.Unlock()
+				w.Write([]byte(allowedVerdict))
+			})
+			proxy := NewReverseProxy(target, client, &ProxyOptions{TrustForwardedHeaders: tt.trustForwarded})
+
+			req := httptest.NewRequest("POST", "/api/orders", nil)
+			req.RemoteAddr = "192.0.2.1:4321"
+			if tt.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			proxy.ServeHTTP(httptest.NewRecorder(), req)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if sourceIP != tt.wantSourceIP {
+				t.Errorf("SourceIP = %q, want %q", sourceIP, tt.wantSourceIP)
+			}
+		})
+	}
+}
+
+func TestAddresses(t *testing.T) {
+	tests := []struct {
+		name         string
+		proxies      []string
+		trustPeer    bool
+		forwardedFor string
+		remoteAddr   string
+		wantSource   string
+		wantPeer     string
+	}{
+		{"no proxies, no header", nil, false, "", "192.0.2.1:4321", "192.0.2.1", "192.0.2.1"},
+		{"no proxies, spoofed header", nil, false, "1.2.3.4", "192.0.2.1:4321", "1.2.3.4", "192.0.2.1"},
+		{"untrusted peer", []string{"10.0.0.0/8"}, false, "1.2.3.4", "192.0.2.1:4321", "192.0.2.1", "192.0.2.1"},
+		{"trusted peer", []string{"10.0.0.0/8"}, false, "203.0.113.7", "10.0.0.5:4321", "203.0.113.7", "203.0.113.7"},
+		{"trusted peer, spoofed entry", []string{"10.0.0.0/8"}, false, "1.2.3.4, 203.0.113.7", "10.0.0.5:4321", "203.0.113.7", "203.0.113.7"},
+		{"trusted proxy chain", []string{"10.0.0.0/8", "192.0.2.10"}, false, "1.2.3.4, 203.0.113.7, 192.0.2.10", "10.0.0.5:4321", "203.0.113.7", "203.0.113.7"},
+		{"trusted peer, garbage hop", []string{"10.0.0.0/8"}, false, "1.2.3.4, junk", "10.0.0.5:4321", "10.0.0.5", "10.0.0.5"},
+		{"trust any peer", nil, true, "1.2.3.4, 203.0.113.7", "192.0.2.1:4321", "203.0.113.7", "203.0.113.7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(&Config{APIKey: "test", TrustedProxies: tt.proxies})
+			header := func(name string) string {
+				if name == "X-Forwarded-For" {
+					return tt.forwardedFor
+				}
+				return ""
+			}
+			source, peer := client.addresses(header, tt.forwardedFor, tt.remoteAddr, tt.trustPeer)
+			if source != tt.wantSource || peer != tt.wantPeer {
+				t.Errorf("addresses() = %q, %q, want %q, %q", source, peer, tt.wantSource, tt.wantPeer)
+			}
+		})
+	}
+}
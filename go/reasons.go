@@ -0,0 +1,58 @@
+/**
+ * Guardial Go SDK Structured Risk Reasons
+ * Machine-readable codes alongside the prose RiskReasons
+ */
+
+package guardial
+
+import "github.com/divyankvijayvergiya/guardial-sdk/types"
+
+// RiskReason is a machine-readable counterpart of an entry in RiskReasons
+type RiskReason = types.RiskReason
+
+// Reason categories
+const (
+	ReasonCategoryPolicy       = "policy"
+	ReasonCategoryGeo          = "geo"
+	ReasonCategoryCost         = "resource_consumption"
+	ReasonCategorySeverity     = "severity"
+	ReasonCategoryReview       = "review"
+	ReasonCategoryBlocklist    = "blocklist"          // Source IP on the block list
+	ReasonCategoryFingerprint  = "client_fingerprint" // Unexpected caller on a machine-to-machine route
+	ReasonCategoryGraphQL      = "graphql"            // GraphQL operation refused
+	ReasonCategoryCanary       = "canary"             // Canary token replayed
+	ReasonCategoryWebhook      = "webhook"            // Webhook delivery forged, stale, or replayed
+	ReasonCategoryUnclassified = "unclassified"
+)
+
+// Reason codes added by the SDK; detection reasons carry codes assigned by the API
+const (
+	ReasonPolicyThreshold      = "policy_threshold"
+	ReasonPolicyDegrade        = "policy_degrade_threshold"
+	ReasonMonitorOnly          = "policy_monitor_only"
+	ReasonGeoBlocked           = "geo_blocked"
+	ReasonGeoChallenge         = "geo_challenge"
+	ReasonGeoThreshold         = "geo_threshold"
+	ReasonCostLimit            = "cost_limit_exceeded"
+	ReasonSeverityRecalibrated = "severity_recalibrated"
+	ReasonReviewAllowed        = "review_allowed"
+	ReasonReviewDenied         = "review_denied"
+	ReasonOverride             = "allow_override"
+	ReasonTrustAllowed         = "trust_allowed"
+	ReasonUnclassified         = "unclassified"
+)
+
+// structureReasons fills Reasons for API versions that only send prose reasons, so
+// callers can rely on Reasons being populated
+func structureReasons(analysis *SecurityEventResponse) {
+	if len(analysis.Reasons) > 0 {
+		return
+	}
+	for _, message := range analysis.RiskReasons {
+		analysis.Reasons = append(analysis.Reasons, RiskReason{
+			Code:     ReasonUnclassified,
+			Category: ReasonCategoryUnclassified,
+			Message:  message,
+		})
+	}
+}
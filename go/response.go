@@ -0,0 +1,219 @@
+/**
+ * Guardial Go SDK Response Analysis
+ * Captures outgoing responses and submits them for leakage and reflection detection
+ */
+
+package guardial
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/divyankvijayvergiya/guardial-sdk/types"
+)
+
+// ResponseEvent is an outgoing response submitted for analysis
+type ResponseEvent = types.ResponseEvent
+
+// ResponseAnalysis is the verdict on a ResponseEvent
+type ResponseAnalysis = types.ResponseAnalysis
+
+// Response analysis defaults
+const (
+	defaultResponseBodyBytes = 64 * 1024
+	defaultResponseQueueSize = 1000
+	responseAnalysisWorkers  = 4
+)
+
+// defaultResponseContentTypes are the media types whose bodies are captured by default
+var defaultResponseContentTypes = []string{
+	"text/*", "application/json", "application/*+json", "application/xml", "application/*+xml", "application/javascript",
+}
+
+// streamingContentTypes are the media types of responses delivered as a stream, which
+// must reach the client as they are written
+var streamingContentTypes = []string{"text/event-stream", "application/x-ndjson", "application/stream+json", "multipart/x-mixed-replace"}
+
+// isStreamingResponse reports whether a response with header is a stream
+func isStreamingResponse(header http.Header) bool {
+	contentType := header.Get("Content-Type")
+	return contentType != "" && matchMediaType(contentType, streamingContentTypes)
+}
+
+// ResponseAnalysisOptions configures capture of outgoing responses. Responses are
+// submitted in the background after they are sent, so analysis never delays them.
+// Streams (Server-Sent Events and other streaming types, or responses the handler
+// flushes) pass through as written; their bodies aren't captured from the point they
+// start streaming, but their status, headers, and size still are.
+type ResponseAnalysisOptions struct {
+	MaxBodyBytes int      // Response body bytes captured (default: 64KB)
+	ContentTypes []string // Media types whose bodies are captured, in path.Match syntax (default: text and JSON/XML types)
+	QueueSize    int      // Responses waiting for submission; more are dropped (default: 1000)
+
+	// OnResult is called with each response's verdict, e.g. to alert on leaks
+	OnResult func(response *ResponseEvent, analysis *ResponseAnalysis)
+}
+
+// responseAnalyzer submits captured responses from a bounded queue
+type responseAnalyzer struct {
+	client  *Client
+	options ResponseAnalysisOptions
+	queue   chan *ResponseEvent
+}
+
+func newResponseAnalyzer(client *Client, options *ResponseAnalysisOptions) *responseAnalyzer {
+	opts := *options
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = defaultResponseBodyBytes
+	}
+	if len(opts.ContentTypes) == 0 {
+		opts.ContentTypes = defaultResponseContentTypes
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = defaultResponseQueueSize
+	}
+
+	a := &responseAnalyzer{client: client, options: opts, queue: make(chan *ResponseEvent, opts.QueueSize)}
+	for i := 0; i < responseAnalysisWorkers; i++ {
+		client.goBackground(a.run)
+	}
+	return a
+}
+
+// wrap returns the writer for the downstream handler and a function to call once the
+// response is complete
+func (a *responseAnalyzer) wrap(w http.ResponseWriter, r *http.Request) (http.ResponseWriter, func()) {
+	state := requestStateFromContext(r.Context())
+	if state == nil || state.event == nil {
+		return w, func() {} // Excluded path
+	}
+	capture := &responseCapture{ResponseWriter: w, limit: a.options.MaxBodyBytes, contentTypes: a.options.ContentTypes}
+	return capture, func() { a.submit(state, capture) }
+}
+
+// submit queues the captured response, dropping it if the queue is full
+func (a *responseAnalyzer) submit(state *requestState, capture *responseCapture) {
+	if capture.header == nil {
+		capture.header = capture.ResponseWriter.Header()
+	}
+	response := &ResponseEvent{
+		Request:       a.client.anonymize(state.event),
+		StatusCode:    capture.status,
+		Headers:       a.client.anonymizeResponseHeaders(state.event, a.client.extractHeaders(capture.header)),
+		Body:          capture.body.String(),
+		BodyTruncated: capture.truncated,
+		BodyBytes:     capture.written,
+		Streaming:     capture.streaming,
+		Timestamp:     time.Now().UTC().Format(time.RFC3339),
+	}
+	if response.StatusCode == 0 {
+		response.StatusCode = http.StatusOK
+	}
+	if state.analysis != nil {
+		response.EventID = state.analysis.EventID
+	}
+
+	select {
+	case a.queue <- response:
+	default:
+		a.client.log("⚠️ Response analysis queue full, dropping response:", state.event.Method, state.event.Path)
+	}
+}
+
+// run submits queued responses until the client closes, then drains the queue
+func (a *responseAnalyzer) run() {
+	for {
+		select {
+		case response := <-a.queue:
+			a.analyze(a.client.ctx, response)
+		case <-a.client.closing:
+			for {
+				select {
+				case response := <-a.queue:
+					a.analyze(a.client.ctx, response)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (a *responseAnalyzer) analyze(ctx context.Context, response *ResponseEvent) {
+	var analysis ResponseAnalysis
+	if err := a.client.postJSON(ctx, "/api/responses", response, &analysis); err != nil {
+		a.client.warn("⚠️ Response analysis failed:", err)
+		return
+	}
+	if analysis.RiskScore > 0 {
+		a.client.log("⚠️ Risky response:", response.Request.Method, response.Request.Path, response.StatusCode, analysis.RiskScore)
+	}
+	if a.options.OnResult != nil {
+		a.options.OnResult(response, &analysis)
+	}
+}
+
+// responseCapture passes a response through while keeping its status, headers, and
+// the start of its body
+type responseCapture struct {
+	http.ResponseWriter
+	limit        int
+	contentTypes []string
+
+	status    int
+	header    http.Header
+	body      bytes.Buffer
+	capture   bool
+	truncated bool
+	streaming bool
+	written   int64
+}
+
+func (c *responseCapture) WriteHeader(code int) {
+	if c.status == 0 {
+		c.status = code
+		c.header = c.ResponseWriter.Header().Clone()
+		contentType := c.header.Get("Content-Type")
+		c.streaming = isStreamingResponse(c.header)
+		c.capture = !c.streaming && (contentType == "" || matchMediaType(contentType, c.contentTypes))
+	}
+	c.ResponseWriter.WriteHeader(code)
+}
+
+func (c *responseCapture) Write(p []byte) (int, error) {
+	if c.status == 0 {
+		c.WriteHeader(http.StatusOK)
+	}
+	switch {
+	case !c.capture:
+		c.truncated = c.truncated || len(p) > 0
+	case c.body.Len()+len(p) > c.limit:
+		c.body.Write(p[:c.limit-c.body.Len()])
+		c.truncated = true
+	default:
+		c.body.Write(p)
+	}
+	n, err := c.ResponseWriter.Write(p)
+	c.written += int64(n)
+	return n, err
+}
+
+// Flush implements http.Flusher when the underlying writer supports it. A flushed
+// response is treated as a stream, and its body is no longer captured.
+func (c *responseCapture) Flush() {
+	if c.status == 0 {
+		c.WriteHeader(http.StatusOK)
+	}
+	c.streaming = true
+	c.capture = false
+	if flusher, ok := c.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (c *responseCapture) Unwrap() http.ResponseWriter {
+	return c.ResponseWriter
+}
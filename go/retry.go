@@ -0,0 +1,108 @@
+/**
+ * Guardial Go SDK Retries
+ * Exponential backoff with jitter for transient API failures
+ */
+
+package guardial
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+)
+
+// unprocessedStatusCodes are the statuses that say the API didn't act on a request, so
+// even a non-idempotent one can be sent again
+var unprocessedStatusCodes = []int{http.StatusTooManyRequests, http.StatusServiceUnavailable}
+
+// RetryPolicy configures retries of transient failures (network errors, 429, and 5xx).
+// Calls that aren't idempotent, such as submitting events, are only retried when the
+// API can't have acted on them: failed connections, 429, and 503.
+type RetryPolicy struct {
+	MaxAttempts          int           `json:"max_attempts"`           // Total attempts including the first
+	InitialBackoff       time.Duration `json:"initial_backoff"`        // Backoff before the first retry
+	MaxBackoff           time.Duration `json:"max_backoff"`            // Upper bound for any single backoff
+	Multiplier           float64       `json:"multiplier"`             // Backoff growth factor per attempt
+	RetryableStatusCodes []int         `json:"retryable_status_codes"` // API statuses worth retrying
+}
+
+// DefaultRetryPolicy returns the default retry policy: 3 attempts, 100ms doubling up to 2s
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+		Multiplier:     2,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// retryable reports whether err is worth another attempt of a call with method
+func (p *RetryPolicy) retryable(ctx context.Context, method string, err error) bool {
+	// The caller gave up; don't keep trying on its behalf
+	if ctx.Err() != nil {
+		return false
+	}
+	idempotent := method == http.MethodGet || method == http.MethodHead || method == http.MethodPut || method == http.MethodDelete
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if !idempotent && !containsInt(unprocessedStatusCodes, apiErr.StatusCode) {
+			return false
+		}
+		return containsInt(p.RetryableStatusCodes, apiErr.StatusCode)
+	}
+
+	// Network errors are transient by nature, but a request may have been processed
+	// unless the connection never opened
+	var opErr *net.OpError
+	return idempotent || (errors.As(err, &opErr) && opErr.Op == "dial")
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns how long to wait before the given retry (1-based), using full jitter
+// and honoring a Retry-After hint from the API
+func (p *RetryPolicy) backoff(retry int, lastErr error) time.Duration {
+	var apiErr *APIError
+	if errors.As(lastErr, &apiErr) && apiErr.RetryAfter > 0 {
+		if p.MaxBackoff > 0 && apiErr.RetryAfter > p.MaxBackoff {
+			return p.MaxBackoff
+		}
+		return apiErr.RetryAfter
+	}
+
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	ceiling := float64(p.InitialBackoff)
+	for i := 1; i < retry; i++ {
+		ceiling *= multiplier
+	}
+	if p.MaxBackoff > 0 && ceiling > float64(p.MaxBackoff) {
+		ceiling = float64(p.MaxBackoff)
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(ceiling)) + 1)
+}
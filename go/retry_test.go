@@ -0,0 +1,62 @@
+package guardial
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSendRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		status       int
+		wantAttempts int32
+	}{
+		{"GET on 502", http.MethodGet, http.StatusBadGateway, 3},
+		{"POST on 502", http.MethodPost, http.StatusBadGateway, 1},
+		{"POST on 500", http.MethodPost, http.StatusInternalServerError, 1},
+		{"POST on 503", http.MethodPost, http.StatusServiceUnavailable, 3},
+		{"POST on 429", http.MethodPost, http.StatusTooManyRequests, 3},
+		{"POST on 400", http.MethodPost, http.StatusBadRequest, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			client := newConfiguredTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				w.WriteHeader(tt.status)
+			}, func(c *Config) {
+				c.Retry = DefaultRetryPolicy()
+				c.Retry.InitialBackoff = time.Millisecond
+			})
+
+			if _, err := client.send(context.Background(), tt.method, "/api/events", []byte("{}"), "application/json"); err == nil {
+				t.Fatal("send() = nil error")
+			}
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestSendCanceledDuringBackoff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	client := newConfiguredTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		w.WriteHeader(http.StatusBadGateway)
+	}, func(c *Config) {
+		c.Retry = DefaultRetryPolicy()
+		c.Retry.InitialBackoff = time.Hour
+		c.Retry.MaxBackoff = time.Hour
+	})
+
+	_, err := client.send(ctx, http.MethodGet, "/api/overrides", nil, "")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("send() = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,198 @@
+/**
+ * Guardial Go SDK Review Queue
+ * Human review of borderline verdicts, with held or provisionally allowed requests
+ */
+
+package guardial
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ActionReview is reported for borderline verdicts that need a human decision
+const ActionReview = "review"
+
+// ReviewItem is what a ReviewQueue receives for each borderline request
+type ReviewItem struct {
+	ID string `json:"id"` // Pass to Client.ResolveReview
+
+	// Event is the request, with credential headers (Authorization, Cookie, API keys)
+	// masked and Config.Anonymization applied
+	Event      *SecurityEventRequest  `json:"event"`
+	Analysis   *SecurityEventResponse `json:"analysis"`
+	Held       bool                   `json:"held"` // The request is waiting for a decision
+	EnqueuedAt string                 `json:"enqueued_at"`
+}
+
+// ReviewQueue hands borderline requests to human reviewers
+type ReviewQueue interface {
+	Enqueue(ctx context.Context, item *ReviewItem) error
+}
+
+// ReviewQueueFunc adapts a function to a ReviewQueue
+type ReviewQueueFunc func(ctx context.Context, item *ReviewItem) error
+
+// Enqueue implements ReviewQueue
+func (f ReviewQueueFunc) Enqueue(ctx context.Context, item *ReviewItem) error {
+	return f(ctx, item)
+}
+
+// NewWebhookReviewQueue returns a ReviewQueue that POSTs each item as JSON to url; a
+// nil httpClient uses http.DefaultClient
+func NewWebhookReviewQueue(url string, httpClient *http.Client) ReviewQueue {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return ReviewQueueFunc(func(ctx context.Context, item *ReviewItem) error {
+		body, err := json.Marshal(item)
+		if err != nil {
+			return fmt.Errorf("failed to marshal review item: %w", err)
+		}
+		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("failed to create review webhook request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("review webhook failed: %w", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			return fmt.Errorf("review webhook returned status %d", resp.StatusCode)
+		}
+		return nil
+	})
+}
+
+// NewPublishReviewQueue returns a ReviewQueue that publishes each item as JSON through
+// publish, keyed by item ID, e.g. to an SQS queue or Kafka topic
+func NewPublishReviewQueue(publish PublishFunc) ReviewQueue {
+	return ReviewQueueFunc(func(ctx context.Context, item *ReviewItem) error {
+		value, err := json.Marshal(item)
+		if err != nil {
+			return fmt.Errorf("failed to marshal review item: %w", err)
+		}
+		if err := publish(ctx, item.ID, value); err != nil {
+			return fmt.Errorf("failed to publish review item: %w", err)
+		}
+		return nil
+	})
+}
+
+// ReviewOptions configures how the middleware handles verdicts with Action "review"
+type ReviewOptions struct {
+	Queue ReviewQueue
+
+	// Hold keeps a request waiting up to this long for Client.ResolveReview. Zero lets
+	// review requests through provisionally as soon as they are enqueued.
+	Hold time.Duration
+
+	// DenyOnTimeout refuses held requests that weren't resolved in time, or couldn't be
+	// enqueued, instead of letting them through provisionally
+	DenyOnTimeout bool
+}
+
+// reviewRegistry tracks held requests waiting for a decision
+type reviewRegistry struct {
+	mu      sync.Mutex
+	pending map[string]chan bool
+}
+
+func newReviewRegistry() *reviewRegistry {
+	return &reviewRegistry{pending: make(map[string]chan bool)}
+}
+
+// ResolveReview delivers a reviewer's decision for a held request, e.g. from the
+// endpoint the review tool calls back. It returns false if no request with that ID
+// is waiting, because it wasn't held, timed out, or was handled by another instance.
+func (c *Client) ResolveReview(id string, approved bool) bool {
+	r := c.reviews
+	r.mu.Lock()
+	decision, ok := r.pending[id]
+	delete(r.pending, id)
+	r.mu.Unlock()
+	if ok {
+		decision <- approved
+	}
+	return ok
+}
+
+// review enqueues a borderline request and settles its verdict: approved and
+// provisional requests are allowed, denied ones blocked and so refused
+func (m *middleware) review(ctx context.Context, event *SecurityEventRequest, analysis *SecurityEventResponse) {
+	options, reviews := m.options.Review, m.client.reviews
+	snapshot := *analysis
+	item := &ReviewItem{
+		ID:         "review_" + generateRandomString(16),
+		Event:      m.client.anonymize(redactCredentials(event)),
+		Analysis:   &snapshot, // The verdict before review
+		Held:       options.Hold > 0,
+		EnqueuedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if !item.Held {
+		enqueued := m.client.goBackground(func() {
+			if err := options.Queue.Enqueue(m.client.ctx, item); err != nil {
+				m.client.log("⚠️ Failed to enqueue review:", err)
+			}
+		})
+		if !enqueued {
+			// The client is closing and no longer starts background work
+			if err := options.Queue.Enqueue(ctx, item); err != nil {
+				m.client.log("⚠️ Failed to enqueue review:", err)
+			}
+		}
+		settleReview(analysis, true, "allowed provisionally pending review")
+		return
+	}
+
+	decision := make(chan bool, 1)
+	reviews.mu.Lock()
+	reviews.pending[item.ID] = decision
+	reviews.mu.Unlock()
+	defer func() {
+		reviews.mu.Lock()
+		delete(reviews.pending, item.ID)
+		reviews.mu.Unlock()
+	}()
+
+	if err := options.Queue.Enqueue(ctx, item); err != nil {
+		m.client.log("⚠️ Failed to enqueue review:", err)
+		settleReview(analysis, !options.DenyOnTimeout, "review unavailable")
+		return
+	}
+	m.client.log("⏳ Holding request for review:", event.Method, event.Path, item.ID)
+
+	timer := time.NewTimer(options.Hold)
+	defer timer.Stop()
+	select {
+	case approved := <-decision:
+		if approved {
+			settleReview(analysis, true, "approved by reviewer")
+		} else {
+			settleReview(analysis, false, "denied by reviewer")
+		}
+	case <-timer.C:
+		settleReview(analysis, !options.DenyOnTimeout, "review timed out")
+	case <-ctx.Done():
+		settleReview(analysis, false, "client went away during review")
+	}
+}
+
+// settleReview records the outcome of a review on the verdict
+func settleReview(analysis *SecurityEventResponse, allowed bool, outcome string) {
+	analysis.Allowed = allowed
+	code := ReasonReviewAllowed
+	if !allowed {
+		analysis.Action = "block"
+		code = ReasonReviewDenied
+	}
+	analysis.AddReason(code, ReasonCategoryReview, 0, outcome)
+}
@@ -0,0 +1,78 @@
+package guardial
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const reviewVerdict = `{"allowed":true,"action":"review","risk_score":55}`
+
+func TestReviewOutcomes(t *testing.T) {
+	tests := []struct {
+		name       string
+		options    ReviewOptions
+		decide     func(client *Client, item *ReviewItem) // nil leaves the item unresolved
+		wantStatus int
+	}{
+		{"approved", ReviewOptions{Hold: time.Second}, func(c *Client, item *ReviewItem) { c.ResolveReview(item.ID, true) }, http.StatusOK},
+		{"denied", ReviewOptions{Hold: time.Second}, func(c *Client, item *ReviewItem) { c.ResolveReview(item.ID, false) }, http.StatusForbidden},
+		{"timed out", ReviewOptions{Hold: 10 * time.Millisecond}, nil, http.StatusOK},
+		{"timed out, deny", ReviewOptions{Hold: 10 * time.Millisecond, DenyOnTimeout: true}, nil, http.StatusForbidden},
+		{"provisional", ReviewOptions{}, nil, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, verdictAPI(reviewVerdict))
+			review := tt.options
+			review.Queue = ReviewQueueFunc(func(ctx context.Context, item *ReviewItem) error {
+				if tt.decide != nil {
+					go tt.decide(client, item)
+				}
+				return nil
+			})
+			options := DefaultMiddlewareOptions()
+			options.Review = &review
+			handler := StandardMiddleware(client, options)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest("POST", "/api/transfers", nil))
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestReviewItemRedactsCredentials(t *testing.T) {
+	client := newTestClient(t, verdictAPI(reviewVerdict))
+	items := make(chan *ReviewItem, 1)
+	options := DefaultMiddlewareOptions()
+	options.Review = &ReviewOptions{
+		Hold: 10 * time.Millisecond,
+		Queue: ReviewQueueFunc(func(ctx context.Context, item *ReviewItem) error {
+			items <- item
+			return nil
+		}),
+	}
+	handler := StandardMiddleware(client, options)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("handler saw Authorization %q, want it unchanged", got)
+		}
+	}))
+
+	req := httptest.NewRequest("POST", "/api/transfers", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Cookie", "session=secret")
+	req.Header.Set("X-Request-Id", "abc")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	item := <-items
+	for name, want := range map[string]string{"Authorization": redactedValue, "Cookie": redactedValue, "X-Request-Id": "abc"} {
+		if got := item.Event.Headers[name]; got != want {
+			t.Errorf("item header %s = %q, want %q", name, got, want)
+		}
+	}
+}
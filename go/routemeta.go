@@ -0,0 +1,89 @@
+/**
+ * Guardial Go SDK Route Annotations
+ * Route sensitivity declared in code, sent with events and enforced more strictly
+ */
+
+package guardial
+
+import (
+	"sync"
+
+	"github.com/divyankvijayvergiya/guardial-sdk/types"
+)
+
+// RouteMeta describes how sensitive a route is; see AnnotateRoute
+type RouteMeta = types.RouteMeta
+
+// Route sensitivities
+const (
+	SensitivityLow      = "low"
+	SensitivityMedium   = "medium"
+	SensitivityHigh     = "high"
+	SensitivityCritical = "critical"
+)
+
+// Data classes of routes. Routes handling DataClassPayments or DataClassPII get the
+// stricter defaults described at AnnotateRoute.
+const (
+	DataClassPublic   = "public"
+	DataClassInternal = "internal"
+	DataClassPII      = "pii"      // Personal data: names, addresses, government IDs
+	DataClassPayments = "payments" // Card numbers, bank details, payment instructions
+)
+
+// sensitiveBlockThreshold is the risk score from which requests to payment and PII
+// routes are blocked, unless a RouteRule gives them a Policy
+const sensitiveBlockThreshold = 60
+
+type routeAnnotation struct {
+	pattern string
+	meta    RouteMeta
+}
+
+// routeAnnotations are declared by the application, typically next to its route
+// registrations, so they're process-wide rather than per middleware
+var routeAnnotations struct {
+	mu   sync.RWMutex
+	list []routeAnnotation
+}
+
+// AnnotateRoute declares the sensitivity of the routes matching pattern, whatever
+// router serves them. pattern is a route pattern as RouteFunc reports it
+// ("/users/{id}"), or a path pattern as in RouteRule.Path ("/checkout/*"). Annotating
+// a pattern again replaces its metadata; otherwise the first matching annotation applies.
+//
+// The middleware sends the metadata with each event. Routes handling payments or PII
+// get stricter defaults, unless a RouteRule for them says otherwise: they are always
+// analyzed (no prefilter, sampling, or trust skip), fail closed, and block from a risk
+// score of 60.
+func AnnotateRoute(pattern string, meta RouteMeta) {
+	routeAnnotations.mu.Lock()
+	defer routeAnnotations.mu.Unlock()
+	for i := range routeAnnotations.list {
+		if routeAnnotations.list[i].pattern == pattern {
+			routeAnnotations.list[i].meta = meta
+			return
+		}
+	}
+	routeAnnotations.list = append(routeAnnotations.list, routeAnnotation{pattern: pattern, meta: meta})
+}
+
+// annotatedRoute returns a copy of the metadata of the first annotation matching
+// event's route pattern or cleaned path, or nil. Matching the cleaned path keeps
+// "//checkout/pay" and "/static/../checkout/pay" under a "/checkout/*" annotation.
+func annotatedRoute(event *SecurityEventRequest) *RouteMeta {
+	routeAnnotations.mu.RLock()
+	defer routeAnnotations.mu.RUnlock()
+	for _, annotation := range routeAnnotations.list {
+		if (event.Route != "" && annotation.pattern == event.Route) || matchPathPattern(annotation.pattern, event.Path) {
+			meta := annotation.meta
+			return &meta
+		}
+	}
+	return nil
+}
+
+// sensitiveRoute reports whether meta calls for the stricter defaults
+func sensitiveRoute(meta *RouteMeta) bool {
+	return meta != nil && (meta.DataClass == DataClassPayments || meta.DataClass == DataClassPII)
+}
@@ -0,0 +1,42 @@
+package guardial
+
+import "testing"
+
+func TestAnnotatedRoute(t *testing.T) {
+	routeAnnotations.mu.Lock()
+	saved := routeAnnotations.list
+	routeAnnotations.list = nil
+	routeAnnotations.mu.Unlock()
+	t.Cleanup(func() {
+		routeAnnotations.mu.Lock()
+		routeAnnotations.list = saved
+		routeAnnotations.mu.Unlock()
+	})
+	AnnotateRoute("/checkout/*", RouteMeta{DataClass: DataClassPayments})
+	AnnotateRoute("/users/{id}", RouteMeta{DataClass: DataClassPII})
+
+	tests := []struct {
+		route string
+		path  string
+		want  string // DataClass; "" for no annotation
+	}{
+		{"", "/checkout/pay", DataClassPayments},
+		{"", "//checkout/pay", DataClassPayments},
+		{"", "/static/../checkout/pay", DataClassPayments},
+		{"", "/checkout/../static/app.js", ""},
+		{"/users/{id}", "/users/42", DataClassPII},
+		{"", "/users/42", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			meta := annotatedRoute(&SecurityEventRequest{Route: tt.route, Path: tt.path})
+			got := ""
+			if meta != nil {
+				got = meta.DataClass
+			}
+			if got != tt.want {
+				t.Errorf("annotatedRoute(%q, %q) data class = %q, want %q", tt.route, tt.path, got, tt.want)
+			}
+		})
+	}
+}
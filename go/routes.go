@@ -0,0 +1,121 @@
+/**
+ * Guardial Go SDK Route Rules
+ * Per-route thresholds, failure handling, and exclusions for the middleware
+ */
+
+package guardial
+
+import (
+	"path"
+	"strings"
+)
+
+// RouteRule overrides the middleware's behavior for matching requests. The first rule
+// in MiddlewareOptions.Routes that matches a request applies.
+type RouteRule struct {
+	// Path matches request paths with path.Match syntax ("/users/*/settings"). A
+	// trailing "/*" matches the path and everything below it ("/admin/*").
+	Path    string
+	Methods []string // Empty matches every method
+
+	Skip bool // Don't analyze matching requests, like ExcludePaths
+
+	// FailClosed refuses matching requests when analysis fails, and FailOpen lets them
+	// through, whatever MiddlewareOptions.FailOpen says. FailClosed wins if both are
+	// set. Blocked verdicts are refused either way.
+	FailClosed bool
+	FailOpen   bool
+
+	// Policy is enforced on the verdicts of matching requests on top of the client's
+	// policy, e.g. a lower BlockThreshold or MonitorOnly
+	Policy *Policy
+
+	// SecurityHeaders replaces MiddlewareOptions.SecurityHeaders for matching requests,
+	// e.g. to allow framing of an embeddable widget
+	SecurityHeaders *SecurityHeaderOptions
+}
+
+// matches reports whether the rule applies to a request
+func (r *RouteRule) matches(method, requestPath string) bool {
+	if len(r.Methods) > 0 {
+		found := false
+		for _, m := range r.Methods {
+			found = found || strings.EqualFold(m, method)
+		}
+		if !found {
+			return false
+		}
+	}
+	return matchPathPattern(r.Path, requestPath)
+}
+
+// matchPathPattern matches requestPath against a path.Match pattern, where a trailing
+// "/*" also matches everything below the prefix. requestPath is cleaned first, so
+// "//admin/x" and "/static/../admin/x" match rules for "/admin/*".
+func matchPathPattern(pattern, requestPath string) bool {
+	requestPath = cleanPath(requestPath)
+	prefix, subtree := strings.CutSuffix(pattern, "/*")
+	if !subtree {
+		matched, _ := path.Match(pattern, requestPath)
+		return matched
+	}
+
+	// Match the prefix against as many leading segments as it has
+	slashes := strings.Count(prefix, "/")
+	head := requestPath
+	for i, n := 0, 0; i < len(requestPath); i++ {
+		if requestPath[i] != '/' {
+			continue
+		}
+		if n == slashes {
+			head = requestPath[:i]
+			break
+		}
+		n++
+	}
+	if strings.Count(head, "/") != slashes {
+		return false
+	}
+	matched, _ := path.Match(prefix, head)
+	return matched
+}
+
+// route returns the first route rule matching a request, or nil
+func (m *middleware) route(method, requestPath string) *RouteRule {
+	for i := range m.options.Routes {
+		if m.options.Routes[i].matches(method, requestPath) {
+			return &m.options.Routes[i]
+		}
+	}
+	return nil
+}
+
+// failOpen reports whether requests under rule proceed when analysis fails.
+// Sensitive routes (see AnnotateRoute) fail closed unless rule says otherwise.
+func (m *middleware) failOpen(rule *RouteRule, sensitive bool) bool {
+	switch {
+	case rule != nil && rule.FailClosed:
+		return false
+	case rule != nil && rule.FailOpen:
+		return true
+	case sensitive:
+		return false
+	}
+	return m.options.FailOpen
+}
+
+// cleanPath returns the canonical form of a request path, the way net/http's ServeMux
+// routes it: rooted, without empty, "." or ".." segments, and keeping a trailing slash
+func cleanPath(requestPath string) string {
+	if requestPath == "" {
+		return "/"
+	}
+	if requestPath[0] != '/' {
+		requestPath = "/" + requestPath
+	}
+	cleaned := path.Clean(requestPath)
+	if strings.HasSuffix(requestPath, "/") && cleaned != "/" {
+		cleaned += "/"
+	}
+	return cleaned
+}
@@ -0,0 +1,48 @@
+package guardial
+
+import "testing"
+
+func TestMatchPathPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"/admin/*", "/admin", true},
+		{"/admin/*", "/admin/users/42", true},
+		{"/admin/*", "//admin/users", true},
+		{"/admin/*", "/public/../admin/users", true},
+		{"/admin/*", "/admin/./users", true},
+		{"/admin/*", "/administrator", false},
+		{"/public/*", "/public/../admin/users", false},
+		{"/users/*/settings", "/users/42//settings", true},
+		{"/users/*/settings", "/users/42/../7/settings", true},
+		{"/users/*/settings", "/users/42/settings/../../admin", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			if got := matchPathPattern(tt.pattern, tt.path); got != tt.want {
+				t.Errorf("matchPathPattern(%q, %q) = %t, want %t", tt.pattern, tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCleanPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"", "/"},
+		{"/", "/"},
+		{"admin", "/admin"},
+		{"//admin//x", "/admin/x"},
+		{"/static/../admin/", "/admin/"},
+		{"/../../etc/passwd", "/etc/passwd"},
+	}
+	for _, tt := range tests {
+		if got := cleanPath(tt.path); got != tt.want {
+			t.Errorf("cleanPath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+/**
+ * Guardial Go SDK Safe Regular Expressions
+ * Vetted compilation of user-supplied patterns, so rules can't stall the request path
+ */
+
+package guardial
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+)
+
+// Limits on user-supplied regular expressions
+const (
+	maxPatternLength = 1024 // Characters in a pattern
+	maxPatternInsts  = 4096 // Instructions in the compiled program, which bound the cost per input byte
+	maxPatternRepeat = 100  // Largest counted repetition, as in x{n,m}
+	maxMatchInput    = 4096 // Bytes of input a pattern is matched against
+)
+
+// SafeRegexp is a regular expression vetted by CompileSafeRegexp. Matching has RE2
+// semantics (no backreferences or lookaround) and runs in time linear in the input,
+// which is limited to 4KB, so a pattern can't introduce ReDoS.
+type SafeRegexp struct {
+	re *regexp.Regexp
+}
+
+// CompileSafeRegexp compiles a user-supplied or synced pattern, rejecting patterns
+// longer than 1024 characters, counted repetitions above 100, and patterns whose
+// compiled program is large enough to make matching expensive. Rejections match
+// ErrUnsafePattern.
+func CompileSafeRegexp(pattern string) (*SafeRegexp, error) {
+	if len(pattern) > maxPatternLength {
+		return nil, fmt.Errorf("%w: longer than %d characters", ErrUnsafePattern, maxPatternLength)
+	}
+	parsed, err := syntax.Parse(pattern, syntax.Perl)
+	var syntaxErr *syntax.Error
+	if errors.As(err, &syntaxErr) && (syntaxErr.Code == syntax.ErrInvalidRepeatSize ||
+		syntaxErr.Code == syntax.ErrNestingDepth || syntaxErr.Code == syntax.ErrLarge) {
+		return nil, fmt.Errorf("%w: %v", ErrUnsafePattern, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+	if repeat := maxRepeat(parsed); repeat > maxPatternRepeat {
+		return nil, fmt.Errorf("%w: repetition count %d above %d", ErrUnsafePattern, repeat, maxPatternRepeat)
+	}
+	prog, err := syntax.Compile(parsed.Simplify())
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+	if len(prog.Inst) > maxPatternInsts {
+		return nil, fmt.Errorf("%w: compiles to %d instructions, above %d", ErrUnsafePattern, len(prog.Inst), maxPatternInsts)
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+	return &SafeRegexp{re: re}, nil
+}
+
+// maxRepeat returns the largest counted repetition bound in re
+func maxRepeat(re *syntax.Regexp) int {
+	largest := 0
+	if re.Op == syntax.OpRepeat {
+		largest = re.Max
+		if re.Min > largest {
+			largest = re.Min
+		}
+	}
+	for _, sub := range re.Sub {
+		if repeat := maxRepeat(sub); repeat > largest {
+			largest = repeat
+		}
+	}
+	return largest
+}
+
+// MatchString reports whether s matches the pattern. Inputs over 4KB never match:
+// matching a prefix would let padding past the cut satisfy anchored patterns.
+func (r *SafeRegexp) MatchString(s string) bool {
+	if len(s) > maxMatchInput {
+		return false
+	}
+	return r.re.MatchString(s)
+}
+
+// String returns the source pattern
+func (r *SafeRegexp) String() string {
+	return r.re.String()
+}
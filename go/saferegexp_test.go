@@ -0,0 +1,33 @@
+package guardial
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSafeRegexpMatchString(t *testing.T) {
+	padding := strings.Repeat("a", maxMatchInput)
+	tests := []struct {
+		name    string
+		pattern string
+		input   string
+		want    bool
+	}{
+		{"short match", `^/static/[a-z]+$`, "/static/app", true},
+		{"short mismatch", `^/static/[a-z]+$`, "/static/../admin", false},
+		{"input at the limit", `^a+$`, padding, true},
+		{"anchored pattern matched by padding", `^/static/[a-z]+`, "/static/" + padding + "/../admin", false},
+		{"unanchored pattern past the limit", `admin`, padding + "admin", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			re, err := CompileSafeRegexp(tt.pattern)
+			if err != nil {
+				t.Fatalf("CompileSafeRegexp(%q) = %v", tt.pattern, err)
+			}
+			if got := re.MatchString(tt.input); got != tt.want {
+				t.Errorf("MatchString() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
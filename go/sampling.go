@@ -0,0 +1,234 @@
+/**
+ * Guardial Go SDK Sampling
+ * Fixed-rate and adaptive sampling: suspicious traffic in full, benign traffic sparingly
+ */
+
+package guardial
+
+import (
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// alwaysAnalyzePathWords mark endpoints that handle credentials or sessions
+var alwaysAnalyzePathWords = []string{"login", "logon", "signin", "signup", "register", "auth", "token", "password", "session", "account"}
+
+// DefaultAlwaysAnalyze is the AlwaysAnalyze predicate used when SampleRate is set and
+// AlwaysAnalyze isn't. It picks out the traffic most likely to carry attacks:
+// authentication and account endpoints, GraphQL operations, and requests other than
+// GET, HEAD, and OPTIONS that carry a body.
+func DefaultAlwaysAnalyze(event *SecurityEventRequest) bool {
+	lower := strings.ToLower(event.Path)
+	for _, word := range alwaysAnalyzePathWords {
+		if strings.Contains(lower, word) {
+			return true
+		}
+	}
+	if len(event.GraphQL) > 0 {
+		return true
+	}
+	switch event.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return event.RequestBody != "" || len(event.Files) > 0 || event.BodyTruncated
+}
+
+// sampled reports whether event passes MiddlewareOptions.SampleRate
+func (m *middleware) sampled(event *SecurityEventRequest) bool {
+	rate := m.options.SampleRate
+	if rate <= 0 || rate >= 1 {
+		return true
+	}
+	always := m.options.AlwaysAnalyze
+	if always == nil {
+		always = DefaultAlwaysAnalyze
+	}
+	return always(event) || rand.Float64() < rate
+}
+
+// AdaptiveSamplingOptions configures adaptive sampling in the middleware. Routes and IPs
+// that recently produced detections are always analyzed; routes that keep coming back
+// clean are sampled less and less. A token bucket keeps analyses within EventsPerMinute,
+// with part of it reserved for hot traffic.
+type AdaptiveSamplingOptions struct {
+	EventsPerMinute int           // Budget of analyzed requests per minute (0: no budget)
+	MinRate         float64       // Lowest sample rate for consistently clean routes (default: 0.01)
+	HotTTL          time.Duration // How long a route or IP stays fully sampled after a detection (default: 10m)
+	HotRiskScore    int           // Risk score that counts as a detection even if allowed (default: 50)
+}
+
+const (
+	// maxSampledKeys bounds the routes and IPs the sampler tracks
+	maxSampledKeys = 10000
+	// sampleDecay is applied to a route's rate after every clean analysis
+	sampleDecay = 0.95
+	// hotReserve is the share of the budget only hot traffic may use
+	hotReserve = 0.2
+)
+
+type adaptiveSampler struct {
+	client  *Client
+	options AdaptiveSamplingOptions
+
+	mu        sync.Mutex
+	tokens    float64
+	refilled  time.Time
+	routeRate map[string]float64
+	hot       map[string]time.Time // "route:" and "ip:" keys, to when they cool down
+}
+
+func newAdaptiveSampler(client *Client, options *AdaptiveSamplingOptions) *adaptiveSampler {
+	opts := *options
+	if opts.MinRate <= 0 {
+		opts.MinRate = 0.01
+	}
+	if opts.HotTTL <= 0 {
+		opts.HotTTL = 10 * time.Minute
+	}
+	if opts.HotRiskScore <= 0 {
+		opts.HotRiskScore = 50
+	}
+	return &adaptiveSampler{
+		client:    client,
+		options:   opts,
+		tokens:    float64(opts.EventsPerMinute),
+		refilled:  time.Now(),
+		routeRate: make(map[string]float64),
+		hot:       make(map[string]time.Time),
+	}
+}
+
+// sample reports whether event should be analyzed
+func (s *adaptiveSampler) sample(event *SecurityEventRequest) bool {
+	route := sampleRoute(event)
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.isHot("route:"+route, now) || s.isHot("ip:"+event.SourceIP, now) {
+		if !s.take(now, 0) {
+			s.client.log("⚠️ Sampling budget exhausted, skipping hot request:", event.Method, event.Path)
+			return false
+		}
+		return true
+	}
+
+	rate, ok := s.routeRate[route]
+	if !ok {
+		rate = 1
+	}
+	return rand.Float64() < rate && s.take(now, hotReserve)
+}
+
+// take spends a token from the budget, leaving reserve (a share of the budget) untouched.
+// Callers hold s.mu.
+func (s *adaptiveSampler) take(now time.Time, reserve float64) bool {
+	if s.options.EventsPerMinute <= 0 {
+		return true
+	}
+	capacity := float64(s.options.EventsPerMinute)
+	s.tokens += now.Sub(s.refilled).Minutes() * capacity
+	if s.tokens > capacity {
+		s.tokens = capacity
+	}
+	s.refilled = now
+
+	if s.tokens < 1+capacity*reserve {
+		return false
+	}
+	s.tokens--
+	return true
+}
+
+// observe feeds an analysis back into the sampler
+func (s *adaptiveSampler) observe(event *SecurityEventRequest, analysis *SecurityEventResponse) {
+	route := sampleRoute(event)
+	detected := !analysis.Allowed || len(analysis.OwaspDetected) > 0 || analysis.RiskScore >= s.options.HotRiskScore
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if detected {
+		s.routeRate[route] = 1
+		s.markHot("route:"+route, now)
+		s.markHot("ip:"+event.SourceIP, now)
+		return
+	}
+
+	rate, ok := s.routeRate[route]
+	if !ok {
+		if len(s.routeRate) >= maxSampledKeys {
+			return
+		}
+		rate = 1
+	}
+	rate *= sampleDecay
+	if rate < s.options.MinRate {
+		rate = s.options.MinRate
+	}
+	s.routeRate[route] = rate
+}
+
+// isHot reports whether key had a detection within HotTTL. Callers hold s.mu.
+func (s *adaptiveSampler) isHot(key string, now time.Time) bool {
+	until, ok := s.hot[key]
+	if ok && now.After(until) {
+		delete(s.hot, key)
+		return false
+	}
+	return ok
+}
+
+// markHot samples key fully for HotTTL. Callers hold s.mu.
+func (s *adaptiveSampler) markHot(key string, now time.Time) {
+	if _, exists := s.hot[key]; !exists && len(s.hot) >= maxSampledKeys {
+		for k, until := range s.hot {
+			if now.After(until) {
+				delete(s.hot, k)
+			}
+		}
+		if len(s.hot) >= maxSampledKeys {
+			return
+		}
+	}
+	s.hot[key] = now.Add(s.options.HotTTL)
+}
+
+// sampleRoute returns the event's route pattern or, without one, its path with IDs
+// collapsed, so /users/42 and /users/43 share a sample rate
+func sampleRoute(event *SecurityEventRequest) string {
+	if event.Route != "" {
+		return event.Route
+	}
+	segments := strings.Split(event.Path, "/")
+	for i, segment := range segments {
+		if isIDSegment(segment) {
+			segments[i] = ":id"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// isIDSegment reports whether a path segment looks like a numeric, hex, or UUID identifier
+func isIDSegment(segment string) bool {
+	if segment == "" {
+		return false
+	}
+	digits := 0
+	for _, r := range segment {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case r >= 'a' && r <= 'f', r >= 'A' && r <= 'F', r == '-':
+		default:
+			return false
+		}
+	}
+	return digits == len(segment) || (digits > 0 && len(segment) >= 16)
+}
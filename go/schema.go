@@ -0,0 +1,125 @@
+/**
+ * Guardial Go SDK Event Schema Versions
+ * Negotiates the events schema with self-hosted backends of different ages
+ */
+
+package guardial
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/divyankvijayvergiya/guardial-sdk/types"
+)
+
+// SchemaVersion is the newest events schema this SDK produces; see types.SchemaVersion
+const SchemaVersion = types.SchemaVersion
+
+// Schema negotiation headers sent by the API
+const (
+	HeaderSchemaVersion    = "X-Guardial-Schema-Version"     // Newest schema the backend accepts
+	HeaderMinSchemaVersion = "X-Guardial-Min-Schema-Version" // Oldest schema the backend accepts
+)
+
+// ErrSchemaUnsupported is matched by SchemaVersionError
+var ErrSchemaUnsupported = errors.New("guardial: backend requires a newer events schema")
+
+// SchemaVersionError is returned when the backend requires a newer events schema than
+// this SDK supports. Upgrade the SDK to talk to this backend.
+type SchemaVersionError struct {
+	Required  int       // Oldest schema the backend accepts
+	Supported int       // Newest schema this SDK produces
+	Err       *APIError // The API's response
+}
+
+// Error implements the error interface
+func (e *SchemaVersionError) Error() string {
+	return fmt.Sprintf("guardial: backend requires events schema %d, SDK supports up to %d", e.Required, e.Supported)
+}
+
+// Is matches ErrSchemaUnsupported
+func (e *SchemaVersionError) Is(target error) bool {
+	return target == ErrSchemaUnsupported
+}
+
+// Unwrap returns the underlying API error
+func (e *SchemaVersionError) Unwrap() error {
+	return e.Err
+}
+
+// observeSchema tracks the newest schema the backend advertises so later events are
+// down-converted for older backends
+func (c *Client) observeSchema(header http.Header) {
+	advertised, err := strconv.Atoi(header.Get(HeaderSchemaVersion))
+	if err != nil || advertised <= 0 {
+		return
+	}
+	if c.config.SchemaVersion > 0 && advertised > c.config.SchemaVersion {
+		advertised = c.config.SchemaVersion
+	}
+	if advertised > SchemaVersion {
+		advertised = SchemaVersion
+	}
+	if previous := c.schemaVersion.Swap(int32(advertised)); int(previous) != advertised {
+		c.log("Events schema version negotiated:", advertised)
+	}
+}
+
+// eventSchemaVersion returns the schema version events are sent with
+func (c *Client) eventSchemaVersion() int {
+	if negotiated := int(c.schemaVersion.Load()); negotiated > 0 {
+		return negotiated
+	}
+	if c.config.SchemaVersion > 0 && c.config.SchemaVersion < SchemaVersion {
+		return c.config.SchemaVersion
+	}
+	return SchemaVersion
+}
+
+// wireEvent returns event as it should be sent to the backend, down-converted to the
+// negotiated schema version. event itself is never modified.
+func (c *Client) wireEvent(event *SecurityEventRequest) *SecurityEventRequest {
+	wire := *c.anonymize(event)
+	wire.SchemaVersion = c.eventSchemaVersion()
+	if wire.SchemaVersion >= 3 && c.schemaVersion.Load() >= 3 {
+		// Only backends that advertised schema 3 can resolve header references
+		c.headerSets.encode(&wire)
+	}
+	if wire.SchemaVersion < 8 {
+		wire.RouteMeta = nil
+	}
+	if wire.SchemaVersion < 7 {
+		wire.EventID = ""
+	}
+	if wire.SchemaVersion < 6 {
+		wire.Files = nil
+	}
+	if wire.SchemaVersion < 5 {
+		wire.BodyTruncated = false
+	}
+	if wire.SchemaVersion < 4 {
+		wire.GraphQL = nil
+	}
+	if wire.SchemaVersion < 3 {
+		wire.Route = ""
+	}
+	if wire.SchemaVersion < 2 {
+		// Version 1 predates these fields
+		wire.SchemaVersion = 0
+		wire.ASN = 0
+		wire.RequestCost = 0
+		wire.Timestamp = ""
+	}
+	return &wire
+}
+
+// newSchemaVersionError builds the typed error for a 426 Upgrade Required response
+func newSchemaVersionError(resp *http.Response, apiErr *APIError) error {
+	required, err := strconv.Atoi(resp.Header.Get(HeaderMinSchemaVersion))
+	if err != nil {
+		required = SchemaVersion + 1
+	}
+	return &SchemaVersionError{Required: required, Supported: SchemaVersion, Err: apiErr}
+}
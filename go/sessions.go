@@ -0,0 +1,250 @@
+/**
+ * Guardial Go SDK Session Analytics
+ * Periodic per-session rollups of request counts, paths, and risk trajectory
+ */
+
+package guardial
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/divyankvijayvergiya/guardial-sdk/types"
+)
+
+// SessionRollup summarizes one session's traffic over a reporting window
+type SessionRollup = types.SessionRollup
+
+// Session analytics limits
+const (
+	defaultSessionInterval    = time.Minute
+	defaultSessionIdleTimeout = 30 * time.Minute
+	defaultMaxSessions        = 10000
+	maxSessionPaths           = 1000 // Distinct paths counted per session and window
+	maxSessionIPs             = 10   // Source IPs listed per rollup
+	maxSessionScores          = 100  // Risk scores listed per rollup
+	sessionRollupBatchSize    = 500  // Rollups per submission
+)
+
+// SessionAnalyticsOptions configures per-session rollups. Every request the middleware
+// sees counts, including unsampled ones, so rollups pair well with Sampling.
+type SessionAnalyticsOptions struct {
+	Interval    time.Duration // How often rollups are shipped (default: 1m)
+	IdleTimeout time.Duration // Sessions without requests this long are forgotten (default: 30m)
+	MaxSessions int           // Sessions tracked at once; new ones are ignored while full (default: 10000)
+
+	// SessionKey identifies the session r belongs to, e.g. from a session cookie or the
+	// authenticated user; return "" to leave r out. Keys are hashed before they are
+	// shipped, as keyed pseudonyms with Config.Anonymization. Default: client IP and
+	// User-Agent.
+	SessionKey func(r *http.Request) string
+}
+
+// sessionStats is what the exporter knows about one session
+type sessionStats struct {
+	firstSeen time.Time
+	lastSeen  time.Time
+	total     int
+
+	// Current window
+	requests  int
+	analyzed  int
+	blocked   int
+	abandoned int
+	methods   map[string]int
+	paths     map[string]struct{}
+	ips       []string
+	scores    []int
+	scoreSum  int
+	maxScore  int
+}
+
+// sessionExporter aggregates requests per session and ships rollups every Interval
+type sessionExporter struct {
+	client  *Client
+	options SessionAnalyticsOptions
+
+	mu          sync.Mutex
+	sessions    map[string]*sessionStats
+	windowStart time.Time
+}
+
+func newSessionExporter(client *Client, options *SessionAnalyticsOptions) *sessionExporter {
+	opts := *options
+	if opts.Interval <= 0 {
+		opts.Interval = defaultSessionInterval
+	}
+	if opts.IdleTimeout <= 0 {
+		opts.IdleTimeout = defaultSessionIdleTimeout
+	}
+	if opts.MaxSessions <= 0 {
+		opts.MaxSessions = defaultMaxSessions
+	}
+	if opts.SessionKey == nil {
+		opts.SessionKey = func(r *http.Request) string {
+			return client.getClientIP(r) + "\x00" + r.UserAgent()
+		}
+	}
+
+	e := &sessionExporter{
+		client:      client,
+		options:     opts,
+		sessions:    make(map[string]*sessionStats),
+		windowStart: time.Now(),
+	}
+	client.goBackground(e.run)
+	return e
+}
+
+// observe records a request the middleware handled; refused reports whether it was
+// answered without reaching the application
+func (e *sessionExporter) observe(r *http.Request, refused bool) {
+	state := requestStateFromContext(r.Context())
+	if state == nil || state.event == nil {
+		return // Excluded path
+	}
+	key := e.options.SessionKey(r)
+	if key == "" {
+		return
+	}
+	key = e.client.sessionKey(key)
+
+	now := time.Now()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	stats := e.sessions[key]
+	if stats == nil {
+		if len(e.sessions) >= e.options.MaxSessions {
+			return
+		}
+		stats = &sessionStats{firstSeen: now}
+		e.sessions[key] = stats
+	}
+	if stats.methods == nil {
+		stats.methods = make(map[string]int)
+		stats.paths = make(map[string]struct{})
+	}
+
+	event := state.event
+	stats.lastSeen = now
+	stats.total++
+	stats.requests++
+	stats.methods[event.Method]++
+	if len(stats.paths) < maxSessionPaths {
+		stats.paths[event.Path] = struct{}{}
+	}
+	if ip := e.client.anonymizedIP(event); len(stats.ips) < maxSessionIPs && !containsString(stats.ips, ip) {
+		stats.ips = append(stats.ips, ip)
+	}
+	analysis := state.analysis
+	if state.abandoned {
+		stats.abandoned++
+	} else if refused || (analysis != nil && !analysis.Allowed) {
+		stats.blocked++
+	}
+	if analysis != nil {
+		stats.analyzed++
+		stats.scoreSum += analysis.RiskScore
+		if analysis.RiskScore > stats.maxScore {
+			stats.maxScore = analysis.RiskScore
+		}
+		if len(stats.scores) == maxSessionScores {
+			stats.scores = append(stats.scores[:0], stats.scores[1:]...)
+		}
+		stats.scores = append(stats.scores, analysis.RiskScore)
+	}
+}
+
+// run ships rollups every Interval, and a final round when the client closes
+func (e *sessionExporter) run() {
+	ticker := time.NewTicker(e.options.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			e.flush(e.client.ctx)
+		case <-e.client.closing:
+			e.flush(e.client.ctx)
+			return
+		}
+	}
+}
+
+// flush ships a rollup for every session active in the current window, starts a new
+// window, and forgets idle sessions
+func (e *sessionExporter) flush(ctx context.Context) {
+	rollups := e.rollups(time.Now())
+	for start := 0; start < len(rollups); start += sessionRollupBatchSize {
+		end := start + sessionRollupBatchSize
+		if end > len(rollups) {
+			end = len(rollups)
+		}
+		payload := map[string]interface{}{"rollups": rollups[start:end]}
+		if err := e.client.postJSON(ctx, "/api/sessions/rollups", payload, nil); err != nil {
+			e.client.warn("⚠️ Failed to ship session rollups:", err)
+			return
+		}
+	}
+	if len(rollups) > 0 {
+		e.client.log("Session rollups shipped:", len(rollups))
+	}
+}
+
+// rollups closes the current window at now
+func (e *sessionExporter) rollups(now time.Time) []*SessionRollup {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	windowStart := e.windowStart
+	e.windowStart = now
+
+	var rollups []*SessionRollup
+	for key, stats := range e.sessions {
+		if stats.requests == 0 {
+			if now.Sub(stats.lastSeen) >= e.options.IdleTimeout {
+				delete(e.sessions, key)
+			}
+			continue
+		}
+
+		rollup := &SessionRollup{
+			SessionKey:    key,
+			CustomerID:    e.client.config.CustomerID,
+			SessionID:     e.client.sessionID,
+			FirstSeen:     stats.firstSeen.UTC().Format(time.RFC3339),
+			WindowStart:   windowStart.UTC().Format(time.RFC3339),
+			WindowEnd:     now.UTC().Format(time.RFC3339),
+			Requests:      stats.requests,
+			TotalRequests: stats.total,
+			Analyzed:      stats.analyzed,
+			Blocked:       stats.blocked,
+			Abandoned:     stats.abandoned,
+			Methods:       stats.methods,
+			DistinctPaths: len(stats.paths),
+			SourceIPs:     stats.ips,
+			MaxRiskScore:  stats.maxScore,
+			RiskScores:    stats.scores,
+		}
+		if stats.analyzed > 0 {
+			rollup.MeanRiskScore = float64(stats.scoreSum) / float64(stats.analyzed)
+		}
+		rollups = append(rollups, rollup)
+
+		// Start the session's next window
+		*stats = sessionStats{firstSeen: stats.firstSeen, lastSeen: stats.lastSeen, total: stats.total}
+	}
+	return rollups
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+/**
+ * Guardial Go SDK Severity Recalibration
+ * Client-side severity overrides for noisy detection rules
+ */
+
+package guardial
+
+import (
+	"strings"
+)
+
+// SeverityRule overrides the severity of matching detections. Empty match fields match everything.
+type SeverityRule struct {
+	RuleID     string `json:"rule_id"`     // Matches OwaspDetection.PatternMatched
+	Category   string `json:"category"`    // OWASP category code, e.g. "A03"
+	PathPrefix string `json:"path_prefix"` // Only apply to requests under this path
+	Severity   string `json:"severity"`    // New severity: LOW, MEDIUM, HIGH, or CRITICAL
+}
+
+// severityRanks orders severities; unknown severities rank as 0
+var severityRanks = map[string]int{
+	"LOW":      1,
+	"MEDIUM":   2,
+	"HIGH":     3,
+	"CRITICAL": 4,
+}
+
+// blockingSeverity is the lowest severity that keeps a recalibrated request blocked
+const blockingSeverity = "HIGH"
+
+func severityRank(severity string) int {
+	return severityRanks[strings.ToUpper(severity)]
+}
+
+func (r SeverityRule) matches(path string, detection *OwaspDetection) bool {
+	if r.RuleID != "" && r.RuleID != detection.PatternMatched {
+		return false
+	}
+	if r.Category != "" && !strings.HasPrefix(strings.ToUpper(detection.OwaspCategory), strings.ToUpper(r.Category)) {
+		return false
+	}
+	if r.PathPrefix != "" && !strings.HasPrefix(path, r.PathPrefix) {
+		return false
+	}
+	return true
+}
+
+// recalibrateSeverity applies Config.SeverityRules to analysis before it is enforced.
+// The first matching rule wins. A blocked request whose detections were all downgraded
+// below HIGH is allowed.
+func (c *Client) recalibrateSeverity(path string, analysis *SecurityEventResponse) {
+	if len(c.config.SeverityRules) == 0 {
+		return
+	}
+
+	downgraded := false
+	for i := range analysis.OwaspDetected {
+		detection := &analysis.OwaspDetected[i]
+		for _, rule := range c.config.SeverityRules {
+			if !rule.matches(path, detection) {
+				continue
+			}
+			if !strings.EqualFold(detection.Severity, rule.Severity) {
+				if severityRank(rule.Severity) < severityRank(detection.Severity) {
+					downgraded = true
+				}
+				detection.OriginalSeverity = detection.Severity
+				detection.Severity = strings.ToUpper(rule.Severity)
+			}
+			break
+		}
+	}
+
+	if !downgraded || analysis.Allowed || len(analysis.OwaspDetected) == 0 {
+		return
+	}
+	for _, detection := range analysis.OwaspDetected {
+		if severityRank(detection.Severity) >= severityRank(blockingSeverity) {
+			return
+		}
+	}
+
+	c.log("Severity recalibration allowed request:", path)
+	analysis.Allowed = true
+	analysis.Action = "allow"
+	analysis.AddReason(ReasonSeverityRecalibrated, ReasonCategorySeverity, 0, "severity recalibrated below blocking threshold")
+}
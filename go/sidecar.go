@@ -0,0 +1,288 @@
+/**
+ * Guardial Go SDK Analysis Sidecar
+ * Compact framed protocol to a co-located analysis sidecar over a unix socket
+ */
+
+package guardial
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"time"
+)
+
+// Sidecar protocol. Every message is a frame:
+//
+//	uint32 length of type + payload (big endian) | uint8 type | JSON payload
+//
+// The SDK sends a sidecarFrameAnalyze frame holding a SecurityEventRequest; the sidecar
+// answers on the same connection with sidecarFrameVerdict (a SecurityEventResponse) or
+// sidecarFrameError ({"error": "..."}). A connection carries one call at a time and is
+// kept open for the next one.
+const (
+	sidecarFrameAnalyze byte = 1
+	sidecarFrameVerdict byte = 2
+	sidecarFrameError   byte = 3
+)
+
+// maxSidecarFrame bounds frame sizes so a corrupt length can't exhaust memory
+const maxSidecarFrame = 16 * 1024 * 1024
+
+// SidecarConfig sends analysis to a co-located sidecar that hosts the rule engine,
+// instead of the API. Rules stay centrally managed by the sidecar; calls stay local.
+type SidecarConfig struct {
+	SocketPath    string        `json:"socket_path"`     // Unix socket the sidecar listens on
+	Timeout       time.Duration `json:"timeout"`         // Per-call deadline (default: 50ms)
+	MaxConns      int           `json:"max_conns"`       // Pooled connections (default: 8)
+	FallbackToAPI bool          `json:"fallback_to_api"` // Use the API when the sidecar fails
+}
+
+// sidecarClient pools connections to the sidecar. Each slot holds an idle connection or
+// nil, so at most MaxConns connections are open.
+type sidecarClient struct {
+	config SidecarConfig
+	slots  chan net.Conn
+}
+
+func newSidecarClient(config *SidecarConfig) *sidecarClient {
+	cfg := *config
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 50 * time.Millisecond
+	}
+	if cfg.MaxConns <= 0 {
+		cfg.MaxConns = 8
+	}
+	s := &sidecarClient{config: cfg, slots: make(chan net.Conn, cfg.MaxConns)}
+	for i := 0; i < cfg.MaxConns; i++ {
+		s.slots <- nil
+	}
+	return s
+}
+
+// Send implements Transport, analyzing events one frame at a time
+func (s *sidecarClient) Send(ctx context.Context, events []*SecurityEventRequest) ([]*SecurityEventResponse, error) {
+	results := make([]*SecurityEventResponse, len(events))
+	for i, event := range events {
+		analysis, err := s.analyze(ctx, event)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = analysis
+	}
+	return results, nil
+}
+
+// analyze sends event to the sidecar and returns its verdict
+func (s *sidecarClient) analyze(ctx context.Context, event *SecurityEventRequest) (*SecurityEventResponse, error) {
+	var conn net.Conn
+	select {
+	case conn = <-s.slots:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	analysis, conn, err := s.call(ctx, conn, event)
+	s.slots <- conn
+	return analysis, err
+}
+
+// call runs one request on conn, dialing if conn is nil. It returns the connection to
+// reuse, or nil if it was closed.
+func (s *sidecarClient) call(ctx context.Context, conn net.Conn, event *SecurityEventRequest) (*SecurityEventResponse, net.Conn, error) {
+	deadline := time.Now().Add(s.config.Timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+
+	if conn == nil {
+		var err error
+		dialer := net.Dialer{Deadline: deadline}
+		if conn, err = dialer.DialContext(ctx, "unix", s.config.SocketPath); err != nil {
+			return nil, nil, fmt.Errorf("failed to connect to sidecar: %w", err)
+		}
+	}
+	conn.SetDeadline(deadline)
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, conn, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	if err := writeSidecarFrame(conn, sidecarFrameAnalyze, payload); err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("failed to send to sidecar: %w", err)
+	}
+	frameType, payload, err := readSidecarFrame(conn)
+	if err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("failed to read sidecar response: %w", err)
+	}
+
+	switch frameType {
+	case sidecarFrameVerdict:
+		var analysis SecurityEventResponse
+		if err := json.Unmarshal(payload, &analysis); err != nil {
+			return nil, conn, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		return &analysis, conn, nil
+	case sidecarFrameError:
+		var sidecarErr struct {
+			Error string `json:"error"`
+		}
+		json.Unmarshal(payload, &sidecarErr)
+		return nil, conn, fmt.Errorf("sidecar analysis failed: %s", sidecarErr.Error)
+	default:
+		conn.Close()
+		return nil, nil, fmt.Errorf("unexpected sidecar frame type %d", frameType)
+	}
+}
+
+// warm opens every pooled connection ahead of the first call
+func (s *sidecarClient) warm(ctx context.Context) error {
+	conns := make([]net.Conn, 0, cap(s.slots))
+	defer func() {
+		for _, conn := range conns {
+			s.slots <- conn
+		}
+	}()
+
+	for len(conns) < cap(s.slots) {
+		var conn net.Conn
+		select {
+		case conn = <-s.slots:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if conn == nil {
+			var err error
+			dialer := net.Dialer{Timeout: s.config.Timeout}
+			if conn, err = dialer.DialContext(ctx, "unix", s.config.SocketPath); err != nil {
+				s.slots <- nil
+				return fmt.Errorf("failed to connect to sidecar: %w", err)
+			}
+		}
+		conns = append(conns, conn)
+	}
+	return nil
+}
+
+// Close implements Transport. It closes idle connections; calls in flight finish normally.
+func (s *sidecarClient) Close() error {
+	drained := 0
+	for drained < cap(s.slots) {
+		select {
+		case conn := <-s.slots:
+			if conn != nil {
+				conn.Close()
+			}
+			drained++
+			continue
+		default:
+		}
+		break
+	}
+	for ; drained > 0; drained-- {
+		s.slots <- nil
+	}
+	return nil
+}
+
+func writeSidecarFrame(w io.Writer, frameType byte, payload []byte) error {
+	frame := make([]byte, 5+len(payload))
+	binary.BigEndian.PutUint32(frame, uint32(1+len(payload)))
+	frame[4] = frameType
+	copy(frame[5:], payload)
+	_, err := w.Write(frame)
+	return err
+}
+
+func readSidecarFrame(r io.Reader) (byte, []byte, error) {
+	var header [5]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, nil, err
+	}
+	length := binary.BigEndian.Uint32(header[:4])
+	if length == 0 || length > maxSidecarFrame {
+		return 0, nil, fmt.Errorf("invalid sidecar frame length %d", length)
+	}
+	payload := make([]byte, length-1)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, nil, err
+	}
+	return header[4], payload, nil
+}
+
+// SidecarAnalyzer analyzes an event inside a sidecar process
+type SidecarAnalyzer func(ctx context.Context, event *SecurityEventRequest) (*SecurityEventResponse, error)
+
+// ServeSidecar answers sidecar protocol calls on ln with analyze until ctx is canceled,
+// for sidecars written in Go. ln is closed on return.
+func ServeSidecar(ctx context.Context, ln net.Listener, analyze SidecarAnalyzer) error {
+	defer closeOnDone(ctx, ln)()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to accept sidecar connection: %w", err)
+		}
+		go serveSidecarConn(ctx, conn, analyze)
+	}
+}
+
+// closeOnDone closes c when ctx is canceled or the returned function is called
+func closeOnDone(ctx context.Context, c io.Closer) func() {
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		c.Close()
+	}()
+	return func() { close(done) }
+}
+
+func serveSidecarConn(ctx context.Context, conn net.Conn, analyze SidecarAnalyzer) {
+	defer closeOnDone(ctx, conn)()
+
+	for {
+		frameType, payload, err := readSidecarFrame(conn)
+		if err != nil {
+			return
+		}
+		if frameType != sidecarFrameAnalyze {
+			writeSidecarError(conn, fmt.Sprintf("unsupported frame type %d", frameType))
+			continue
+		}
+
+		var event SecurityEventRequest
+		if err := json.Unmarshal(payload, &event); err != nil {
+			writeSidecarError(conn, "invalid event: "+err.Error())
+			continue
+		}
+		analysis, err := analyze(ctx, &event)
+		if err != nil {
+			writeSidecarError(conn, err.Error())
+			continue
+		}
+		verdict, err := json.Marshal(analysis)
+		if err != nil {
+			writeSidecarError(conn, err.Error())
+			continue
+		}
+		if err := writeSidecarFrame(conn, sidecarFrameVerdict, verdict); err != nil {
+			return
+		}
+	}
+}
+
+func writeSidecarError(conn net.Conn, message string) {
+	payload, _ := json.Marshal(map[string]string{"error": message})
+	writeSidecarFrame(conn, sidecarFrameError, payload)
+}
@@ -0,0 +1,39 @@
+package guardial
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestReadSidecarFrame(t *testing.T) {
+	var valid bytes.Buffer
+	writeSidecarFrame(&valid, sidecarFrameAnalyze, []byte(`{"method":"GET","path":"/"}`))
+	oversized := make([]byte, 5)
+	binary.BigEndian.PutUint32(oversized, maxSidecarFrame+1)
+
+	tests := []struct {
+		name        string
+		data        []byte
+		wantErr     bool
+		wantPayload string
+	}{
+		{"valid", valid.Bytes(), false, `{"method":"GET","path":"/"}`},
+		{"empty", nil, true, ""},
+		{"short header", []byte{0, 0, 1}, true, ""},
+		{"zero length", []byte{0, 0, 0, 0, sidecarFrameAnalyze}, true, ""},
+		{"oversized length", oversized, true, ""},
+		{"truncated payload", valid.Bytes()[:valid.Len()-1], true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frameType, payload, err := readSidecarFrame(bytes.NewReader(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readSidecarFrame() error = %v, want error %t", err, tt.wantErr)
+			}
+			if err == nil && (frameType != sidecarFrameAnalyze || string(payload) != tt.wantPayload) {
+				t.Errorf("frame = %d %q, want %d %q", frameType, payload, sidecarFrameAnalyze, tt.wantPayload)
+			}
+		})
+	}
+}
@@ -0,0 +1,394 @@
+/**
+ * Guardial Go SDK Detector Simulation
+ * Replays a labeled corpus through the middleware pipeline and scores each detector
+ */
+
+package guardial
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Corpus labels, taken from the top-level directory of each sample
+const (
+	LabelAttack = "attack"
+	LabelBenign = "benign"
+)
+
+// DetectorBlocked is the pseudo-detector scoring the pipeline's overall block decision
+const DetectorBlocked = "blocked"
+
+// simulationRemoteAddr is the peer address of samples that don't name one (TEST-NET-1)
+const simulationRemoteAddr = "192.0.2.1:49152"
+
+// LabeledSample is one request of an evaluation corpus
+type LabeledSample struct {
+	Name     string // Path within the corpus, with ":line" for line-based files
+	Label    string // LabelAttack or LabelBenign
+	Expected string // Reason code an attack should be flagged with; empty if unspecified
+	Request  *http.Request
+}
+
+// LoadCorpus reads a labeled corpus laid out as
+//
+//	corpus/attack/<reason code>/...   attacks expected to be flagged with that code
+//	corpus/attack/...                 attacks without an expected detector
+//	corpus/benign/...                 legitimate traffic
+//
+// Files ending in .http hold one raw HTTP request each (a body without Content-Length
+// runs to the end of the file), .jsonl files hold one request per line in the
+// LogFormatJSONLines format, and .log files hold combined-format access log lines.
+// Other files are ignored. Samples are returned in lexical path order.
+func LoadCorpus(dir string) ([]LabeledSample, error) {
+	var samples []LabeledSample
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		segments := strings.Split(filepath.ToSlash(rel), "/")
+		if len(segments) < 2 || (segments[0] != LabelAttack && segments[0] != LabelBenign) {
+			return nil
+		}
+		sample := LabeledSample{Name: filepath.ToSlash(rel), Label: segments[0]}
+		if sample.Label == LabelAttack && len(segments) > 2 {
+			sample.Expected = segments[1]
+		}
+
+		switch filepath.Ext(path) {
+		case ".http":
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("failed to read sample: %w", err)
+			}
+			if sample.Request, err = parseRawRequest(data); err != nil {
+				return fmt.Errorf("failed to parse %s: %w", sample.Name, err)
+			}
+			samples = append(samples, sample)
+		case ".jsonl":
+			return loadSampleLines(path, sample, LogFormatJSONLines, &samples)
+		case ".log":
+			return loadSampleLines(path, sample, LogFormatCombined, &samples)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load corpus: %w", err)
+	}
+	return samples, nil
+}
+
+// loadSampleLines appends one sample per non-blank line of a log file
+func loadSampleLines(path string, sample LabeledSample, format LogFormat, samples *[]LabeledSample) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to read sample: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLogLineSize)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		event, err := ParseAccessLogLine(scanner.Text(), format)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s:%d: %w", sample.Name, line, err)
+		}
+		lineSample := sample
+		lineSample.Name = fmt.Sprintf("%s:%d", sample.Name, line)
+		lineSample.Request = requestFromEvent(event)
+		*samples = append(*samples, lineSample)
+	}
+	return scanner.Err()
+}
+
+// parseRawRequest parses a hand-written HTTP request
+func parseRawRequest(data []byte) (*http.Request, error) {
+	reader := bufio.NewReader(bytes.NewReader(data))
+	req, err := http.ReadRequest(reader)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	if req.ContentLength <= 0 && len(req.TransferEncoding) == 0 {
+		rest, _ := io.ReadAll(reader)
+		body = bytes.TrimSuffix(bytes.TrimSuffix(rest, []byte("\n")), []byte("\r"))
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+	req.RemoteAddr = simulationRemoteAddr
+	return req, nil
+}
+
+// requestFromEvent rebuilds the request an access log line describes
+func requestFromEvent(event *SecurityEventRequest) *http.Request {
+	header := make(http.Header, len(event.Headers)+1)
+	for name, value := range event.Headers {
+		header.Set(name, value)
+	}
+	if event.UserAgent != "" {
+		header.Set("User-Agent", event.UserAgent)
+	}
+	remoteAddr := simulationRemoteAddr
+	if event.SourceIP != "" {
+		remoteAddr = net.JoinHostPort(event.SourceIP, "0")
+	}
+	return &http.Request{
+		Method:        event.Method,
+		URL:           &url.URL{Path: event.Path, RawQuery: event.QueryParams},
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(event.RequestBody)),
+		ContentLength: int64(len(event.RequestBody)),
+		RemoteAddr:    remoteAddr,
+	}
+}
+
+// SimulationResult is the outcome of one sample
+type SimulationResult struct {
+	Sample    string   `json:"sample"`
+	Label     string   `json:"label"`
+	Expected  string   `json:"expected,omitempty"`
+	Blocked   bool     `json:"blocked"`
+	Detectors []string `json:"detectors,omitempty"` // Reason codes of the verdict
+	Error     string   `json:"error,omitempty"`     // Analysis failure; the sample isn't scored
+}
+
+// Misclassified reports whether the pipeline blocked a benign sample, let an attack
+// through, or missed the expected detector
+func (r *SimulationResult) Misclassified() bool {
+	if r.Error != "" {
+		return false
+	}
+	if r.Label == LabelBenign {
+		return r.Blocked
+	}
+	if !r.Blocked {
+		return true
+	}
+	if r.Expected == "" {
+		return false
+	}
+	for _, detector := range r.Detectors {
+		if detector == r.Expected {
+			return false
+		}
+	}
+	return true
+}
+
+// DetectorMetrics scores one detector over a corpus. A detector flags a sample when the
+// verdict carries its reason code, whether or not the request was blocked, so rules
+// running in monitor mode are scored too.
+type DetectorMetrics struct {
+	Detector       string  `json:"detector"`
+	TruePositives  int     `json:"true_positives"`  // Attacks flagged
+	FalsePositives int     `json:"false_positives"` // Benign samples flagged
+	FalseNegatives int     `json:"false_negatives"` // Attacks expecting this detector that it missed
+	Expected       int     `json:"expected"`        // Attacks expecting this detector
+	Precision      float64 `json:"precision"`       // 0 when nothing was flagged
+	Recall         float64 `json:"recall"`          // Over the Expected attacks; 0 when there are none
+}
+
+func (d *DetectorMetrics) finish() {
+	if flagged := d.TruePositives + d.FalsePositives; flagged > 0 {
+		d.Precision = float64(d.TruePositives) / float64(flagged)
+	}
+	if d.Expected > 0 {
+		d.Recall = float64(d.Expected-d.FalseNegatives) / float64(d.Expected)
+	}
+}
+
+// SimulationReport summarizes a simulation run
+type SimulationReport struct {
+	Samples   int                `json:"samples"`
+	Attacks   int                `json:"attacks"`
+	Benign    int                `json:"benign"`
+	Errors    int                `json:"errors"`
+	Overall   DetectorMetrics    `json:"overall"`   // Scores the block decision (DetectorBlocked)
+	Detectors []DetectorMetrics  `json:"detectors"` // Sorted by reason code
+	Results   []SimulationResult `json:"results"`   // In sample order
+}
+
+// Simulate runs each sample through the middleware pipeline built from options and
+// scores the verdicts against the labels. Samples run one at a time in order, and the
+// pipeline's nondeterministic or side-effecting stages (sampling, trust scoring, the
+// detector watchdog, webhook verification, canaries, degradation, forensic capture,
+// session analytics, review queues, response analysis, BlockHandler) are disabled, so
+// a corpus scores the same on every run against the same backend. Samples are analyzed
+// by a separate client with c's settings but none of its verdict cache, coalescing,
+// blocks, or overrides, so they neither reuse nor leave behind live state.
+// Analysis failures are recorded per sample, not returned.
+func (c *Client) Simulate(ctx context.Context, samples []LabeledSample, options *MiddlewareOptions) *SimulationReport {
+	if options == nil {
+		options = DefaultMiddlewareOptions()
+	}
+	opts := *options
+	opts.FailOpen = false
+	opts.Mode = ModeEnforce
+	opts.Sampling = nil
+	opts.SampleRate = 0
+	opts.Canary = nil
+	opts.Degrade = nil
+	opts.Forensics = nil
+	opts.SessionAnalytics = nil
+	opts.BlockHandler = nil
+	opts.Review = nil
+	opts.ResponseAnalysis = nil
+	opts.Trust = nil
+	opts.Watchdog = nil
+	opts.Webhooks = nil
+
+	sim := c.simulationClient()
+	defer func() {
+		sim.transport = nil // Owned by c
+		sim.Close(context.WithoutCancel(ctx))
+	}()
+	guard := NewGuard(sim, &opts)
+	var rejection *Rejection
+	guard.OnReject(func(w http.ResponseWriter, r *http.Request, refused Rejection) {
+		rejection = &refused
+	})
+
+	report := &SimulationReport{Overall: DetectorMetrics{Detector: DetectorBlocked}}
+	detectors := make(map[string]*DetectorMetrics)
+	detector := func(code string) *DetectorMetrics {
+		if detectors[code] == nil {
+			detectors[code] = &DetectorMetrics{Detector: code}
+		}
+		return detectors[code]
+	}
+
+	for _, sample := range samples {
+		rejection = nil
+		var analysis *SecurityEventResponse
+		guard.Serve(discardResponse{header: make(http.Header)}, sample.Request.WithContext(ctx), func(w http.ResponseWriter, r *http.Request) {
+			analysis = FromContext(r.Context())
+		})
+		if rejection != nil && rejection.Analysis != nil {
+			analysis = rejection.Analysis
+		}
+
+		result := SimulationResult{Sample: sample.Name, Label: sample.Label, Expected: sample.Expected}
+		if rejection != nil && rejection.Status >= http.StatusInternalServerError {
+			result.Error = rejection.Message
+			report.Results = append(report.Results, result)
+			report.Errors++
+			continue
+		}
+		result.Blocked = rejection != nil || (analysis != nil && !analysis.Allowed)
+		if analysis != nil {
+			for _, reason := range analysis.Reasons {
+				result.Detectors = appendUnique(result.Detectors, reason.Code)
+			}
+		}
+		report.Results = append(report.Results, result)
+		report.Samples++
+
+		if sample.Label == LabelBenign {
+			report.Benign++
+			if result.Blocked {
+				report.Overall.FalsePositives++
+			}
+			for _, code := range result.Detectors {
+				detector(code).FalsePositives++
+			}
+			continue
+		}
+
+		report.Attacks++
+		report.Overall.Expected++
+		if result.Blocked {
+			report.Overall.TruePositives++
+		} else {
+			report.Overall.FalseNegatives++
+		}
+		expectedFound := false
+		for _, code := range result.Detectors {
+			detector(code).TruePositives++
+			expectedFound = expectedFound || code == sample.Expected
+		}
+		if sample.Expected != "" {
+			detector(sample.Expected).Expected++
+			if !expectedFound {
+				detector(sample.Expected).FalseNegatives++
+			}
+		}
+	}
+
+	report.Overall.finish()
+	for _, metrics := range detectors {
+		metrics.finish()
+		report.Detectors = append(report.Detectors, *metrics)
+	}
+	sort.Slice(report.Detectors, func(i, j int) bool {
+		return report.Detectors[i].Detector < report.Detectors[j].Detector
+	})
+	return report
+}
+
+// simulationClient returns a client that analyzes like c, through the same API keys,
+// policy, and transport, but shares no verdicts or enforcement state with it and runs
+// no background syncs
+func (c *Client) simulationClient() *Client {
+	config := *c.config
+	keys := c.keys.Load()
+	config.APIKey, config.SecondaryAPIKey = keys.primary, keys.secondary
+	config.Policy = c.policy.Load()
+	config.PolicySyncInterval = 0
+	config.OverrideSyncInterval = 0
+	config.VerdictCache = nil
+	config.CoalesceRequests = false
+	config.AsyncMode = false
+	config.BlockPropagator = nil
+	config.QuotaGovernor = nil
+	config.Maintenance = nil
+	config.UsageAlert = nil
+	config.Trace = nil
+	config.EventTransport = nil
+	config.Sidecar = nil
+
+	sim := NewClient(&config)
+	sim.transport = c.transport
+	return sim
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
+
+// discardResponse swallows the responses of simulated requests
+type discardResponse struct {
+	header http.Header
+}
+
+func (d discardResponse) Header() http.Header         { return d.header }
+func (d discardResponse) Write(b []byte) (int, error) { return len(b), nil }
+func (d discardResponse) WriteHeader(int)             {}
@@ -0,0 +1,52 @@
+package guardial
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSimulateIsolation(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, client *Client) // Live traffic before the simulation
+	}{
+		{"cached live verdict", func(t *testing.T, client *Client) {
+			handler := StandardMiddleware(client, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			req := httptest.NewRequest("GET", "/search?q=1%27%20OR%201=1", nil)
+			req.RemoteAddr = simulationRemoteAddr
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+		}},
+		{"blocked live IP", func(t *testing.T, client *Client) {
+			if err := client.BlockIP(context.Background(), "192.0.2.1", time.Hour, "test"); err != nil {
+				t.Fatalf("BlockIP() = %v", err)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var blocking atomic.Bool
+			client := newConfiguredTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if blocking.Load() {
+					w.Write([]byte(`{"allowed":false,"action":"block","risk_score":95,"reasons":[{"code":"sqli"}]}`))
+					return
+				}
+				w.Write([]byte(allowedVerdict))
+			}, func(c *Config) { c.VerdictCache = DefaultVerdictCacheConfig() })
+			tt.setup(t, client)
+
+			// The backend now blocks the sample: the simulation must ask it again
+			blocking.Store(true)
+			req := httptest.NewRequest("GET", "/search?q=1%27%20OR%201=1", nil)
+			req.RemoteAddr = simulationRemoteAddr
+			report := client.Simulate(context.Background(), []LabeledSample{{Name: "sqli.http", Label: LabelAttack, Expected: "sqli", Request: req}}, nil)
+
+			if len(report.Results) != 1 || report.Results[0].Misclassified() {
+				t.Fatalf("results = %+v, want the sample blocked by sqli", report.Results)
+			}
+		})
+	}
+}
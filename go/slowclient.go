@@ -0,0 +1,260 @@
+/**
+ * Guardial Go SDK Slow Client Detection
+ * Slowloris and slow-body detection from per-connection read pacing
+ */
+
+package guardial
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrSlowClient is returned from request body reads after a slow connection was terminated
+var ErrSlowClient = errors.New("guardial: connection terminated for slow client")
+
+// SlowClientOptions configures slow client detection
+type SlowClientOptions struct {
+	HeaderTimeout time.Duration // Max time from a request's first byte to its handler (default: 10s)
+	MinBodyRate   int           // Body upload rate in bytes/sec below which a client is slow (default: 128)
+	GracePeriod   time.Duration // Body read time before MinBodyRate is enforced (default: 5s)
+	Terminate     bool          // Close the connection of abusive clients
+}
+
+// SlowClientGuard tracks header and body read pacing per connection. It needs both its
+// Listener, to see bytes arrive, and Wrap, to see when headers are complete.
+type SlowClientGuard struct {
+	client  *Client
+	options SlowClientOptions
+}
+
+type pacingConnKey struct{}
+
+// NewSlowClientGuard creates a guard that reports slow clients through c
+func (c *Client) NewSlowClientGuard(options *SlowClientOptions) *SlowClientGuard {
+	opts := SlowClientOptions{}
+	if options != nil {
+		opts = *options
+	}
+	if opts.HeaderTimeout <= 0 {
+		opts.HeaderTimeout = 10 * time.Second
+	}
+	if opts.MinBodyRate <= 0 {
+		opts.MinBodyRate = 128
+	}
+	if opts.GracePeriod <= 0 {
+		opts.GracePeriod = 5 * time.Second
+	}
+	return &SlowClientGuard{client: c, options: opts}
+}
+
+// ListenAndServe serves srv on srv.Addr with slow client detection
+func (g *SlowClientGuard) ListenAndServe(srv *http.Server) error {
+	addr := srv.Addr
+	if addr == "" {
+		addr = ":http"
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	g.Wrap(srv)
+	return srv.Serve(g.Listener(ln))
+}
+
+// Listener wraps ln so reads on accepted connections are timed
+func (g *SlowClientGuard) Listener(ln net.Listener) net.Listener {
+	return &pacingListener{Listener: ln, guard: g}
+}
+
+// Wrap installs the guard's handler and connection context hooks on srv
+func (g *SlowClientGuard) Wrap(srv *http.Server) {
+	next := srv.Handler
+	if next == nil {
+		next = http.DefaultServeMux
+	}
+	srv.Handler = g.Handler(next)
+
+	connContext := srv.ConnContext
+	srv.ConnContext = func(ctx context.Context, c net.Conn) context.Context {
+		if connContext != nil {
+			ctx = connContext(ctx, c)
+		}
+		if tlsConn, ok := c.(*tls.Conn); ok {
+			c = tlsConn.NetConn()
+		}
+		if conn, ok := c.(*pacingConn); ok {
+			ctx = context.WithValue(ctx, pacingConnKey{}, conn)
+		}
+		return ctx
+	}
+}
+
+// Handler marks request headers as complete and times the request body
+func (g *SlowClientGuard) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, _ := r.Context().Value(pacingConnKey{}).(*pacingConn)
+		if conn == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		conn.headersDone()
+		defer conn.requestDone()
+
+		if r.Body != nil && r.Body != http.NoBody {
+			r.Body = &pacingBody{ReadCloser: r.Body, conn: conn, r: r, start: time.Now()}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+type pacingListener struct {
+	net.Listener
+	guard *SlowClientGuard
+}
+
+func (l *pacingListener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return &pacingConn{Conn: c, guard: l.guard}, nil
+}
+
+// pacingConn times each request from its first byte until its handler runs
+type pacingConn struct {
+	net.Conn
+	guard *SlowClientGuard
+
+	mu           sync.Mutex
+	inRequest    bool
+	requestStart time.Time
+	headerBytes  int
+	timer        *time.Timer
+}
+
+func (c *pacingConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+	if n > 0 {
+		c.mu.Lock()
+		if !c.inRequest {
+			// First byte of a new request: headers must complete within HeaderTimeout
+			c.inRequest = true
+			c.requestStart = time.Now()
+			c.headerBytes = 0
+			c.timer = time.AfterFunc(c.guard.options.HeaderTimeout, c.headerTimeout)
+		}
+		if c.timer != nil {
+			c.headerBytes += n
+		}
+		c.mu.Unlock()
+	}
+	return n, err
+}
+
+func (c *pacingConn) headersDone() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+}
+
+func (c *pacingConn) requestDone() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inRequest = false
+}
+
+func (c *pacingConn) Close() error {
+	c.headersDone()
+	return c.Conn.Close()
+}
+
+// headerTimeout fires when a request's headers took longer than HeaderTimeout
+func (c *pacingConn) headerTimeout() {
+	c.mu.Lock()
+	if c.timer == nil {
+		c.mu.Unlock()
+		return
+	}
+	c.timer = nil
+	elapsed := time.Since(c.requestStart)
+	received := c.headerBytes
+	c.mu.Unlock()
+
+	finding := c.finding("slow_headers", "Slow request headers (slowloris)", fmt.Sprintf("%d header bytes in %s", received, elapsed.Round(time.Millisecond)))
+	finding.Metadata["header_bytes"] = strconv.Itoa(received)
+	c.report(context.Background(), finding)
+}
+
+// report sends finding and terminates the connection if configured
+func (c *pacingConn) report(ctx context.Context, finding *Finding) {
+	g := c.guard
+	g.client.log("🐌 Slow client detected:", finding.SourceIP, finding.Evidence)
+	g.client.ReportFindingAsync(ctx, finding)
+
+	if g.options.Terminate {
+		c.Conn.Close()
+	}
+}
+
+func (c *pacingConn) finding(findingType, title, evidence string) *Finding {
+	sourceIP := c.RemoteAddr().String()
+	if host, _, err := net.SplitHostPort(sourceIP); err == nil {
+		sourceIP = host
+	}
+	return &Finding{
+		Type:     findingType,
+		Title:    title,
+		Severity: "MEDIUM",
+		Evidence: evidence,
+		SourceIP: sourceIP,
+		Metadata: map[string]string{
+			"terminated": strconv.FormatBool(c.guard.options.Terminate),
+		},
+	}
+}
+
+// pacingBody reports request bodies uploaded slower than MinBodyRate
+type pacingBody struct {
+	io.ReadCloser
+	conn     *pacingConn
+	r        *http.Request
+	start    time.Time
+	read     int
+	reported bool
+}
+
+func (b *pacingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.read += n
+
+	options := b.conn.guard.options
+	elapsed := time.Since(b.start)
+	if !b.reported && err == nil && elapsed > options.GracePeriod {
+		if rate := float64(b.read) / elapsed.Seconds(); rate < float64(options.MinBodyRate) {
+			b.reported = true
+			finding := b.conn.finding("slow_body", "Slow request body upload",
+				fmt.Sprintf("%d body bytes in %s (%.0f B/s, minimum %d B/s)", b.read, elapsed.Round(time.Millisecond), rate, options.MinBodyRate))
+			finding.Path = b.r.URL.Path
+			finding.Metadata["method"] = b.r.Method
+			finding.Metadata["body_bytes"] = strconv.Itoa(b.read)
+			b.conn.report(b.r.Context(), finding)
+			if options.Terminate {
+				return n, ErrSlowClient
+			}
+		}
+	}
+	return n, err
+}
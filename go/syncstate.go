@@ -0,0 +1,121 @@
+/**
+ * Guardial Go SDK Synced State
+ * Stale-while-revalidate handling of state synced from the control plane
+ */
+
+package guardial
+
+import (
+	"sync"
+	"time"
+)
+
+// Synced state sources
+const (
+	SyncSourcePolicy    = "policy"    // The policy schedule, with PolicySyncInterval
+	SyncSourceOverrides = "overrides" // Allow overrides, with OverrideSyncInterval
+)
+
+// defaultStaleAfter is the staleness ceiling of synced state
+const defaultStaleAfter = 15 * time.Minute
+
+// StalenessConfig configures how state synced from the control plane ages. While
+// refreshes fail, the last good copy keeps being enforced, so an outage neither slows
+// requests down nor changes enforcement. Failures are only logged until the copy is
+// older than StaleAfter; then a warning is raised and OnStale is called, once per outage.
+type StalenessConfig struct {
+	StaleAfter time.Duration `json:"stale_after"` // Age of the last good copy that counts as stale (default: 15m)
+
+	// OnStale is called as a source goes stale (stale true, age of the last good copy)
+	// and as it recovers (stale false), e.g. to page on-call
+	OnStale func(source string, stale bool, age time.Duration) `json:"-"`
+}
+
+// SyncStatus describes one source of synced state
+type SyncStatus struct {
+	Source    string    `json:"source"`     // SyncSourcePolicy or SyncSourceOverrides
+	LastSync  time.Time `json:"last_sync"`  // Last successful refresh; zero if none yet
+	LastError string    `json:"last_error"` // Error of the latest refresh, if it failed
+	Stale     bool      `json:"stale"`      // The last good copy is older than StaleAfter
+}
+
+// syncTracker keeps the freshness of each synced source
+type syncTracker struct {
+	config  StalenessConfig
+	started time.Time // Age reference of sources that never synced
+
+	mu      sync.Mutex
+	sources map[string]*SyncStatus
+}
+
+func newSyncTracker(config *StalenessConfig) *syncTracker {
+	var cfg StalenessConfig
+	if config != nil {
+		cfg = *config
+	}
+	if cfg.StaleAfter <= 0 {
+		cfg.StaleAfter = defaultStaleAfter
+	}
+	return &syncTracker{config: cfg, started: time.Now(), sources: make(map[string]*SyncStatus)}
+}
+
+// SyncStatus reports the freshness of every source this client syncs, e.g. for a
+// readiness endpoint
+func (c *Client) SyncStatus() []SyncStatus {
+	t := c.syncs
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	statuses := make([]SyncStatus, 0, len(t.sources))
+	for _, source := range []string{SyncSourcePolicy, SyncSourceOverrides} {
+		if status, ok := t.sources[source]; ok {
+			statuses = append(statuses, *status)
+		}
+	}
+	return statuses
+}
+
+// refreshed records the outcome of a refresh of source. A failure keeps the last good
+// copy in force; it is logged, and warned about once the copy is past StaleAfter.
+func (c *Client) refreshed(source string, err error) {
+	t := c.syncs
+	now := time.Now()
+	t.mu.Lock()
+	status, ok := t.sources[source]
+	if !ok {
+		status = &SyncStatus{Source: source}
+		t.sources[source] = status
+	}
+
+	reference := status.LastSync
+	if reference.IsZero() {
+		reference = t.started
+	}
+	age := now.Sub(reference)
+
+	if err == nil {
+		recovered := status.Stale
+		status.LastSync, status.LastError, status.Stale = now, "", false
+		t.mu.Unlock()
+		if recovered {
+			c.log("✅ Synced state fresh again:", source, "after", age.Round(time.Second))
+			if t.config.OnStale != nil {
+				t.config.OnStale(source, false, 0)
+			}
+		}
+		return
+	}
+
+	status.LastError = err.Error()
+	alarm := !status.Stale && age > t.config.StaleAfter
+	status.Stale = status.Stale || alarm
+	t.mu.Unlock()
+
+	if !alarm {
+		c.log("Failed to refresh", source, "- keeping the last good copy:", err)
+		return
+	}
+	c.warn("⚠️ Synced", source, "stale for", age.Round(time.Second), "- still enforcing the last good copy:", err)
+	if t.config.OnStale != nil {
+		t.config.OnStale(source, true, age)
+	}
+}
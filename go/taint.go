@@ -0,0 +1,193 @@
+/**
+ * Guardial Go SDK Taint Tracking
+ * Mark user-derived values and check them at dangerous sinks
+ */
+
+package guardial
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// SinkType identifies the kind of sink a value is about to reach
+type SinkType string
+
+const (
+	SinkSQL      SinkType = "sql"
+	SinkExec     SinkType = "exec"
+	SinkTemplate SinkType = "template"
+	SinkHTTP     SinkType = "http"
+)
+
+// TaintFinding describes tainted data that reached a sink unsanitized
+type TaintFinding struct {
+	SinkType SinkType `json:"sink_type"`
+	Context  string   `json:"context,omitempty"` // HTML context for template sinks
+	Tainted  string   `json:"tainted"`
+	Evidence string   `json:"evidence"`
+	Severity string   `json:"severity"`
+	CWEIDs   []string `json:"cwe_ids,omitempty"`
+}
+
+// Error implements the error interface so CheckSink can return the finding directly
+func (f *TaintFinding) Error() string {
+	return fmt.Sprintf("tainted data reached %s sink: %q", f.SinkType, f.Tainted)
+}
+
+// taintTracker holds the tainted values and findings for a single request
+type taintTracker struct {
+	mu        sync.Mutex
+	values    map[string]struct{}
+	findings  []TaintFinding
+	onFinding func(TaintFinding)
+}
+
+type taintContextKey struct{}
+
+// WithTaintTracking returns a copy of ctx that records tainted values.
+// StandardMiddleware installs a tracker on every analyzed request automatically.
+func WithTaintTracking(ctx context.Context) context.Context {
+	return withTaintTracker(ctx, nil)
+}
+
+func withTaintTracker(ctx context.Context, onFinding func(TaintFinding)) context.Context {
+	if ctx.Value(taintContextKey{}) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, taintContextKey{}, &taintTracker{
+		values:    make(map[string]struct{}),
+		onFinding: onFinding,
+	})
+}
+
+func taintFromContext(ctx context.Context) *taintTracker {
+	tracker, _ := ctx.Value(taintContextKey{}).(*taintTracker)
+	return tracker
+}
+
+// Taint marks value as user-derived and returns it unchanged, so it can be used inline:
+//
+//	name := guardial.Taint(r.Context(), r.FormValue("name"))
+//
+// It is a no-op when ctx carries no taint tracker.
+func Taint(ctx context.Context, value string) string {
+	tracker := taintFromContext(ctx)
+	if tracker == nil || value == "" {
+		return value
+	}
+
+	tracker.mu.Lock()
+	tracker.values[value] = struct{}{}
+	tracker.mu.Unlock()
+	return value
+}
+
+// IsTainted reports whether value contains any tainted fragment
+func IsTainted(ctx context.Context, value string) bool {
+	tracker := taintFromContext(ctx)
+	if tracker == nil {
+		return false
+	}
+	return len(tracker.fragmentsIn(value)) > 0
+}
+
+// CheckSink checks value right before it reaches a sink. It returns a *TaintFinding
+// when a tainted fragment carrying characters that are dangerous for sinkType is present.
+func CheckSink(ctx context.Context, value string, sinkType SinkType) error {
+	tracker := taintFromContext(ctx)
+	if tracker == nil {
+		return nil
+	}
+
+	for _, fragment := range tracker.fragmentsIn(value) {
+		if !unsafeForSink(fragment, value, sinkType) {
+			continue
+		}
+
+		finding := TaintFinding{
+			SinkType: sinkType,
+			Tainted:  fragment,
+			Evidence: truncate(value, 256),
+			Severity: "HIGH",
+		}
+		tracker.record(&finding)
+		return &finding
+	}
+	return nil
+}
+
+// TaintFindings returns the findings recorded for ctx so far
+func TaintFindings(ctx context.Context) []TaintFinding {
+	tracker := taintFromContext(ctx)
+	if tracker == nil {
+		return nil
+	}
+
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	return append([]TaintFinding(nil), tracker.findings...)
+}
+
+func (t *taintTracker) fragmentsIn(value string) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var fragments []string
+	for tainted := range t.values {
+		if strings.Contains(value, tainted) {
+			fragments = append(fragments, tainted)
+		}
+	}
+	return fragments
+}
+
+func (t *taintTracker) record(finding *TaintFinding) {
+	if taxonomy, ok := LookupSinkTaxonomy(finding.SinkType); ok && len(finding.CWEIDs) == 0 {
+		finding.CWEIDs = taxonomy.CWEIDs
+	}
+
+	t.mu.Lock()
+	t.findings = append(t.findings, *finding)
+	onFinding := t.onFinding
+	t.mu.Unlock()
+
+	if onFinding != nil {
+		onFinding(*finding)
+	}
+}
+
+// unsafeForSink reports whether a tainted fragment is dangerous in the given sink
+func unsafeForSink(fragment, value string, sinkType SinkType) bool {
+	switch sinkType {
+	case SinkSQL:
+		return strings.ContainsAny(fragment, `'";\`) || strings.Contains(fragment, "--") || strings.Contains(fragment, "/*")
+	case SinkExec:
+		return strings.ContainsAny(fragment, ";|&$`<>\n\r") || strings.HasPrefix(fragment, "-")
+	case SinkTemplate:
+		lower := strings.ToLower(fragment)
+		return strings.ContainsAny(fragment, "<>\"'`") || strings.Contains(lower, "javascript:")
+	case SinkHTTP:
+		if strings.ContainsAny(fragment, "\r\n") {
+			return true
+		}
+		// User data must never decide where an egress request goes
+		parsed, err := url.Parse(value)
+		if err != nil {
+			return true
+		}
+		return strings.Contains(parsed.Host, fragment) || strings.Contains(parsed.User.String(), fragment) ||
+			(parsed.Host != "" && strings.Contains(fragment, parsed.Host))
+	}
+	return false
+}
+
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max] + "..."
+}
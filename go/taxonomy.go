@@ -0,0 +1,95 @@
+/**
+ * Guardial Go SDK Taxonomy Mapping
+ * CWE and MITRE ATT&CK mappings for OWASP detections and local findings
+ */
+
+package guardial
+
+import (
+	"strings"
+)
+
+// Taxonomy maps a detection onto CWE weaknesses and MITRE ATT&CK techniques
+type Taxonomy struct {
+	CWEIDs           []string `json:"cwe_ids"`
+	AttackTechniques []string `json:"attack_techniques"`
+}
+
+// owaspTaxonomy covers the OWASP Top 10 (2021), keyed by category code
+var owaspTaxonomy = map[string]Taxonomy{
+	"A01": {CWEIDs: []string{"CWE-284", "CWE-285", "CWE-639", "CWE-22"}, AttackTechniques: []string{"T1190", "T1078"}},
+	"A02": {CWEIDs: []string{"CWE-327", "CWE-328", "CWE-319"}, AttackTechniques: []string{"T1040", "T1552"}},
+	"A03": {CWEIDs: []string{"CWE-79", "CWE-89", "CWE-78", "CWE-77", "CWE-94"}, AttackTechniques: []string{"T1190", "T1059"}},
+	"A04": {CWEIDs: []string{"CWE-840", "CWE-209", "CWE-799"}, AttackTechniques: []string{"T1190"}},
+	"A05": {CWEIDs: []string{"CWE-16", "CWE-611", "CWE-942"}, AttackTechniques: []string{"T1190"}},
+	"A06": {CWEIDs: []string{"CWE-1104", "CWE-937"}, AttackTechniques: []string{"T1190", "T1195"}},
+	"A07": {CWEIDs: []string{"CWE-287", "CWE-307", "CWE-384"}, AttackTechniques: []string{"T1110", "T1078"}},
+	"A08": {CWEIDs: []string{"CWE-502", "CWE-829", "CWE-494"}, AttackTechniques: []string{"T1195"}},
+	"A09": {CWEIDs: []string{"CWE-778", "CWE-117"}, AttackTechniques: []string{"T1562", "T1070"}},
+	"A10": {CWEIDs: []string{"CWE-918"}, AttackTechniques: []string{"T1190", "T1090"}},
+}
+
+// findingTaxonomy covers the finding types raised locally by the SDK
+var findingTaxonomy = map[string]Taxonomy{
+	"path_traversal":         {CWEIDs: []string{"CWE-22"}, AttackTechniques: []string{"T1083", "T1190"}},
+	"panic":                  {CWEIDs: []string{"CWE-248", "CWE-476"}, AttackTechniques: []string{"T1499"}},
+	"repeated_server_errors": {CWEIDs: []string{"CWE-755"}, AttackTechniques: []string{"T1499", "T1595"}},
+	"tainted_sink":           {CWEIDs: []string{"CWE-20"}, AttackTechniques: []string{"T1190"}},
+	"slow_headers":           {CWEIDs: []string{"CWE-400"}, AttackTechniques: []string{"T1499"}},
+	"slow_body":              {CWEIDs: []string{"CWE-400"}, AttackTechniques: []string{"T1499"}},
+	"connection_flood":       {CWEIDs: []string{"CWE-400", "CWE-770"}, AttackTechniques: []string{"T1498", "T1499"}},
+	"connection_exhaustion":  {CWEIDs: []string{"CWE-400", "CWE-770"}, AttackTechniques: []string{"T1499"}},
+	"connection_surge":       {CWEIDs: []string{"CWE-400"}, AttackTechniques: []string{"T1498"}},
+
+	"canary_triggered":            {CWEIDs: []string{"CWE-200"}, AttackTechniques: []string{"T1552", "T1595"}},
+	"client_fingerprint_mismatch": {CWEIDs: []string{"CWE-287", "CWE-522"}, AttackTechniques: []string{"T1078", "T1550"}},
+}
+
+// sinkTaxonomy refines tainted_sink findings by sink
+var sinkTaxonomy = map[SinkType]Taxonomy{
+	SinkSQL:      {CWEIDs: []string{"CWE-89"}, AttackTechniques: []string{"T1190"}},
+	SinkExec:     {CWEIDs: []string{"CWE-78"}, AttackTechniques: []string{"T1059", "T1190"}},
+	SinkTemplate: {CWEIDs: []string{"CWE-79"}, AttackTechniques: []string{"T1189"}},
+	SinkHTTP:     {CWEIDs: []string{"CWE-918"}, AttackTechniques: []string{"T1090", "T1190"}},
+}
+
+// LookupTaxonomy returns the CWE and ATT&CK mapping for an OWASP category
+// ("A03", "A03:2021", or "A03:2021-Injection") or a local finding type ("path_traversal")
+func LookupTaxonomy(category string) (Taxonomy, bool) {
+	if taxonomy, ok := findingTaxonomy[category]; ok {
+		return taxonomy, true
+	}
+
+	code := strings.ToUpper(strings.TrimSpace(category))
+	if len(code) >= 3 {
+		code = code[:3]
+	}
+	taxonomy, ok := owaspTaxonomy[code]
+	return taxonomy, ok
+}
+
+// LookupSinkTaxonomy returns the CWE and ATT&CK mapping for a taint sink
+func LookupSinkTaxonomy(sink SinkType) (Taxonomy, bool) {
+	taxonomy, ok := sinkTaxonomy[sink]
+	return taxonomy, ok
+}
+
+// enrichTaxonomy fills in CWE and ATT&CK mappings the API didn't provide
+func enrichTaxonomy(analysis *SecurityEventResponse) {
+	for i := range analysis.OwaspDetected {
+		detection := &analysis.OwaspDetected[i]
+		if len(detection.CWEIDs) > 0 && len(detection.AttackTechniques) > 0 {
+			continue
+		}
+		taxonomy, ok := LookupTaxonomy(detection.OwaspCategory)
+		if !ok {
+			continue
+		}
+		if len(detection.CWEIDs) == 0 {
+			detection.CWEIDs = taxonomy.CWEIDs
+		}
+		if len(detection.AttackTechniques) == 0 {
+			detection.AttackTechniques = taxonomy.AttackTechniques
+		}
+	}
+}
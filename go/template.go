@@ -0,0 +1,249 @@
+/**
+ * Guardial Go SDK Template Guard
+ * Runtime XSS protection for html/template and text/template execution
+ */
+
+package guardial
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+)
+
+// Template is satisfied by both *html/template.Template and *text/template.Template
+type Template interface {
+	Execute(wr io.Writer, data interface{}) error
+}
+
+// GuardedTemplate wraps a template so its output is checked for tainted data
+// landing in dangerous HTML contexts before anything is written
+type GuardedTemplate struct {
+	tmpl       Template
+	ReportOnly bool // If true, record findings but still write the output
+}
+
+// GuardTemplate wraps t with the template guard
+// Usage: guardial.GuardTemplate(tmpl).Execute(r.Context(), w, data)
+func GuardTemplate(t Template) *GuardedTemplate {
+	return &GuardedTemplate{tmpl: t}
+}
+
+// Execute renders the template, reports tainted data found in script, attribute, or URL
+// contexts, and returns the first *TaintFinding without writing anything unless ReportOnly is set
+func (g *GuardedTemplate) Execute(ctx context.Context, w io.Writer, data interface{}) error {
+	tracker := taintFromContext(ctx)
+	if tracker == nil {
+		return g.tmpl.Execute(w, data)
+	}
+
+	// Render into a buffer so nothing reaches the client before the check
+	var buf bytes.Buffer
+	if err := g.tmpl.Execute(&buf, data); err != nil {
+		return err
+	}
+
+	output := buf.String()
+	findings := findTemplateTaint(output, tracker.fragmentsIn(output))
+	for i := range findings {
+		tracker.record(&findings[i])
+	}
+
+	if len(findings) > 0 && !g.ReportOnly {
+		return &findings[0]
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// HTML contexts a tainted fragment can land in
+const (
+	templateContextText      = "text"
+	templateContextTag       = "tag"
+	templateContextAttribute = "attribute"
+	templateContextURL       = "url"
+	templateContextScript    = "script"
+)
+
+func findTemplateTaint(output string, fragments []string) []TaintFinding {
+	if len(fragments) == 0 {
+		return nil
+	}
+
+	contexts := scanHTMLContexts(output)
+	var findings []TaintFinding
+	for _, fragment := range fragments {
+		for offset := 0; offset < len(output); {
+			idx := strings.Index(output[offset:], fragment)
+			if idx < 0 {
+				break
+			}
+			start := offset + idx
+			offset = start + len(fragment)
+
+			htmlContext := contexts[start]
+			valueStart := start == 0 || contexts[start-1] != htmlContext
+			if !unsafeInHTMLContext(fragment, htmlContext, valueStart) {
+				continue
+			}
+
+			findings = append(findings, TaintFinding{
+				SinkType: SinkTemplate,
+				Context:  htmlContext,
+				Tainted:  fragment,
+				Evidence: truncate(output[start:], 256),
+				Severity: "HIGH",
+			})
+			break
+		}
+	}
+	return findings
+}
+
+// unsafeInHTMLContext reports whether a tainted fragment can change the meaning of the markup
+func unsafeInHTMLContext(fragment, htmlContext string, valueStart bool) bool {
+	lower := strings.ToLower(fragment)
+	switch htmlContext {
+	case templateContextText:
+		return strings.ContainsAny(fragment, "<>")
+	case templateContextTag:
+		return strings.ContainsAny(fragment, " \t\n=\"'<>/")
+	case templateContextAttribute:
+		return strings.ContainsAny(fragment, "\"'`<>")
+	case templateContextURL:
+		if strings.ContainsAny(fragment, "\"'`<>") {
+			return true
+		}
+		for _, scheme := range []string{"javascript:", "vbscript:", "data:"} {
+			if strings.Contains(lower, scheme) {
+				return true
+			}
+		}
+		// A value that starts the URL controls where it points
+		return valueStart && strings.HasPrefix(fragment, "//")
+	case templateContextScript:
+		return strings.ContainsAny(fragment, "\"'`<>;\\")
+	}
+	return false
+}
+
+// scanHTMLContexts returns the HTML context of every byte in doc. It is a deliberately
+// small tokenizer: good enough to tell text, tags, attribute values, and raw script apart.
+func scanHTMLContexts(doc string) []string {
+	const (
+		stateText = iota
+		stateTag
+		stateAttr
+		stateRaw
+	)
+
+	contexts := make([]string, len(doc))
+	state := stateText
+	var tagName, attrName, attrContext, rawEnd string
+	var quote byte
+
+	for i := 0; i < len(doc); {
+		c := doc[i]
+		switch state {
+		case stateText:
+			if c == '<' && i+1 < len(doc) && (isASCIILetter(doc[i+1]) || doc[i+1] == '/') {
+				j := i + 1
+				closing := doc[j] == '/'
+				if closing {
+					j++
+				}
+				start := j
+				for j < len(doc) && (isASCIILetter(doc[j]) || (doc[j] >= '0' && doc[j] <= '9')) {
+					j++
+				}
+				tagName = strings.ToLower(doc[start:j])
+				if closing {
+					tagName = ""
+				}
+				for k := i; k < j; k++ {
+					contexts[k] = templateContextTag
+				}
+				attrName = ""
+				state = stateTag
+				i = j
+				continue
+			}
+			contexts[i] = templateContextText
+			i++
+
+		case stateTag:
+			contexts[i] = templateContextTag
+			i++
+			switch {
+			case c == '>':
+				if tagName == "script" || tagName == "style" {
+					rawEnd = "</" + tagName
+					state = stateRaw
+				} else {
+					state = stateText
+				}
+			case c == '=':
+				for i < len(doc) && isHTMLSpace(doc[i]) {
+					contexts[i] = templateContextTag
+					i++
+				}
+				attrContext = attributeContext(attrName)
+				quote = 0
+				if i < len(doc) && (doc[i] == '"' || doc[i] == '\'') {
+					quote = doc[i]
+					contexts[i] = templateContextTag
+					i++
+				}
+				state = stateAttr
+			case isHTMLSpace(c) || c == '/':
+				attrName = ""
+			default:
+				attrName += string(c)
+			}
+
+		case stateAttr:
+			if (quote != 0 && c == quote) || (quote == 0 && (isHTMLSpace(c) || c == '>')) {
+				attrName = ""
+				state = stateTag
+				if quote != 0 {
+					contexts[i] = templateContextTag
+					i++
+				}
+				continue
+			}
+			contexts[i] = attrContext
+			i++
+
+		case stateRaw:
+			if strings.HasPrefix(strings.ToLower(doc[i:min(i+len(rawEnd), len(doc))]), rawEnd) {
+				state = stateText
+				continue
+			}
+			contexts[i] = templateContextScript
+			i++
+		}
+	}
+	return contexts
+}
+
+func attributeContext(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if strings.HasPrefix(name, "on") {
+		return templateContextScript
+	}
+	switch name {
+	case "href", "src", "action", "formaction", "xlink:href", "poster", "background", "cite", "data", "codebase", "srcset":
+		return templateContextURL
+	}
+	return templateContextAttribute
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isHTMLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
@@ -0,0 +1,186 @@
+/**
+ * Guardial Go SDK Session Timeline
+ * Attack session reconstruction and incident summaries for postmortems
+ */
+
+package guardial
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TimelineEvent is a single analyzed request within a session, with its detections inlined
+type TimelineEvent struct {
+	EventID       string           `json:"event_id"`
+	Timestamp     time.Time        `json:"timestamp"`
+	Method        string           `json:"method"`
+	Path          string           `json:"path"`
+	SourceIP      string           `json:"source_ip"`
+	UserAgent     string           `json:"user_agent"`
+	RiskScore     int              `json:"risk_score"`
+	RiskReasons   []string         `json:"risk_reasons"`
+	Reasons       []RiskReason     `json:"reasons,omitempty"`
+	Action        string           `json:"action"`
+	Allowed       bool             `json:"allowed"`
+	OwaspDetected []OwaspDetection `json:"owasp_detected"`
+}
+
+// SessionTimeline holds every event of a session ordered in time
+type SessionTimeline struct {
+	SessionID string          `json:"session_id"`
+	Events    []TimelineEvent `json:"events"`
+}
+
+// GetSessionTimeline pulls all events for a session, ordered by time
+func (c *Client) GetSessionTimeline(ctx context.Context, sessionID string) (*SessionTimeline, error) {
+	var timeline SessionTimeline
+	if err := c.getJSON(ctx, "/api/sessions/"+url.PathEscape(sessionID)+"/events", &timeline); err != nil {
+		return nil, err
+	}
+
+	if timeline.SessionID == "" {
+		timeline.SessionID = sessionID
+	}
+	sort.SliceStable(timeline.Events, func(i, j int) bool {
+		return timeline.Events[i].Timestamp.Before(timeline.Events[j].Timestamp)
+	})
+	return &timeline, nil
+}
+
+// TimelineSummary aggregates a session for an incident report
+type TimelineSummary struct {
+	SessionID    string
+	Start, End   time.Time
+	TotalEvents  int
+	Blocked      int
+	MaxRiskScore int
+	SourceIPs    []string
+	Categories   map[string]int // OWASP category -> detection count
+}
+
+// Summary aggregates the timeline
+func (t *SessionTimeline) Summary() TimelineSummary {
+	summary := TimelineSummary{
+		SessionID:   t.SessionID,
+		TotalEvents: len(t.Events),
+		Categories:  make(map[string]int),
+	}
+
+	seenIPs := make(map[string]bool)
+	for i, event := range t.Events {
+		if i == 0 {
+			summary.Start = event.Timestamp
+		}
+		summary.End = event.Timestamp
+		if !event.Allowed {
+			summary.Blocked++
+		}
+		if event.RiskScore > summary.MaxRiskScore {
+			summary.MaxRiskScore = event.RiskScore
+		}
+		if event.SourceIP != "" && !seenIPs[event.SourceIP] {
+			seenIPs[event.SourceIP] = true
+			summary.SourceIPs = append(summary.SourceIPs, event.SourceIP)
+		}
+		for _, detection := range event.OwaspDetected {
+			summary.Categories[detection.OwaspCategory]++
+		}
+	}
+	return summary
+}
+
+// Markdown renders an incident summary suitable for a postmortem document
+func (t *SessionTimeline) Markdown() string {
+	summary := t.Summary()
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Incident summary: session `%s`\n\n", t.SessionID)
+	fmt.Fprintf(&b, "- **Window:** %s → %s\n", formatTimelineTime(summary.Start), formatTimelineTime(summary.End))
+	fmt.Fprintf(&b, "- **Events:** %d (%d blocked)\n", summary.TotalEvents, summary.Blocked)
+	fmt.Fprintf(&b, "- **Max risk score:** %d\n", summary.MaxRiskScore)
+	fmt.Fprintf(&b, "- **Source IPs:** %s\n", strings.Join(summary.SourceIPs, ", "))
+	for _, category := range sortedKeys(summary.Categories) {
+		fmt.Fprintf(&b, "- **%s:** %d detection(s)\n", category, summary.Categories[category])
+	}
+
+	b.WriteString("\n## Timeline\n\n")
+	b.WriteString("| Time | Request | Source IP | Risk | Action | Detections |\n")
+	b.WriteString("|------|---------|-----------|------|--------|------------|\n")
+	for _, event := range t.Events {
+		var detections []string
+		for _, detection := range event.OwaspDetected {
+			detections = append(detections, fmt.Sprintf("%s %s (%s): `%s`",
+				markdownCell(detection.OwaspCategory), markdownCell(detection.OwaspTitle),
+				detection.Severity, markdownCell(detection.Evidence)))
+		}
+		fmt.Fprintf(&b, "| %s | `%s %s` | %s | %d | %s | %s |\n",
+			formatTimelineTime(event.Timestamp), event.Method, markdownCell(event.Path), event.SourceIP,
+			event.RiskScore, event.Action, strings.Join(detections, "<br>"))
+	}
+	return b.String()
+}
+
+var timelineHTMLTemplate = template.Must(template.New("timeline").Funcs(template.FuncMap{
+	"time": formatTimelineTime,
+}).Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>Incident summary: {{.Timeline.SessionID}}</title></head>
+<body>
+<h1>Incident summary: session <code>{{.Timeline.SessionID}}</code></h1>
+<ul>
+<li><strong>Window:</strong> {{time .Summary.Start}} &rarr; {{time .Summary.End}}</li>
+<li><strong>Events:</strong> {{.Summary.TotalEvents}} ({{.Summary.Blocked}} blocked)</li>
+<li><strong>Max risk score:</strong> {{.Summary.MaxRiskScore}}</li>
+<li><strong>Source IPs:</strong> {{range $i, $ip := .Summary.SourceIPs}}{{if $i}}, {{end}}{{$ip}}{{end}}</li>
+{{range $category, $count := .Summary.Categories}}<li><strong>{{$category}}:</strong> {{$count}} detection(s)</li>
+{{end}}</ul>
+<h2>Timeline</h2>
+<table border="1" cellpadding="4">
+<tr><th>Time</th><th>Request</th><th>Source IP</th><th>Risk</th><th>Action</th><th>Detections</th></tr>
+{{range .Timeline.Events}}<tr><td>{{time .Timestamp}}</td><td><code>{{.Method}} {{.Path}}</code></td><td>{{.SourceIP}}</td><td>{{.RiskScore}}</td><td>{{.Action}}</td><td>{{range .OwaspDetected}}{{.OwaspCategory}} {{.OwaspTitle}} ({{.Severity}}): <code>{{.Evidence}}</code><br>{{end}}</td></tr>
+{{end}}</table>
+</body></html>
+`))
+
+// HTML renders the incident summary as a standalone HTML page. Attacker-controlled
+// fields (paths, evidence) are escaped.
+func (t *SessionTimeline) HTML() (string, error) {
+	var buf bytes.Buffer
+	err := timelineHTMLTemplate.Execute(&buf, struct {
+		Timeline *SessionTimeline
+		Summary  TimelineSummary
+	}{t, t.Summary()})
+	if err != nil {
+		return "", fmt.Errorf("failed to render timeline: %w", err)
+	}
+	return buf.String(), nil
+}
+
+func formatTimelineTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// markdownCell keeps attacker-controlled text from breaking the table layout
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "\n", " ")
+	return strings.ReplaceAll(s, "`", "'")
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
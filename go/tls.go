@@ -0,0 +1,43 @@
+/**
+ * Guardial Go SDK TLS
+ * mTLS and custom TLS settings for the connection to the Guardial API
+ */
+
+package guardial
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// LoadClientTLS builds a TLS config for private Guardial deployments. certFile and keyFile
+// hold the client certificate for mTLS and caFile a PEM CA bundle; any of them may be
+// empty. The minimum version is TLS 1.2.
+func LoadClientTLS(certFile, keyFile, caFile string) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	// Client certificate
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	// Custom CA bundle
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", caFile)
+		}
+		config.RootCAs = pool
+	}
+	return config, nil
+}
@@ -0,0 +1,241 @@
+/**
+ * Guardial Go SDK Request Tracing
+ * Per-request pipeline traces with stage timings, for diagnosing individual verdicts
+ */
+
+package guardial
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Trace headers
+const (
+	// HeaderTrace carries TraceConfig.Secret on requests to trace, optionally followed
+	// by ";dry-run" to let the request through whatever the verdict
+	HeaderTrace = "X-Guardial-Trace"
+
+	// HeaderTraceID is set on the responses of traced requests; fetch the trace with it
+	// from Client.TraceHandler
+	HeaderTraceID = "X-Guardial-Trace-Id"
+)
+
+// traceDryRun is the HeaderTrace suffix of dry runs
+const traceDryRun = ";dry-run"
+
+// defaultTraceCapacity is how many finished traces are kept
+const defaultTraceCapacity = 100
+
+// TraceConfig enables per-request tracing. A request carrying the secret in HeaderTrace
+// has every pipeline stage recorded with its timing: body capture, normalization,
+// local detectors, sampling, cache lookups, the API call, and policy decisions. The
+// header is removed before the request is analyzed or passed on.
+type TraceConfig struct {
+	Secret   string // Required; also authenticates TraceHandler
+	Capacity int    // Finished traces kept for retrieval; older ones are dropped (default: 100)
+}
+
+// TraceStage is one step of a traced request
+type TraceStage struct {
+	Name     string        `json:"name"`             // e.g. "verdict_cache" or "api_call"
+	Detail   string        `json:"detail,omitempty"` // The stage's outcome, e.g. "hit" or "skipped"
+	Offset   time.Duration `json:"offset_ns"`        // From the start of the trace
+	Duration time.Duration `json:"duration_ns"`
+}
+
+// RequestTrace is the record of one traced request
+type RequestTrace struct {
+	ID        string        `json:"id"`
+	Method    string        `json:"method"`
+	Path      string        `json:"path"`
+	DryRun    bool          `json:"dry_run"`
+	StartedAt time.Time     `json:"started_at"`
+	Duration  time.Duration `json:"duration_ns"` // Time spent before the request was passed on or refused
+	Outcome   string        `json:"outcome"`     // "proceeded", "refused", or "client_abandoned"
+	Stages    []TraceStage  `json:"stages"`
+
+	mu sync.Mutex
+}
+
+// tracer keeps the finished traces of a client
+type tracer struct {
+	config TraceConfig
+
+	mu     sync.Mutex
+	traces map[string]*RequestTrace
+	order  []string // IDs, oldest first
+}
+
+func newTracer(config *TraceConfig) *tracer {
+	cfg := *config
+	if cfg.Capacity <= 0 {
+		cfg.Capacity = defaultTraceCapacity
+	}
+	return &tracer{config: cfg, traces: make(map[string]*RequestTrace)}
+}
+
+type traceKey struct{}
+
+// traceFromContext returns the trace of the request ctx belongs to, or nil
+func traceFromContext(ctx context.Context) *RequestTrace {
+	trace, _ := ctx.Value(traceKey{}).(*RequestTrace)
+	return trace
+}
+
+// authorized reports whether value holds the trace secret, and whether it asks for a dry run
+func (t *tracer) authorized(value string) (ok, dryRun bool) {
+	if t.config.Secret == "" || value == "" {
+		return false, false
+	}
+	secret, dryRun := strings.CutSuffix(value, traceDryRun)
+	return subtle.ConstantTimeCompare([]byte(secret), []byte(t.config.Secret)) == 1, dryRun
+}
+
+// startTrace begins a trace of r if it carries the trace secret. It returns r with the
+// trace in its context and HeaderTrace removed, and sets HeaderTraceID on w.
+func (c *Client) startTrace(w http.ResponseWriter, r *http.Request) (*http.Request, *RequestTrace) {
+	if c.tracer == nil {
+		return r, nil
+	}
+	value := r.Header.Get(HeaderTrace)
+	if value == "" {
+		return r, nil
+	}
+	ok, dryRun := c.tracer.authorized(value)
+	if !ok {
+		return r, nil
+	}
+
+	trace := &RequestTrace{
+		ID:        "trace_" + generateRandomString(16),
+		Method:    r.Method,
+		Path:      r.URL.Path,
+		DryRun:    dryRun,
+		StartedAt: time.Now(),
+	}
+	r = r.Clone(context.WithValue(r.Context(), traceKey{}, trace))
+	r.Header.Del(HeaderTrace)
+	w.Header().Set(HeaderTraceID, trace.ID)
+	return r, trace
+}
+
+// finishTrace stores trace for retrieval, dropping the oldest one when full
+func (c *Client) finishTrace(trace *RequestTrace, proceeded bool) {
+	if trace == nil {
+		return
+	}
+	trace.mu.Lock()
+	trace.Duration = time.Since(trace.StartedAt)
+	sort.SliceStable(trace.Stages, func(i, j int) bool { return trace.Stages[i].Offset < trace.Stages[j].Offset })
+	switch {
+	case trace.Outcome != "": // Set by abandon
+	case proceeded:
+		trace.Outcome = "proceeded"
+	default:
+		trace.Outcome = "refused"
+	}
+	trace.mu.Unlock()
+
+	t := c.tracer
+	t.mu.Lock()
+	t.traces[trace.ID] = trace
+	t.order = append(t.order, trace.ID)
+	if len(t.order) > t.config.Capacity {
+		delete(t.traces, t.order[0])
+		t.order = t.order[1:]
+	}
+	t.mu.Unlock()
+	c.log("🔍 Request traced:", trace.Method, trace.Path, trace.ID)
+}
+
+// record adds a stage that began at start; it does nothing on a nil trace, so callers
+// needn't check whether the request is traced
+func (t *RequestTrace) record(name string, start time.Time, detail string) {
+	if t == nil {
+		return
+	}
+	now := time.Now()
+	t.mu.Lock()
+	t.Stages = append(t.Stages, TraceStage{Name: name, Detail: detail, Offset: start.Sub(t.StartedAt), Duration: now.Sub(start)})
+	t.mu.Unlock()
+}
+
+// dryRun reports whether the traced request must proceed whatever the verdict
+func (t *RequestTrace) dryRun() bool {
+	return t != nil && t.DryRun
+}
+
+// abandon marks the traced request as given up on by its client
+func (t *RequestTrace) abandon() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	t.Outcome = ActionAbandoned
+	t.mu.Unlock()
+}
+
+// TraceHandler serves finished traces as JSON: GET ?id=<trace ID> returns one trace,
+// and GET without id lists the IDs of the retained traces, newest first. Requests must
+// carry the trace secret in HeaderTrace. Mount it on an internal route; it answers 404
+// when tracing is disabled.
+func (c *Client) TraceHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if c.tracer == nil {
+			http.NotFound(w, r)
+			return
+		}
+		if ok, _ := c.tracer.authorized(r.Header.Get(HeaderTrace)); !ok {
+			writeTraceJSON(w, http.StatusUnauthorized, map[string]string{"error": "Trace secret required"})
+			return
+		}
+		if r.Method != http.MethodGet {
+			writeTraceJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "Method not allowed"})
+			return
+		}
+
+		t := c.tracer
+		id := r.URL.Query().Get("id")
+		if id == "" {
+			t.mu.Lock()
+			ids := make([]string, len(t.order))
+			for i, traceID := range t.order {
+				ids[len(ids)-1-i] = traceID
+			}
+			t.mu.Unlock()
+			writeTraceJSON(w, http.StatusOK, map[string][]string{"traces": ids})
+			return
+		}
+
+		t.mu.Lock()
+		trace := t.traces[id]
+		t.mu.Unlock()
+		if trace == nil {
+			writeTraceJSON(w, http.StatusNotFound, map[string]string{"error": "Trace not found"})
+			return
+		}
+		trace.mu.Lock()
+		body, err := json.Marshal(trace)
+		trace.mu.Unlock()
+		if err != nil {
+			writeTraceJSON(w, http.StatusInternalServerError, map[string]string{"error": "Failed to encode trace"})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	})
+}
+
+func writeTraceJSON(w http.ResponseWriter, status int, value any) {
+	body, _ := json.Marshal(value)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body)
+}
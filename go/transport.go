@@ -0,0 +1,128 @@
+/**
+ * Guardial Go SDK API Transport
+ * Proxy, TLS, and custom RoundTripper settings for the SDK's own API calls
+ */
+
+package guardial
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// TransportOptions tunes connection pooling and dialing for API calls. Zero fields keep
+// the net/http defaults.
+type TransportOptions struct {
+	MaxIdleConns          int           `json:"max_idle_conns,omitempty"`          // Idle connections kept across all hosts
+	MaxIdleConnsPerHost   int           `json:"max_idle_conns_per_host,omitempty"` // Idle connections kept per host (net/http default is 2)
+	MaxConnsPerHost       int           `json:"max_conns_per_host,omitempty"`      // Caps total connections per host
+	IdleConnTimeout       time.Duration `json:"idle_conn_timeout,omitempty"`       // How long an idle connection is kept
+	DisableKeepAlives     bool          `json:"disable_keep_alives,omitempty"`     // Use a new connection per request
+	DialTimeout           time.Duration `json:"dial_timeout,omitempty"`            // TCP connect timeout
+	KeepAlive             time.Duration `json:"keep_alive,omitempty"`              // TCP keep-alive probe interval
+	TLSHandshakeTimeout   time.Duration `json:"tls_handshake_timeout,omitempty"`   // TLS handshake timeout
+	ResponseHeaderTimeout time.Duration `json:"response_header_timeout,omitempty"` // Wait for response headers after the request is written
+}
+
+// DefaultTransportOptions returns settings for high-throughput inline use: a pool large
+// enough that bursts reuse connections instead of churning through new ones
+func DefaultTransportOptions() *TransportOptions {
+	return &TransportOptions{
+		MaxIdleConns:        200,
+		MaxIdleConnsPerHost: 100,
+		IdleConnTimeout:     90 * time.Second,
+		DialTimeout:         5 * time.Second,
+		KeepAlive:           30 * time.Second,
+		TLSHandshakeTimeout: 5 * time.Second,
+	}
+}
+
+// Validate reports transport settings NewClient can't apply: a ProxyURL that doesn't
+// parse, or a TLSConfig alongside a Transport that isn't an *http.Transport.
+// NewClientFromBundle returns its error; NewClient can't, so call it first when building
+// a Config by hand.
+func (config *Config) Validate() error {
+	if config.ProxyURL != "" {
+		if _, err := url.Parse(config.ProxyURL); err != nil {
+			return fmt.Errorf("invalid proxy URL: %w", err)
+		}
+	}
+	if config.TLSConfig != nil && config.Transport != nil {
+		if _, ok := config.Transport.(*http.Transport); !ok {
+			return fmt.Errorf("TLSConfig can't be applied to a %T Transport; set it on the transport instead", config.Transport)
+		}
+	}
+	return nil
+}
+
+// apiTransport returns the transport used for calls to the Guardial API. Config.Transport
+// wins when set; otherwise a copy of http.DefaultTransport is used, which honors
+// HTTPS_PROXY/NO_PROXY unless Config.ProxyURL overrides the proxy and is tuned by
+// Config.TransportOptions.
+func apiTransport(config *Config) http.RoundTripper {
+	if config.Transport != nil {
+		if transport, ok := config.Transport.(*http.Transport); ok && config.TLSConfig != nil {
+			transport = transport.Clone()
+			transport.TLSClientConfig = config.TLSConfig.Clone()
+			return transport
+		}
+		return config.Transport
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	if config.ProxyURL != "" {
+		proxy, err := url.Parse(config.ProxyURL)
+		if err != nil {
+			// Fail API calls rather than silently bypassing the proxy
+			err = fmt.Errorf("invalid proxy URL: %w", err)
+			transport.Proxy = func(*http.Request) (*url.URL, error) { return nil, err }
+		} else {
+			transport.Proxy = http.ProxyURL(proxy)
+		}
+	}
+	if config.TLSConfig != nil {
+		transport.TLSClientConfig = config.TLSConfig.Clone()
+	}
+	if config.TransportOptions != nil {
+		applyTransportOptions(transport, config.TransportOptions)
+	}
+	return transport
+}
+
+func applyTransportOptions(transport *http.Transport, opts *TransportOptions) {
+	if opts.MaxIdleConns > 0 {
+		transport.MaxIdleConns = opts.MaxIdleConns
+	}
+	if opts.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+	}
+	if opts.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = opts.MaxConnsPerHost
+	}
+	if opts.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = opts.IdleConnTimeout
+	}
+	if opts.TLSHandshakeTimeout > 0 {
+		transport.TLSHandshakeTimeout = opts.TLSHandshakeTimeout
+	}
+	if opts.ResponseHeaderTimeout > 0 {
+		transport.ResponseHeaderTimeout = opts.ResponseHeaderTimeout
+	}
+	transport.DisableKeepAlives = opts.DisableKeepAlives
+
+	// Rebuild the dialer only when its settings change
+	if opts.DialTimeout > 0 || opts.KeepAlive != 0 {
+		dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+		if opts.DialTimeout > 0 {
+			dialer.Timeout = opts.DialTimeout
+		}
+		if opts.KeepAlive != 0 {
+			dialer.KeepAlive = opts.KeepAlive
+		}
+		transport.DialContext = dialer.DialContext
+	}
+}
@@ -0,0 +1,67 @@
+package guardial
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// roundTripperFunc adapts a function to http.RoundTripper
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		configure func(config *Config)
+		wantErr   string
+	}{
+		{"defaults", func(config *Config) {}, ""},
+		{"proxy URL", func(config *Config) { config.ProxyURL = "http://proxy.internal:3128" }, ""},
+		{"invalid proxy URL", func(config *Config) { config.ProxyURL = "://proxy" }, "invalid proxy URL"},
+		{"TLS with http.Transport", func(config *Config) {
+			config.TLSConfig = &tls.Config{}
+			config.Transport = &http.Transport{}
+		}, ""},
+		{"TLS with custom RoundTripper", func(config *Config) {
+			config.TLSConfig = &tls.Config{}
+			config.Transport = roundTripperFunc(http.DefaultTransport.RoundTrip)
+		}, "TLSConfig can't be applied"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			tt.configure(config)
+			err := config.Validate()
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("Validate() = %v, want nil", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("Validate() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestInvalidProxyURLFailsCalls(t *testing.T) {
+	called := false
+	client := newConfiguredTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		verdictAPI(allowedVerdict)(w, r)
+	}, func(config *Config) {
+		config.ProxyURL = "://proxy"
+	})
+
+	_, err := client.HealthCheck(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "invalid proxy URL") {
+		t.Errorf("HealthCheck() = %v, want an invalid proxy URL error", err)
+	}
+	if called {
+		t.Error("request bypassed the proxy")
+	}
+}
@@ -0,0 +1,242 @@
+/**
+ * Guardial Go SDK Progressive Trust
+ * Per-identity trust scores from request history, with trust-dependent enforcement
+ */
+
+package guardial
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Trust score bounds and defaults
+const (
+	maxTrustScore          = 100
+	defaultInitialTrust    = 50
+	defaultTrustReward     = 1
+	defaultTrustPenalty    = 25
+	defaultTrustFindingMin = 50
+	maxMemoryTrustEntries  = 100000
+	trustEvictionSample    = 16 // Records compared to pick one to evict
+)
+
+// TrustRecord is the history kept for one identity
+type TrustRecord struct {
+	Score     int       `json:"score"`    // 0 to 100
+	Clean     int       `json:"clean"`    // Clean analyses
+	Findings  int       `json:"findings"` // Blocked or risky analyses
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TrustStore persists trust records, typically in a store shared by every instance
+// such as Redis. It is called inline on each analyzed request, so it should be fast;
+// concurrent updates of one identity may overwrite each other.
+type TrustStore interface {
+	Load(ctx context.Context, identity string) (record TrustRecord, found bool, err error)
+	Save(ctx context.Context, identity string, record TrustRecord) error
+}
+
+// TrustLevel adjusts enforcement for identities whose score is at least MinScore
+type TrustLevel struct {
+	MinScore int
+
+	// Policy is enforced on top of the client's policy, e.g. a lower BlockThreshold
+	// for untrusted identities
+	Policy *Policy
+
+	// AllowBelow lets through verdicts the API blocked when their risk score is below
+	// it, reducing friction for good users. 0 keeps the API's blocks.
+	AllowBelow int
+
+	// SkipRate is the share of requests at this level that proceed unanalyzed, from 0
+	// to 1, reducing analysis volume for trusted traffic
+	SkipRate float64
+}
+
+// TrustOptions configures progressive trust scoring. Each identity starts at
+// InitialScore; clean analyses add Reward and findings (blocked verdicts, or risk
+// scores at or above FindingRiskScore) subtract Penalty.
+type TrustOptions struct {
+	// Identity names the client r comes from; "" skips scoring. Default: the user from
+	// MiddlewareOptions.UserFunc, or else the peer IP (see Config.TrustedProxies).
+	Identity func(r *http.Request) string
+
+	Store TrustStore // nil keeps scores in memory on this instance
+
+	InitialScore     int // Score of new identities (default: 50)
+	Reward           int // Added per clean analysis (default: 1)
+	Penalty          int // Subtracted per finding (default: 25)
+	FindingRiskScore int // Risk score that counts as a finding even if allowed (default: 50)
+
+	// Levels adjust enforcement by score; the level with the highest MinScore not above
+	// an identity's score applies
+	Levels []TrustLevel
+}
+
+type trustScorer struct {
+	client  *Client
+	options TrustOptions
+}
+
+func newTrustScorer(client *Client, options *TrustOptions) *trustScorer {
+	opts := *options
+	if opts.Store == nil {
+		opts.Store = NewMemoryTrustStore()
+	}
+	if opts.InitialScore <= 0 {
+		opts.InitialScore = defaultInitialTrust
+	}
+	if opts.Reward <= 0 {
+		opts.Reward = defaultTrustReward
+	}
+	if opts.Penalty <= 0 {
+		opts.Penalty = defaultTrustPenalty
+	}
+	if opts.FindingRiskScore <= 0 {
+		opts.FindingRiskScore = defaultTrustFindingMin
+	}
+	opts.Levels = append([]TrustLevel(nil), opts.Levels...)
+	sort.Slice(opts.Levels, func(i, j int) bool { return opts.Levels[i].MinScore > opts.Levels[j].MinScore })
+	return &trustScorer{client: client, options: opts}
+}
+
+// identity returns the trust identity of a request handled by the middleware
+func (t *trustScorer) identity(r *http.Request, state *requestState) string {
+	if t.options.Identity != nil {
+		return t.options.Identity(r)
+	}
+	if state.user != "" {
+		return "user:" + state.user
+	}
+	return "ip:" + state.event.PeerIP
+}
+
+// trustIdentity returns the trust identity of event: the one handle computed, or the
+// peer IP for adapters that call Guard.Check
+func (m *middleware) trustIdentity(ctx context.Context, event *SecurityEventRequest) string {
+	if state := requestStateFromContext(ctx); state != nil && state.event == event {
+		return state.trust
+	}
+	return "ip:" + event.PeerIP
+}
+
+// load returns identity's record, or a new one if it has none or the store failed
+func (t *trustScorer) load(ctx context.Context, identity string) TrustRecord {
+	record, found, err := t.options.Store.Load(ctx, identity)
+	if err != nil {
+		t.client.log("⚠️ Failed to load trust score:", err)
+	}
+	if err != nil || !found {
+		return TrustRecord{Score: t.options.InitialScore}
+	}
+	return record
+}
+
+// level returns the trust level for score, or nil
+func (t *trustScorer) level(score int) *TrustLevel {
+	for i := range t.options.Levels {
+		if score >= t.options.Levels[i].MinScore {
+			return &t.options.Levels[i]
+		}
+	}
+	return nil
+}
+
+// skip reports whether a request at level proceeds unanalyzed
+func (t *trustScorer) skip(level *TrustLevel) bool {
+	return level != nil && level.SkipRate > 0 && rand.Float64() < level.SkipRate
+}
+
+// enforce applies level to the analysis of event
+func (t *trustScorer) enforce(level *TrustLevel, score int, event *SecurityEventRequest, analysis *SecurityEventResponse) {
+	if level == nil {
+		return
+	}
+	label := fmt.Sprintf("trust level %d", level.MinScore)
+	if level.Policy != nil {
+		enforcePolicy(*level.Policy, label, event, analysis)
+	}
+	if !analysis.Allowed && level.AllowBelow > 0 && analysis.RiskScore < level.AllowBelow {
+		analysis.Allowed = true
+		analysis.Action = "allow"
+		analysis.AddReason(ReasonTrustAllowed, ReasonCategoryPolicy, 0,
+			fmt.Sprintf("%s: trust score %d, risk score %d below %d", label, score, analysis.RiskScore, level.AllowBelow))
+	}
+}
+
+// observe updates identity's record with the outcome of an analysis
+func (t *trustScorer) observe(ctx context.Context, identity string, record TrustRecord, analysis *SecurityEventResponse) {
+	if !analysis.Allowed || analysis.RiskScore >= t.options.FindingRiskScore {
+		record.Findings++
+		record.Score -= t.options.Penalty
+		if record.Score < 0 {
+			record.Score = 0
+		}
+	} else {
+		record.Clean++
+		record.Score += t.options.Reward
+		if record.Score > maxTrustScore {
+			record.Score = maxTrustScore
+		}
+	}
+	record.UpdatedAt = time.Now()
+	if err := t.options.Store.Save(ctx, identity, record); err != nil {
+		t.client.log("⚠️ Failed to save trust score:", err)
+	}
+}
+
+// MemoryTrustStore is a TrustStore local to one process. Once it holds 100000
+// identities, each new one replaces the most trusted of a random sample of records, so
+// flooding the store with fresh identities doesn't flush penalized ones.
+type MemoryTrustStore struct {
+	mu      sync.Mutex
+	records map[string]TrustRecord
+}
+
+// NewMemoryTrustStore returns an empty in-memory TrustStore
+func NewMemoryTrustStore() *MemoryTrustStore {
+	return &MemoryTrustStore{records: make(map[string]TrustRecord)}
+}
+
+// Load implements TrustStore
+func (s *MemoryTrustStore) Load(ctx context.Context, identity string) (TrustRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record, found := s.records[identity]
+	return record, found, nil
+}
+
+// Save implements TrustStore
+func (s *MemoryTrustStore) Save(ctx context.Context, identity string, record TrustRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, found := s.records[identity]; !found && len(s.records) >= maxMemoryTrustEntries {
+		delete(s.records, s.evictable())
+	}
+	s.records[identity] = record
+	return nil
+}
+
+// evictable returns the identity with the highest score, then the oldest update, among
+// a sample of records; map iteration order makes the sample random
+func (s *MemoryTrustStore) evictable() string {
+	var evict string
+	var worst TrustRecord
+	sampled := 0
+	for identity, record := range s.records {
+		if sampled == 0 || record.Score > worst.Score ||
+			(record.Score == worst.Score && record.UpdatedAt.Before(worst.UpdatedAt)) {
+			evict, worst = identity, record
+		}
+		if sampled++; sampled == trustEvictionSample {
+			break
+		}
+	}
+	return evict
+}
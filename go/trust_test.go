@@ -0,0 +1,59 @@
+package guardial
+
+import (
+	"context"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestTrustIdentity(t *testing.T) {
+	tests := []struct {
+		name         string
+		user         string
+		forwardedFor string
+		want         string
+	}{
+		{"peer", "", "", "ip:192.0.2.1"},
+		{"spoofed forwarded IP", "", "203.0.113.7", "ip:192.0.2.1"},
+		{"user", "alice", "203.0.113.7", "user:alice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultMiddlewareOptions()
+			options.Trust = &TrustOptions{}
+			m := newMiddleware(NewClient(&Config{APIKey: "test"}), options)
+
+			req := httptest.NewRequest("GET", "/api/orders", nil)
+			req.RemoteAddr = "192.0.2.1:4321"
+			if tt.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			sourceIP, peerIP := m.client.requestAddresses(req, false)
+			state := &requestState{event: &SecurityEventRequest{SourceIP: sourceIP, PeerIP: peerIP}, user: tt.user}
+			if got := m.trust.identity(req, state); got != tt.want {
+				t.Errorf("identity() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryTrustStoreKeepsPenalized(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryTrustStore()
+	store.Save(ctx, "ip:192.0.2.1", TrustRecord{Score: 0, Findings: 2})
+	for i := 1; i < maxMemoryTrustEntries; i++ {
+		store.Save(ctx, "ip:filler-"+strconv.Itoa(i), TrustRecord{Score: defaultInitialTrust + 1})
+	}
+
+	// A flood of fresh identities evicts others, never the penalized record
+	for i := 0; i < 1000; i++ {
+		store.Save(ctx, "ip:flood-"+strconv.Itoa(i), TrustRecord{Score: defaultInitialTrust + 1})
+	}
+	if record, found, _ := store.Load(ctx, "ip:192.0.2.1"); !found || record.Score != 0 {
+		t.Errorf("Load() = %+v, %t; want the penalized record", record, found)
+	}
+	if len(store.records) != maxMemoryTrustEntries {
+		t.Errorf("store holds %d records, want %d", len(store.records), maxMemoryTrustEntries)
+	}
+}
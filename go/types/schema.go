@@ -0,0 +1,104 @@
+/**
+ * Guardial Go SDK Wire Types
+ * JSON Schema generation from the wire types
+ */
+
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// JSONSchema returns a JSON Schema (draft 2020-12) document for the type of v, e.g.
+// JSONSchema(SecurityEventRequest{}). Nested structs are described under $defs.
+// Fields without omitempty are required; slices, maps, and pointers may be null.
+func JSONSchema(v interface{}) ([]byte, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("JSON schema needs a struct type, got %v", t)
+	}
+
+	defs := make(map[string]interface{})
+	doc := structSchema(t, defs)
+	doc["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	doc["title"] = t.Name()
+	doc["x-schema-version"] = SchemaVersion
+	if len(defs) > 0 {
+		doc["$defs"] = defs
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+func structSchema(t reflect.Type, defs map[string]interface{}) map[string]interface{} {
+	properties := make(map[string]interface{})
+	var required []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = typeSchema(field.Type, defs)
+		if !strings.Contains(options, "omitempty") {
+			required = append(required, name)
+		}
+	}
+
+	// Unknown properties stay allowed: later schema versions add fields
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func typeSchema(t reflect.Type, defs map[string]interface{}) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return nullable(typeSchema(t.Elem(), defs))
+	case reflect.Struct:
+		if _, ok := defs[t.Name()]; !ok {
+			defs[t.Name()] = nil // Reserve the name for recursive types
+			defs[t.Name()] = structSchema(t, defs)
+		}
+		return map[string]interface{}{"$ref": "#/$defs/" + t.Name()}
+	case reflect.Slice, reflect.Array:
+		return nullable(map[string]interface{}{"type": "array", "items": typeSchema(t.Elem(), defs)})
+	case reflect.Map:
+		return nullable(map[string]interface{}{"type": "object", "additionalProperties": typeSchema(t.Elem(), defs)})
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	default:
+		// interface{} values, e.g. GraphQL variables, can be any JSON value
+		return map[string]interface{}{}
+	}
+}
+
+// nullable lets schema also match null, as Go encodes nil slices, maps, and pointers
+func nullable(schema map[string]interface{}) map[string]interface{} {
+	if kind, ok := schema["type"].(string); ok {
+		schema["type"] = []string{kind, "null"}
+		return schema
+	}
+	return map[string]interface{}{"anyOf": []interface{}{schema, map[string]interface{}{"type": "null"}}}
+}
@@ -0,0 +1,254 @@
+/**
+ * Guardial Go SDK Wire Types
+ * Schema-stable event, verdict, prompt, and finding types
+ */
+
+// Package types holds the JSON wire types of the Guardial events API. It has no
+// dependencies beyond the standard library, so pipelines that consume mirrored events
+// can use it without importing the SDK; package guardial aliases every type here.
+//
+// Compatibility: within a major version, fields are only ever added, never renamed,
+// retyped, or removed, and JSON names never change. Each addition to the event schema
+// raises SchemaVersion. JSONSchema describes any of these types as a JSON Schema
+// document for consumers in other languages.
+package types
+
+// SchemaVersion is the newest events schema these types describe.
+//
+//	1: original event fields
+//	2: adds schema_version, asn, request_cost, and timestamp
+//	3: adds route and header delta encoding (headers_id, headers_ref, headers_removed)
+//	4: adds graphql
+//	5: adds body_truncated
+//	6: adds files; multipart bodies are sent as their URL-encoded form fields
+//	7: adds event_id, generated client-side and echoed back in verdicts
+//	8: adds route_meta
+const SchemaVersion = 8
+
+// SecurityEventRequest represents a request to be analyzed
+type SecurityEventRequest struct {
+	// EventID identifies the event; the SDK generates it before sending, and the
+	// verdict's EventID echoes it (schema 7)
+	EventID string `json:"event_id,omitempty"`
+
+	Method      string            `json:"method"`
+	Path        string            `json:"path"`
+	Route       string            `json:"route,omitempty"` // Matched route pattern, e.g. "/users/{id}"
+	SourceIP    string            `json:"source_ip"`
+	UserAgent   string            `json:"user_agent"`
+	Headers     map[string]string `json:"headers"`
+	QueryParams string            `json:"query_params"`
+	RequestBody string            `json:"request_body"`
+	CustomerID  string            `json:"customer_id"`
+	HasAuth     bool              `json:"has_auth"`
+	CountryCode string            `json:"country_code"`
+	ASN         uint32            `json:"asn,omitempty"`
+	RequestCost int               `json:"request_cost,omitempty"`
+	SessionID   string            `json:"session_id"`
+	Timestamp   string            `json:"timestamp,omitempty"` // RFC 3339; set for historical events
+
+	// PeerIP is the client address that IP blocks, overrides, and other local
+	// enforcement key on: the connection's peer, or the client a trusted proxy
+	// forwarded for. Unlike SourceIP, which may come from a client-supplied
+	// X-Forwarded-For, the client can't choose it. It is never sent.
+	PeerIP string `json:"-"`
+
+	GraphQL []*GraphQLOperation `json:"graphql,omitempty"` // Operations of a GraphQL request, one per batch entry
+
+	// BodyTruncated means RequestBody holds only part of the body, or none of it,
+	// because of the middleware's body capture limits (schema 5)
+	BodyTruncated bool `json:"body_truncated,omitempty"`
+
+	// Files describes the file parts of a multipart/form-data body, whose form fields
+	// then make up RequestBody (schema 6)
+	Files []*UploadedFile `json:"files,omitempty"`
+
+	// RouteMeta is the sensitivity the application declared for the route (schema 8)
+	RouteMeta *RouteMeta `json:"route_meta,omitempty"`
+
+	// Header delta encoding, set by the client when sending (schema 3). HeadersID marks
+	// Headers as a full set the backend should remember; HeadersRef means Headers only
+	// holds changes against that set, minus HeadersRemoved.
+	HeadersID      string   `json:"headers_id,omitempty"`
+	HeadersRef     string   `json:"headers_ref,omitempty"`
+	HeadersRemoved []string `json:"headers_removed,omitempty"`
+
+	// SchemaVersion is set by the SDK when sending; see SchemaVersion
+	SchemaVersion int `json:"schema_version,omitempty"`
+}
+
+// RouteMeta describes how sensitive a route is, as declared by the application
+type RouteMeta struct {
+	Sensitivity  string `json:"sensitivity,omitempty"`   // "low", "medium", "high", or "critical"
+	DataClass    string `json:"data_class,omitempty"`    // Data the route handles, e.g. "pii" or "payments"
+	AuthRequired bool   `json:"auth_required,omitempty"` // The route is only for authenticated callers
+}
+
+// SecurityEventResponse represents the response from security analysis
+type SecurityEventResponse struct {
+	EventID        string           `json:"event_id"`
+	RiskScore      int              `json:"risk_score"`
+	RiskReasons    []string         `json:"risk_reasons"`
+	Reasons        []RiskReason     `json:"reasons,omitempty"` // Structured form of RiskReasons
+	Action         string           `json:"action"`
+	Allowed        bool             `json:"allowed"`
+	OwaspDetected  []OwaspDetection `json:"owasp_detected"`
+	ProcessingTime string           `json:"processing_time_ms"`
+}
+
+// OwaspDetection represents an OWASP vulnerability detection
+type OwaspDetection struct {
+	ID              uint   `json:"id"`
+	SecurityEventID uint   `json:"security_event_id"`
+	OrganizationID  uint   `json:"organization_id"`
+	OwaspCategory   string `json:"owasp_category"`
+	OwaspTitle      string `json:"owasp_title"`
+	Severity        string `json:"severity"`
+	PatternMatched  string `json:"pattern_matched"`
+	Evidence        string `json:"evidence"`
+	Recommendation  string `json:"recommendation"`
+	FoundIn         string `json:"found_in"`
+	CreatedAt       string `json:"created_at"`
+
+	CWEIDs           []string `json:"cwe_ids,omitempty"`           // e.g. "CWE-89"
+	AttackTechniques []string `json:"attack_techniques,omitempty"` // MITRE ATT&CK, e.g. "T1190"
+
+	OriginalSeverity string `json:"original_severity,omitempty"` // Set when an SDK severity rule changed Severity
+}
+
+// LLMGuardRequest represents a request to analyze an LLM prompt
+type LLMGuardRequest struct {
+	Input   string            `json:"input"`
+	Context map[string]string `json:"context,omitempty"`
+}
+
+// LLMDetection represents an LLM prompt violation detection
+type LLMDetection struct {
+	RuleID         string `json:"rule_id"`
+	Title          string `json:"title"`
+	Severity       string `json:"severity"`
+	PatternMatched string `json:"pattern_matched"`
+	Evidence       string `json:"evidence"`
+	Recommendation string `json:"recommendation"`
+}
+
+// LLMGuardResponse represents the response from LLM prompt analysis
+type LLMGuardResponse struct {
+	Allowed        bool           `json:"allowed"`
+	Action         string         `json:"action"`
+	Reasons        []string       `json:"reasons"`
+	Detections     []LLMDetection `json:"detections"`
+	ProcessingTime string         `json:"processing_time_ms"`
+}
+
+// RiskReason is a machine-readable counterpart of an entry in RiskReasons. Match on Code
+// for metrics labels, conditional logic, or localized messages; Message is the English
+// prose also found in RiskReasons.
+type RiskReason struct {
+	Code     string `json:"code"`     // Stable identifier, e.g. "sql_injection", "geo_blocked"
+	Category string `json:"category"` // Reason family, e.g. "injection", "policy", "geo"
+	Weight   int    `json:"weight"`   // Contribution to RiskScore; 0 if the reason didn't change the score
+	Message  string `json:"message"`
+}
+
+// HasReason reports whether the analysis carries a reason with code
+func (r *SecurityEventResponse) HasReason(code string) bool {
+	for _, reason := range r.Reasons {
+		if reason.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// AddReason records a reason in both Reasons and RiskReasons so they stay parallel
+func (r *SecurityEventResponse) AddReason(code, category string, weight int, message string) {
+	r.Reasons = append(r.Reasons, RiskReason{Code: code, Category: category, Weight: weight, Message: message})
+	r.RiskReasons = append(r.RiskReasons, message)
+}
+
+// GraphQL operation types
+const (
+	GraphQLQuery        = "query"
+	GraphQLMutation     = "mutation"
+	GraphQLSubscription = "subscription"
+)
+
+// GraphQLOperation is one GraphQL operation carried by a request
+type GraphQLOperation struct {
+	OperationName string                 `json:"operation_name,omitempty"`
+	OperationType string                 `json:"operation_type"` // GraphQLQuery, GraphQLMutation, or GraphQLSubscription
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+	Introspection bool                   `json:"introspection,omitempty"` // Selects __schema or __type
+}
+
+// UploadedFile describes one file part of a multipart/form-data request
+type UploadedFile struct {
+	Field       string `json:"field"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type,omitempty"` // As declared by the client
+	Size        int64  `json:"size"`                   // Bytes seen; a lower bound when Truncated
+	SHA256      string `json:"sha256,omitempty"`       // Hex digest of the whole file, if hashed and not truncated
+	Sample      string `json:"sample,omitempty"`       // Base64 of the file's first bytes, if sampled
+	Truncated   bool   `json:"truncated,omitempty"`    // Body capture limits cut the file short
+}
+
+// Finding represents a detection raised locally by the SDK rather than by the analysis API
+type Finding struct {
+	Type       string            `json:"type"`
+	Title      string            `json:"title"`
+	Severity   string            `json:"severity"`
+	Evidence   string            `json:"evidence"`
+	Path       string            `json:"path,omitempty"`
+	SourceIP   string            `json:"source_ip,omitempty"`
+	CustomerID string            `json:"customer_id"`
+	SessionID  string            `json:"session_id"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+
+	CWEIDs           []string `json:"cwe_ids,omitempty"`
+	AttackTechniques []string `json:"attack_techniques,omitempty"`
+}
+
+// SessionRollup summarizes one session's traffic over a reporting window, so the backend
+// gets behavioral context without an event for every low-value request
+type SessionRollup struct {
+	SessionKey    string         `json:"session_key"` // Hash of the application's session key
+	CustomerID    string         `json:"customer_id"`
+	SessionID     string         `json:"session_id"`   // SDK instance, as on events
+	FirstSeen     string         `json:"first_seen"`   // RFC 3339
+	WindowStart   string         `json:"window_start"` // RFC 3339
+	WindowEnd     string         `json:"window_end"`   // RFC 3339
+	Requests      int            `json:"requests"`     // In this window
+	TotalRequests int            `json:"total_requests"`
+	Analyzed      int            `json:"analyzed"` // Requests with a verdict
+	Blocked       int            `json:"blocked"`
+	Abandoned     int            `json:"abandoned"` // Clients that disconnected before the verdict
+	Methods       map[string]int `json:"methods"`
+	DistinctPaths int            `json:"distinct_paths"`
+	SourceIPs     []string       `json:"source_ips,omitempty"`
+	MaxRiskScore  int            `json:"max_risk_score"`
+	MeanRiskScore float64        `json:"mean_risk_score"`
+	RiskScores    []int          `json:"risk_scores,omitempty"` // Verdict scores in arrival order, the most recent if capped
+}
+
+// ResponseEvent is an outgoing response submitted for analysis, e.g. for data leakage,
+// verbose stack traces, or reflected injection payloads
+type ResponseEvent struct {
+	EventID       string                `json:"event_id,omitempty"` // Event of the request it answers, when that was analyzed
+	Request       *SecurityEventRequest `json:"request"`            // The request, for detecting reflections
+	StatusCode    int                   `json:"status_code"`
+	Headers       map[string]string     `json:"headers"`
+	Body          string                `json:"body"`
+	BodyTruncated bool                  `json:"body_truncated,omitempty"` // Body holds only part of the response body, or none of it
+	BodyBytes     int64                 `json:"body_bytes"`               // Size of the whole response body as sent
+	Streaming     bool                  `json:"streaming,omitempty"`      // Streamed (SSE, flushed chunks); Body holds at most what preceded streaming
+	Timestamp     string                `json:"timestamp"`                // RFC 3339
+}
+
+// ResponseAnalysis is the verdict on a ResponseEvent
+type ResponseAnalysis struct {
+	EventID   string       `json:"event_id"`
+	RiskScore int          `json:"risk_score"`
+	Reasons   []RiskReason `json:"reasons,omitempty"` // e.g. "data_leakage", "stack_trace", "reflected_input"
+}
@@ -0,0 +1,83 @@
+/**
+ * Guardial Go SDK Event IDs
+ * Client-side ULIDs, so events can be referenced before the API answers
+ */
+
+package guardial
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"sync"
+	"time"
+)
+
+// crockford is the base32 alphabet of ULIDs
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ulidSource keeps ULIDs generated in the same millisecond increasing
+var ulidSource struct {
+	mu      sync.Mutex
+	ms      uint64
+	entropy [10]byte
+}
+
+// NewULID returns a ULID: 26 characters that sort by creation time, from a 48-bit
+// millisecond timestamp and 80 random bits. ULIDs from the same millisecond increase
+// monotonically within the process. It is the default Config.IDGenerator.
+func NewULID() string {
+	ms := uint64(time.Now().UnixMilli())
+
+	ulidSource.mu.Lock()
+	entropy := &ulidSource.entropy
+	if ms > ulidSource.ms || !incrementEntropy(entropy) {
+		// A new millisecond, or the random part overflowed (2^80 IDs in one millisecond)
+		ulidSource.ms = ms
+		if _, err := rand.Read(entropy[:]); err != nil {
+			// Without randomness, keep counting from the current value
+			incrementEntropy(entropy)
+		}
+	} else {
+		ms = ulidSource.ms // The clock stepped back; stay ordered
+	}
+	var id [16]byte
+	binary.BigEndian.PutUint16(id[0:2], uint16(ms>>32))
+	binary.BigEndian.PutUint32(id[2:6], uint32(ms))
+	copy(id[6:], entropy[:])
+	ulidSource.mu.Unlock()
+
+	return encodeULID(id)
+}
+
+// incrementEntropy adds one to entropy, reporting false if it wrapped around
+func incrementEntropy(entropy *[10]byte) bool {
+	for i := len(entropy) - 1; i >= 0; i-- {
+		entropy[i]++
+		if entropy[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// encodeULID writes the 128 bits of id as 26 base32 characters, most significant first
+func encodeULID(id [16]byte) string {
+	hi := binary.BigEndian.Uint64(id[0:8])
+	lo := binary.BigEndian.Uint64(id[8:16])
+
+	var out [26]byte
+	for i := 25; i >= 0; i-- {
+		out[i] = crockford[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:])
+}
+
+// newEventID returns an ID for an event from Config.IDGenerator
+func (c *Client) newEventID() string {
+	if c.config.IDGenerator != nil {
+		return c.config.IDGenerator()
+	}
+	return NewULID()
+}
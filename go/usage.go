@@ -0,0 +1,133 @@
+/**
+ * Guardial Go SDK Usage and Quota
+ * Plan usage visibility and threshold alerts before the monthly quota runs out
+ */
+
+package guardial
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Usage headers sent by the API on every response
+const (
+	HeaderUsageUsed  = "X-Guardial-Usage-Used"  // Requests used this period
+	HeaderUsageLimit = "X-Guardial-Usage-Limit" // Requests allowed this period
+	HeaderUsageReset = "X-Guardial-Usage-Reset" // When the period resets (RFC 3339)
+)
+
+// Usage reports the account's request usage for the current billing period
+type Usage struct {
+	Plan         string    `json:"plan"`          // e.g. "free_forever"
+	RequestsUsed int64     `json:"requests_used"` // Requests counted this period
+	RequestLimit int64     `json:"request_limit"` // Requests allowed this period; 0 means unlimited
+	PeriodStart  time.Time `json:"period_start"`
+	ResetsAt     time.Time `json:"resets_at"`
+}
+
+// Remaining returns the requests left this period, or -1 for unlimited plans
+func (u *Usage) Remaining() int64 {
+	if u.RequestLimit <= 0 {
+		return -1
+	}
+	if remaining := u.RequestLimit - u.RequestsUsed; remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// Fraction returns the share of the limit used (1.0 = exhausted), or 0 for unlimited plans
+func (u *Usage) Fraction() float64 {
+	if u.RequestLimit <= 0 {
+		return 0
+	}
+	return float64(u.RequestsUsed) / float64(u.RequestLimit)
+}
+
+// UsageAlertConfig calls OnThreshold once per billing period as usage crosses each threshold
+type UsageAlertConfig struct {
+	Thresholds  []float64                            // Fractions of the limit (default: 0.8, 0.9, 1.0)
+	OnThreshold func(usage Usage, threshold float64) // Called from a background goroutine
+}
+
+// GetUsage returns requests used this period, the plan limit, and the reset date
+func (c *Client) GetUsage(ctx context.Context) (*Usage, error) {
+	var usage Usage
+	if err := c.getJSON(ctx, "/api/usage", &usage); err != nil {
+		return nil, err
+	}
+	c.checkUsage(usage)
+	c.observeQuota(usage)
+	return &usage, nil
+}
+
+// usageTracker remembers which thresholds have already fired this period
+type usageTracker struct {
+	mu      sync.Mutex
+	period  time.Time // ResetsAt of the period being tracked
+	crossed float64   // Highest threshold already reported this period
+}
+
+// observeUsage checks usage headers on API responses against the alert thresholds, so
+// alerts fire without polling GetUsage
+func (c *Client) observeUsage(header http.Header) {
+	if c.config.UsageAlert == nil && c.governor == nil {
+		return
+	}
+	used, err := strconv.ParseInt(header.Get(HeaderUsageUsed), 10, 64)
+	if err != nil {
+		return
+	}
+	limit, err := strconv.ParseInt(header.Get(HeaderUsageLimit), 10, 64)
+	if err != nil {
+		return
+	}
+	usage := Usage{RequestsUsed: used, RequestLimit: limit}
+	usage.ResetsAt, _ = time.Parse(time.RFC3339, header.Get(HeaderUsageReset))
+	c.checkUsage(usage)
+	c.observeQuota(usage)
+}
+
+// checkUsage fires OnThreshold for the highest newly crossed threshold
+func (c *Client) checkUsage(usage Usage) {
+	alert := c.config.UsageAlert
+	if alert == nil || alert.OnThreshold == nil || usage.RequestLimit <= 0 {
+		return
+	}
+	thresholds := alert.Thresholds
+	if len(thresholds) == 0 {
+		thresholds = []float64{0.8, 0.9, 1.0}
+	}
+
+	t := &c.usage
+	t.mu.Lock()
+	// A new billing period re-arms every threshold
+	if !usage.ResetsAt.IsZero() && !usage.ResetsAt.Equal(t.period) {
+		t.period = usage.ResetsAt
+		t.crossed = 0
+	}
+
+	fraction := usage.Fraction()
+	var fire float64
+	sorted := append([]float64(nil), thresholds...)
+	sort.Float64s(sorted)
+	for _, threshold := range sorted {
+		if fraction >= threshold && threshold > t.crossed {
+			fire = threshold
+		}
+	}
+	if fire > 0 {
+		t.crossed = fire
+	}
+	t.mu.Unlock()
+
+	if fire > 0 {
+		c.log("⚠️ Usage crossed", strconv.FormatFloat(fire*100, 'f', 0, 64)+"% of plan limit:", usage.RequestsUsed, "/", usage.RequestLimit)
+		go alert.OnThreshold(usage, fire)
+	}
+}
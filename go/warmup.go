@@ -0,0 +1,91 @@
+/**
+ * Guardial Go SDK Warm-up
+ * Pre-resolves, pre-connects, and primes caches before a server takes traffic
+ */
+
+package guardial
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sync"
+)
+
+// maxWarmupConns caps the connections Warmup opens per endpoint
+const maxWarmupConns = 8
+
+// Warmup pays the first-request costs up front: it resolves every endpoint's host, opens
+// pooled TLS connections to each endpoint (which also negotiates the events schema),
+// fetches plan usage when usage alerts or the quota governor are configured, and
+// connects to the analysis sidecar. Call it before the server starts accepting traffic.
+// Failures are returned but leave the client fully usable.
+func (c *Client) Warmup(ctx context.Context) error {
+	endpoints := append([]string{c.config.Endpoint}, c.config.FallbackEndpoints...)
+
+	var mu sync.Mutex
+	var errs []error
+	fail := func(err error) {
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+	}
+
+	var wg sync.WaitGroup
+	for _, endpoint := range endpoints {
+		if u, err := url.Parse(endpoint); err == nil && c.config.ProxyURL == "" && net.ParseIP(u.Hostname()) == nil {
+			if _, err := net.DefaultResolver.LookupHost(ctx, u.Hostname()); err != nil {
+				fail(fmt.Errorf("failed to resolve %s: %w", u.Hostname(), err))
+				continue
+			}
+		}
+
+		// Concurrent requests each open a connection; they stay pooled afterwards
+		for i := 0; i < c.warmupConns(); i++ {
+			wg.Add(1)
+			go func(endpoint string) {
+				defer wg.Done()
+				if _, err := c.healthCheck(ctx, endpoint); err != nil {
+					fail(fmt.Errorf("failed to connect to %s: %w", endpoint, err))
+				}
+			}(endpoint)
+		}
+	}
+
+	if sidecar, ok := c.transport.(*sidecarClient); ok {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := sidecar.warm(ctx); err != nil {
+				fail(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if c.config.UsageAlert != nil || c.governor != nil {
+		if _, err := c.GetUsage(ctx); err != nil {
+			fail(fmt.Errorf("failed to fetch usage: %w", err))
+		}
+	}
+
+	err := errors.Join(errs...)
+	if err != nil {
+		c.log("⚠️ Warm-up incomplete:", err)
+	} else {
+		c.log("⚡ Warm-up complete")
+	}
+	return err
+}
+
+// warmupConns returns how many connections to open per endpoint: enough for a burst of
+// first requests, within what the pool keeps idle
+func (c *Client) warmupConns() int {
+	options := c.config.TransportOptions
+	if options == nil || options.MaxIdleConnsPerHost <= 0 || options.DisableKeepAlives {
+		return 1
+	}
+	return min(options.MaxIdleConnsPerHost, maxWarmupConns)
+}
@@ -0,0 +1,196 @@
+/**
+ * Guardial Go SDK Detector Watchdog
+ * Per-detector latency budgets that disable slow local detectors for a cool-down
+ */
+
+package guardial
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Local detectors the watchdog times
+const (
+	DetectorCanary      = "canary"             // Replayed canary token lookup
+	DetectorFingerprint = "client_fingerprint" // Machine-to-machine caller check
+	DetectorGraphQL     = "graphql"            // GraphQL parsing and operation rules
+)
+
+// accessControl reports whether detector enforces access rules rather than detecting
+// attacks. The watchdog never disables these: slowing them down on purpose would
+// otherwise switch off the caller allowlists and GraphQL limits.
+func accessControl(detector string) bool {
+	return detector == DetectorFingerprint || detector == DetectorGraphQL
+}
+
+// Watchdog defaults
+const (
+	defaultDetectorBudget   = 5 * time.Millisecond
+	defaultDetectorWindow   = 1000
+	defaultDetectorMinCalls = 100
+	defaultDetectorCoolDown = 5 * time.Minute
+	detectorCheckEvery      = 100 // Calls between p99 checks
+)
+
+// WatchdogOptions configures the detector watchdog. It times every run of the canary
+// detector and, when its p99 over the last Window runs exceeds its budget, disables it
+// for CoolDown: requests then proceed without that check. Afterwards the detector is
+// re-enabled and measured afresh. Client fingerprints and GraphQL rules are access
+// controls, so they always run.
+type WatchdogOptions struct {
+	Budgets       map[string]time.Duration // p99 budget per detector (Detector* constants)
+	DefaultBudget time.Duration            // Budget of detectors not in Budgets (default: 5ms)
+	Window        int                      // Runs the p99 is computed over (default: 1000)
+	MinCalls      int                      // Runs needed before a detector can be disabled (default: 100)
+	CoolDown      time.Duration            // How long a detector stays disabled (default: 5m)
+
+	// OnChange is called as a detector is disabled and re-enabled, e.g. to alert on
+	// the lost coverage; p99 is the latency that disabled it
+	OnChange func(detector string, disabled bool, p99 time.Duration)
+}
+
+// detectorTiming is the watchdog's state for one detector
+type detectorTiming struct {
+	samples       []time.Duration // Ring of the last Window run times
+	next          int
+	calls         int
+	disabledUntil time.Time
+}
+
+type detectorWatchdog struct {
+	client  *Client
+	options WatchdogOptions
+
+	mu        sync.Mutex
+	detectors map[string]*detectorTiming
+}
+
+func newDetectorWatchdog(client *Client, options *WatchdogOptions) *detectorWatchdog {
+	opts := *options
+	if opts.DefaultBudget <= 0 {
+		opts.DefaultBudget = defaultDetectorBudget
+	}
+	if opts.Window <= 0 {
+		opts.Window = defaultDetectorWindow
+	}
+	if opts.MinCalls <= 0 {
+		opts.MinCalls = defaultDetectorMinCalls
+	}
+	if opts.MinCalls > opts.Window {
+		opts.MinCalls = opts.Window
+	}
+	if opts.CoolDown <= 0 {
+		opts.CoolDown = defaultDetectorCoolDown
+	}
+	return &detectorWatchdog{client: client, options: opts, detectors: make(map[string]*detectorTiming)}
+}
+
+// watch runs the local detector fn, under the watchdog if there is one; it returns
+// false if the detector is disabled and fn was skipped
+func (m *middleware) watch(ctx context.Context, detector string, fn func()) bool {
+	start := time.Now()
+	ran := true
+	if m.watchdog == nil || accessControl(detector) {
+		fn()
+	} else {
+		ran = m.watchdog.run(detector, fn)
+	}
+	if trace := traceFromContext(ctx); trace != nil {
+		detail := ""
+		if !ran {
+			detail = "disabled by watchdog"
+		}
+		trace.record(detector, start, detail)
+	}
+	return ran
+}
+
+// watchGraphQL runs checkGraphQL under the watchdog
+func (m *middleware) watchGraphQL(ctx context.Context, event *SecurityEventRequest) (rejection *Rejection) {
+	m.watch(ctx, DetectorGraphQL, func() { rejection = m.checkGraphQL(event) })
+	return rejection
+}
+
+func (w *detectorWatchdog) run(detector string, fn func()) bool {
+	if !w.enabled(detector) {
+		return false
+	}
+	start := time.Now()
+	fn()
+	w.record(detector, time.Since(start))
+	return true
+}
+
+// enabled reports whether detector may run, re-enabling it once its cool-down is over
+func (w *detectorWatchdog) enabled(detector string) bool {
+	w.mu.Lock()
+	timing := w.detectors[detector]
+	if timing == nil || timing.disabledUntil.IsZero() {
+		w.mu.Unlock()
+		return true
+	}
+	if time.Now().Before(timing.disabledUntil) {
+		w.mu.Unlock()
+		return false
+	}
+	*timing = detectorTiming{}
+	w.mu.Unlock()
+
+	w.client.log("⏱️ Detector re-enabled after cool-down:", detector)
+	if w.options.OnChange != nil {
+		w.options.OnChange(detector, false, 0)
+	}
+	return true
+}
+
+// record adds a run time and disables detector if its p99 is over budget
+func (w *detectorWatchdog) record(detector string, elapsed time.Duration) {
+	w.mu.Lock()
+	timing := w.detectors[detector]
+	if timing == nil {
+		timing = &detectorTiming{samples: make([]time.Duration, 0, w.options.Window)}
+		w.detectors[detector] = timing
+	}
+	if !timing.disabledUntil.IsZero() {
+		w.mu.Unlock()
+		return // Disabled by a concurrent run
+	}
+	if len(timing.samples) < w.options.Window {
+		timing.samples = append(timing.samples, elapsed)
+	} else {
+		timing.samples[timing.next] = elapsed
+		timing.next = (timing.next + 1) % w.options.Window
+	}
+	timing.calls++
+	if timing.calls < w.options.MinCalls || (timing.calls-w.options.MinCalls)%detectorCheckEvery != 0 {
+		w.mu.Unlock()
+		return
+	}
+
+	p99 := percentile(timing.samples, 0.99)
+	budget, ok := w.options.Budgets[detector]
+	if !ok {
+		budget = w.options.DefaultBudget
+	}
+	if p99 <= budget {
+		w.mu.Unlock()
+		return
+	}
+	timing.disabledUntil = time.Now().Add(w.options.CoolDown)
+	w.mu.Unlock()
+
+	w.client.log("⏱️ Detector disabled for", w.options.CoolDown, "- p99", p99, "over budget", budget, ":", detector)
+	if w.options.OnChange != nil {
+		w.options.OnChange(detector, true, p99)
+	}
+}
+
+// percentile returns the q quantile of samples, which are left unchanged
+func percentile(samples []time.Duration, q float64) time.Duration {
+	sorted := append([]time.Duration(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[int(float64(len(sorted)-1)*q)]
+}
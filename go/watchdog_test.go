@@ -0,0 +1,33 @@
+package guardial
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWatchdogKeepsAccessControls(t *testing.T) {
+	tests := []struct {
+		detector    string
+		wantEnabled bool
+	}{
+		{DetectorCanary, false},
+		{DetectorFingerprint, true},
+		{DetectorGraphQL, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.detector, func(t *testing.T) {
+			options := DefaultMiddlewareOptions()
+			options.Watchdog = &WatchdogOptions{DefaultBudget: time.Microsecond, Window: 1, MinCalls: 1, CoolDown: time.Hour}
+			m := newMiddleware(NewClient(&Config{APIKey: "test"}), options)
+
+			// A slow run puts the detector over budget
+			m.watch(context.Background(), tt.detector, func() { time.Sleep(time.Millisecond) })
+
+			ran := false
+			if m.watch(context.Background(), tt.detector, func() { ran = true }) != tt.wantEnabled || ran != tt.wantEnabled {
+				t.Errorf("detector ran = %t after exceeding its budget, want %t", ran, tt.wantEnabled)
+			}
+		})
+	}
+}
@@ -0,0 +1,309 @@
+/**
+ * Guardial Go SDK Webhook Guard
+ * Signature verification and replay protection for inbound third-party webhooks
+ */
+
+package guardial
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Built-in webhook signature schemes
+const (
+	WebhookStripe = "stripe" // Stripe-Signature: t=<unix>,v1=<HMAC of "t.body">
+	WebhookGitHub = "github" // X-Hub-Signature-256: sha256=<HMAC of body>
+	WebhookSlack  = "slack"  // X-Slack-Signature: v0=<HMAC of "v0:ts:body">, X-Slack-Request-Timestamp
+)
+
+// defaultReplayWindow bounds delivery age and how long delivery IDs are remembered
+const defaultReplayWindow = 5 * time.Minute
+
+// maxRememberedDeliveries bounds the in-memory delivery log of an endpoint
+const maxRememberedDeliveries = 100000
+
+// webhookMaxBodyBytes is the body capture limit of WebhookMiddlewareOptions; GitHub
+// sends payloads of up to 25MB, and a signature can only be checked on the whole body
+const webhookMaxBodyBytes = 25 << 20
+
+// ErrReplayedWebhook is reported for a webhook delivery received before
+var ErrReplayedWebhook = errors.New("guardial: webhook delivery replayed")
+
+// WebhookDelivery is what a verifier learns from a delivery with a valid signature
+type WebhookDelivery struct {
+	ID        string    // Identifies the delivery for replay detection; must be covered by the signature
+	Timestamp time.Time // When the sender signed it; zero if the scheme doesn't say
+}
+
+// WebhookVerifier checks the signature of a delivery against secret. It returns
+// ErrMissingSignature or ErrInvalidSignature (possibly wrapped) if the delivery isn't
+// authentic; the timestamp is checked by the caller.
+type WebhookVerifier func(header http.Header, body []byte, secret string) (WebhookDelivery, error)
+
+var webhookSchemes = struct {
+	sync.RWMutex
+	verifiers map[string]WebhookVerifier
+}{verifiers: map[string]WebhookVerifier{
+	WebhookStripe: verifyStripe,
+	WebhookGitHub: verifyGitHub,
+	WebhookSlack:  verifySlack,
+}}
+
+// RegisterWebhookScheme makes a signature scheme available to WebhookOptions by name,
+// replacing any scheme registered under it before
+func RegisterWebhookScheme(name string, verifier WebhookVerifier) {
+	webhookSchemes.Lock()
+	defer webhookSchemes.Unlock()
+	webhookSchemes.verifiers[name] = verifier
+}
+
+func webhookScheme(name string) WebhookVerifier {
+	webhookSchemes.RLock()
+	defer webhookSchemes.RUnlock()
+	return webhookSchemes.verifiers[name]
+}
+
+// WebhookDeduper records deliveries across instances, e.g. with Redis SET NX EX
+type WebhookDeduper interface {
+	// FirstSeen records key and reports whether it wasn't recorded within ttl
+	FirstSeen(ctx context.Context, key string, ttl time.Duration) (bool, error)
+}
+
+// WebhookOptions verifies the deliveries a third party sends to a webhook endpoint.
+// Requests must carry a valid signature, a timestamp within ReplayWindow (for schemes
+// that sign one), and a delivery not seen within ReplayWindow; the rest are refused
+// before analysis. Verified deliveries are then analyzed like any request.
+type WebhookOptions struct {
+	// Path selects the requests to verify, in RouteRule syntax ("/webhooks/stripe");
+	// empty verifies every request through the middleware
+	Path string
+
+	Scheme  string   // WebhookStripe, WebhookGitHub, WebhookSlack, or a registered name
+	Secrets []string // Signing secrets; a delivery signed with any of them passes, for rotation
+
+	// ReplayWindow is the age past which deliveries are refused as stale, and how long
+	// delivery IDs are remembered to refuse duplicates (default: 5m). GitHub signs
+	// neither a timestamp nor its delivery ID, so its deliveries are only checked for
+	// duplicate signatures.
+	ReplayWindow time.Duration
+
+	// Deduper shares the delivery log between instances; nil keeps it in memory, so a
+	// replay sent to another instance goes unnoticed
+	Deduper WebhookDeduper
+}
+
+// WebhookMiddlewareOptions returns the options for middleware in front of webhook
+// endpoints: DefaultMiddlewareOptions verifying webhooks, with the pre-filter off and
+// bodies of up to 25MB captured
+func WebhookMiddlewareOptions(webhooks ...WebhookOptions) *MiddlewareOptions {
+	options := DefaultMiddlewareOptions()
+	options.Webhooks = webhooks
+	options.Prefilter = nil
+	options.MaxBodyBytes = webhookMaxBodyBytes
+	return options
+}
+
+// webhookGuard verifies the deliveries of one WebhookOptions entry
+type webhookGuard struct {
+	client   *Client
+	options  WebhookOptions
+	verifier WebhookVerifier
+
+	mu        sync.Mutex
+	delivered map[string]time.Time // Expiry of remembered delivery keys, without a Deduper
+	pruneAt   int
+}
+
+func newWebhookGuard(client *Client, options WebhookOptions) *webhookGuard {
+	if options.ReplayWindow <= 0 {
+		options.ReplayWindow = defaultReplayWindow
+	}
+	verifier := webhookScheme(options.Scheme)
+	if verifier == nil {
+		client.log("⚠️ Unknown webhook scheme, deliveries will be refused:", options.Scheme)
+	}
+	return &webhookGuard{client: client, options: options, verifier: verifier, delivered: make(map[string]time.Time), pruneAt: 1024}
+}
+
+// checkWebhook verifies a request against the webhook entry matching its path, if any,
+// and returns the rejection for deliveries that fail verification
+func (m *middleware) checkWebhook(ctx context.Context, event *SecurityEventRequest, header http.Header, body []byte, truncated bool) *Rejection {
+	var guard *webhookGuard
+	for _, candidate := range m.webhooks {
+		if candidate.options.Path == "" || matchPathPattern(candidate.options.Path, event.Path) {
+			guard = candidate
+			break
+		}
+	}
+	if guard == nil {
+		return nil
+	}
+
+	start := time.Now()
+	err := guard.verify(ctx, header, body, truncated)
+	if err == nil {
+		traceFromContext(ctx).record("webhook", start, "verified")
+		return nil
+	}
+	traceFromContext(ctx).record("webhook", start, err.Error())
+	m.client.log("🚫 Webhook delivery refused:", event.Path, err)
+
+	rejection := &Rejection{Status: http.StatusUnauthorized, Message: "Invalid webhook signature", Category: ReasonCategoryWebhook}
+	switch {
+	case truncated:
+		rejection.Status, rejection.Message = http.StatusRequestEntityTooLarge, "Webhook payload too large"
+	case errors.Is(err, ErrStaleSignature):
+		rejection.Message = "Stale webhook delivery"
+	case errors.Is(err, ErrReplayedWebhook):
+		rejection.Status, rejection.Message = http.StatusConflict, "Webhook delivery already received"
+	}
+	return rejection
+}
+
+// verify checks a delivery's signature, age, and novelty
+func (g *webhookGuard) verify(ctx context.Context, header http.Header, body []byte, truncated bool) error {
+	if truncated {
+		return fmt.Errorf("%w: body exceeds MaxBodyBytes", ErrInvalidSignature)
+	}
+	if g.verifier == nil {
+		return fmt.Errorf("%w: unknown scheme %q", ErrInvalidSignature, g.options.Scheme)
+	}
+
+	var delivery WebhookDelivery
+	err := ErrMissingSignature
+	for _, secret := range g.options.Secrets {
+		if delivery, err = g.verifier(header, body, secret); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if !delivery.Timestamp.IsZero() {
+		if age := time.Since(delivery.Timestamp); age > g.options.ReplayWindow || age < -g.options.ReplayWindow {
+			return ErrStaleSignature
+		}
+	}
+	if delivery.ID == "" {
+		return nil
+	}
+
+	key := g.options.Scheme + ":" + delivery.ID
+	if g.options.Deduper != nil {
+		first, err := g.options.Deduper.FirstSeen(ctx, key, g.options.ReplayWindow)
+		if err != nil {
+			// Don't refuse authentic deliveries because the log is unavailable
+			g.client.warn("Webhook delivery log unavailable:", err)
+			return nil
+		}
+		if !first {
+			return ErrReplayedWebhook
+		}
+		return nil
+	}
+	if !g.remember(key, time.Now()) {
+		return ErrReplayedWebhook
+	}
+	return nil
+}
+
+// remember records key in the in-memory delivery log and reports whether it is new
+func (g *webhookGuard) remember(key string, now time.Time) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if expires, ok := g.delivered[key]; ok && now.Before(expires) {
+		return false
+	}
+	if len(g.delivered) >= g.pruneAt {
+		for k, expires := range g.delivered {
+			if !now.Before(expires) {
+				delete(g.delivered, k)
+			}
+		}
+		g.pruneAt = max(1024, 2*len(g.delivered))
+		if len(g.delivered) >= maxRememberedDeliveries {
+			return true // Over the bound, new deliveries go unrecorded
+		}
+	}
+	g.delivered[key] = now.Add(g.options.ReplayWindow)
+	return true
+}
+
+// webhookMAC returns the hex HMAC-SHA256 of the concatenated parts under secret
+func webhookMAC(secret string, parts ...[]byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	for _, part := range parts {
+		mac.Write(part)
+	}
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func verifyStripe(header http.Header, body []byte, secret string) (WebhookDelivery, error) {
+	value := header.Get("Stripe-Signature")
+	if value == "" {
+		return WebhookDelivery{}, ErrMissingSignature
+	}
+	var timestamp string
+	var signatures []string
+	for _, item := range strings.Split(value, ",") {
+		key, val, _ := strings.Cut(strings.TrimSpace(item), "=")
+		switch key {
+		case "t":
+			timestamp = val
+		case "v1":
+			signatures = append(signatures, val)
+		}
+	}
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return WebhookDelivery{}, fmt.Errorf("%w: bad timestamp %q", ErrInvalidSignature, timestamp)
+	}
+
+	expected := webhookMAC(secret, []byte(timestamp), []byte("."), body)
+	for _, signature := range signatures {
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			return WebhookDelivery{ID: signature, Timestamp: time.Unix(unix, 0)}, nil
+		}
+	}
+	return WebhookDelivery{}, ErrInvalidSignature
+}
+
+func verifyGitHub(header http.Header, body []byte, secret string) (WebhookDelivery, error) {
+	signature, found := strings.CutPrefix(header.Get("X-Hub-Signature-256"), "sha256=")
+	if !found {
+		return WebhookDelivery{}, ErrMissingSignature
+	}
+	if !hmac.Equal([]byte(signature), []byte(webhookMAC(secret, body))) {
+		return WebhookDelivery{}, ErrInvalidSignature
+	}
+	// X-GitHub-Delivery isn't signed, so a replay could carry a fresh one
+	return WebhookDelivery{ID: signature}, nil
+}
+
+func verifySlack(header http.Header, body []byte, secret string) (WebhookDelivery, error) {
+	signature, found := strings.CutPrefix(header.Get("X-Slack-Signature"), "v0=")
+	timestamp := header.Get("X-Slack-Request-Timestamp")
+	if !found || timestamp == "" {
+		return WebhookDelivery{}, ErrMissingSignature
+	}
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return WebhookDelivery{}, fmt.Errorf("%w: bad timestamp %q", ErrInvalidSignature, timestamp)
+	}
+	if !hmac.Equal([]byte(signature), []byte(webhookMAC(secret, []byte("v0:"+timestamp+":"), body))) {
+		return WebhookDelivery{}, ErrInvalidSignature
+	}
+	return WebhookDelivery{ID: signature, Timestamp: time.Unix(unix, 0)}, nil
+}
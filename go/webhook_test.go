@@ -0,0 +1,76 @@
+package guardial
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testWebhookSecret = "whsec_test"
+
+// githubDelivery builds a GitHub delivery of body signed with secret
+func githubDelivery(body []byte, secret, deliveryID string) *http.Request {
+	req := httptest.NewRequest("POST", "/webhooks/github", bytes.NewReader(body))
+	req.Header.Set("X-Hub-Signature-256", "sha256="+webhookMAC(secret, body))
+	req.Header.Set("X-GitHub-Delivery", deliveryID)
+	return req
+}
+
+// stripeDelivery builds a Stripe delivery of body signed with secret at signedAt
+func stripeDelivery(body []byte, secret string, signedAt time.Time) *http.Request {
+	timestamp := strconv.FormatInt(signedAt.Unix(), 10)
+	req := httptest.NewRequest("POST", "/webhooks/stripe", bytes.NewReader(body))
+	req.Header.Set("Stripe-Signature", "t="+timestamp+",v1="+webhookMAC(secret, []byte(timestamp), []byte("."), body))
+	return req
+}
+
+func TestWebhookDeliveries(t *testing.T) {
+	body := []byte(`{"action":"opened"}`)
+	large := []byte(`{"payload":"` + strings.Repeat("x", 2<<20) + `"}`)
+	now := time.Now()
+
+	tests := []struct {
+		name       string
+		deliveries []*http.Request
+		wantStatus []int
+	}{
+		{"github delivered once", []*http.Request{githubDelivery(body, testWebhookSecret, "d1")}, []int{http.StatusOK}},
+		{"github replayed with the same ID", []*http.Request{
+			githubDelivery(body, testWebhookSecret, "d1"), githubDelivery(body, testWebhookSecret, "d1"),
+		}, []int{http.StatusOK, http.StatusConflict}},
+		{"github replayed with a fresh ID", []*http.Request{
+			githubDelivery(body, testWebhookSecret, "d1"), githubDelivery(body, testWebhookSecret, "d2"),
+		}, []int{http.StatusOK, http.StatusConflict}},
+		{"github distinct payloads", []*http.Request{
+			githubDelivery(body, testWebhookSecret, "d1"), githubDelivery([]byte(`{"action":"closed"}`), testWebhookSecret, "d2"),
+		}, []int{http.StatusOK, http.StatusOK}},
+		{"github forged", []*http.Request{githubDelivery(body, "wrong", "d1")}, []int{http.StatusUnauthorized}},
+		{"github payload over 1MB", []*http.Request{githubDelivery(large, testWebhookSecret, "d1")}, []int{http.StatusOK}},
+		{"stripe replayed", []*http.Request{
+			stripeDelivery(body, testWebhookSecret, now), stripeDelivery(body, testWebhookSecret, now),
+		}, []int{http.StatusOK, http.StatusConflict}},
+		{"stripe stale", []*http.Request{stripeDelivery(body, testWebhookSecret, now.Add(-time.Hour))}, []int{http.StatusUnauthorized}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, verdictAPI(allowedVerdict))
+			options := WebhookMiddlewareOptions(
+				WebhookOptions{Path: "/webhooks/github", Scheme: WebhookGitHub, Secrets: []string{testWebhookSecret}},
+				WebhookOptions{Path: "/webhooks/stripe", Scheme: WebhookStripe, Secrets: []string{testWebhookSecret}},
+			)
+			handler := StandardMiddleware(client, options)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+			for i, req := range tt.deliveries {
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, req)
+				if rec.Code != tt.wantStatus[i] {
+					t.Errorf("delivery %d: status = %d, want %d", i, rec.Code, tt.wantStatus[i])
+				}
+			}
+		})
+	}
+}
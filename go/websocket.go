@@ -0,0 +1,125 @@
+/**
+ * Guardial Go SDK WebSocket Inspection
+ * Analysis of WebSocket upgrades and of the messages received on upgraded connections
+ */
+
+package guardial
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strings"
+)
+
+// MethodWebSocketMessage is the method of events for WebSocket messages, so exclusions
+// and route rules can tell them from the upgrade ("WEBSOCKET /chat/**")
+const MethodWebSocketMessage = "WEBSOCKET"
+
+// Verdict modes for inspected WebSocket messages
+const (
+	MessageVerdictClose = "close" // A refused message closes the connection (policy violation)
+	MessageVerdictDrop  = "drop"  // A refused message is skipped and the connection stays open
+)
+
+// defaultMaxMessageBytes bounds the part of a message submitted for analysis
+const defaultMaxMessageBytes = 64 * 1024
+
+// WebSocketOptions configures inspection of messages received on a connection
+type WebSocketOptions struct {
+	SampleRate      float64 // Fraction of messages analyzed (default: 1.0)
+	VerdictMode     string  // MessageVerdictClose (default) or MessageVerdictDrop
+	MaxMessageBytes int     // Message bytes analyzed; longer messages are truncated (default: 64KB)
+	InspectBinary   bool    // Also analyze binary messages; by default only text is
+}
+
+// IsWebSocketUpgrade reports whether r asks to switch to the WebSocket protocol
+func IsWebSocketUpgrade(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket") &&
+		strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade")
+}
+
+// MessageInspector analyzes the messages received on one WebSocket connection. The
+// guardialgorilla and guardialnhooyr adapters wrap connections with one.
+type MessageInspector struct {
+	guard   *Guard
+	ctx     context.Context
+	upgrade SecurityEventRequest
+	options WebSocketOptions
+}
+
+// Messages returns an inspector for the connection upgraded from r. Pass the request
+// the handler received from the middleware, so message events share the upgrade's
+// client IP, headers, and user (for allow overrides).
+func (g *Guard) Messages(r *http.Request, options *WebSocketOptions) *MessageInspector {
+	var opts WebSocketOptions
+	if options != nil {
+		opts = *options
+	}
+	if opts.SampleRate <= 0 {
+		opts.SampleRate = 1
+	}
+	if opts.VerdictMode == "" {
+		opts.VerdictMode = MessageVerdictClose
+	}
+	if opts.MaxMessageBytes <= 0 {
+		opts.MaxMessageBytes = defaultMaxMessageBytes
+	}
+
+	// Connections often outlive the upgrade handler, which cancels r's context on return
+	i := &MessageInspector{guard: g, ctx: context.WithoutCancel(r.Context()), options: opts}
+	if state := requestStateFromContext(r.Context()); state != nil && state.event != nil {
+		i.upgrade = *state.event
+	} else {
+		client := g.m.client
+		sourceIP, peerIP := client.requestAddresses(r, g.m.trustPeer)
+		i.upgrade = SecurityEventRequest{
+			Path:        r.URL.Path,
+			SourceIP:    sourceIP,
+			PeerIP:      peerIP,
+			UserAgent:   r.UserAgent(),
+			Headers:     client.extractHeaders(r.Header),
+			QueryParams: r.URL.RawQuery,
+			CustomerID:  client.config.CustomerID,
+			HasAuth:     client.hasAuthHeaders(r.Header),
+			SessionID:   client.sessionID,
+		}
+	}
+	return i
+}
+
+// Inspect analyzes a received message and returns nil if it may be processed, or the
+// rejection if not; see CloseOnReject for what to do with a refused message
+func (i *MessageInspector) Inspect(data []byte, binary bool) *Rejection {
+	if binary && !i.options.InspectBinary {
+		return nil
+	}
+	if i.options.SampleRate < 1 && rand.Float64() >= i.options.SampleRate {
+		return nil
+	}
+
+	event := i.upgrade
+	event.EventID = i.guard.m.client.newEventID()
+	event.Method = MethodWebSocketMessage
+	event.Headers = make(map[string]string, len(i.upgrade.Headers))
+	for name, value := range i.upgrade.Headers {
+		event.Headers[name] = value
+	}
+	event.BodyTruncated = len(data) > i.options.MaxMessageBytes
+	if event.BodyTruncated {
+		data = data[:i.options.MaxMessageBytes]
+	}
+	event.RequestBody = string(data)
+
+	_, rejection := i.guard.Check(i.ctx, &event)
+	if rejection != nil {
+		i.guard.m.client.log("🚫 WebSocket message refused:", event.Path, rejection.Message)
+	}
+	return rejection
+}
+
+// CloseOnReject reports whether a refused message must close the connection rather
+// than be skipped
+func (i *MessageInspector) CloseOnReject() bool {
+	return i.options.VerdictMode != MessageVerdictDrop
+}
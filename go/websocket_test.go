@@ -0,0 +1,37 @@
+package guardial
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestMessageEventIDs(t *testing.T) {
+	var mu sync.Mutex
+	seen := map[string]bool{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var event SecurityEventRequest
+		json.NewDecoder(r.Body).Decode(&event)
+		mu.Lock()
+		seen[event.EventID] = true
+		mu.Unlock()
+		w.Write([]byte(allowedVerdict))
+	})
+	guard := NewGuard(client, nil)
+	inspector := guard.Messages(httptest.NewRequest("GET", "/ws", nil), nil)
+
+	const messages = 3
+	for i := 0; i < messages; i++ {
+		if rejection := inspector.Inspect([]byte(`{"op":"ping"}`), false); rejection != nil {
+			t.Fatalf("Inspect() = %+v, want nil", rejection)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(seen) != messages || seen[""] {
+		t.Errorf("message events carried IDs %v, want %d distinct IDs", seen, messages)
+	}
+}